@@ -0,0 +1,239 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+// ARCCache is an adaptive replacement cache (Megiddo and Modha) bounded by its entry count.
+// It splits the entries between t1, used once lately, and t2, used at least twice, and remembers
+// the keys recently evicted from each of them in the ghost lists b1 and b2. A hit on a ghost key
+// moves the target size p of t1 towards the list which would have kept it, so the cache adapts
+// between recency and frequency. Get, Put and Remove are O(1).
+// It is not safe for concurrent use, see SyncCache.
+type ARCCache struct {
+	capacity int
+	p        int // target size of t1
+	onEvict  EvictCallback
+	items    map[interface{}]*cacheEntry // the ghosts included
+	t1       entryList
+	t2       entryList
+	b1       entryList
+	b2       entryList
+	stats    Stats
+}
+
+var _ CacheInterface = &ARCCache{}
+
+// Instantiates an ARC cache holding up to capacity entries, onEvict may be nil.
+func NewARCCache(capacity int, onEvict EvictCallback) *ARCCache {
+	return &ARCCache{
+		capacity: capacity,
+		onEvict:  onEvict,
+		items:    make(map[interface{}]*cacheEntry),
+	}
+}
+
+func (cache *ARCCache) Get(key interface{}) (value interface{}, ok bool) {
+	entry := cache.live(key)
+	if entry == nil {
+		cache.stats.Misses++
+		return nil, false
+	}
+	cache.stats.Hits++
+	cache.move(entry, &cache.t2)
+	return entry.value, true
+}
+
+func (cache *ARCCache) Put(key interface{}, value interface{}) bool {
+	if cache.capacity < 1 {
+		return false
+	}
+
+	entry, ok := cache.items[key]
+	switch {
+	case ok && cache.isLive(entry):
+		entry.value = value
+		cache.move(entry, &cache.t2)
+		return true
+	case ok && entry.list == &cache.b1:
+		cache.p = min(cache.p+max(cache.b2.size/cache.b1.size, 1), cache.capacity)
+		cache.replace(false)
+		entry.value = value
+		cache.move(entry, &cache.t2)
+		return true
+	case ok && entry.list == &cache.b2:
+		cache.p = max(cache.p-max(cache.b1.size/cache.b2.size, 1), 0)
+		cache.replace(true)
+		entry.value = value
+		cache.move(entry, &cache.t2)
+		return true
+	}
+
+	total := cache.t1.size + cache.t2.size + cache.b1.size + cache.b2.size
+	switch {
+	case cache.t1.size+cache.b1.size == cache.capacity:
+		if cache.t1.size < cache.capacity {
+			cache.forget(cache.b1.last)
+			cache.replace(false)
+		} else {
+			cache.evict(cache.t1.last)
+			cache.forget(cache.t1.last)
+		}
+	case total >= cache.capacity:
+		if total >= 2*cache.capacity {
+			cache.forget(cache.b2.last)
+		}
+		cache.replace(false)
+	}
+
+	entry = &cacheEntry{key: key, value: value}
+	cache.items[key] = entry
+	cache.move(entry, &cache.t1)
+	return true
+}
+
+// Removes key, a ghost key is forgotten as well.
+func (cache *ARCCache) Remove(key interface{}) bool {
+	entry, ok := cache.items[key]
+	if !ok {
+		return false
+	}
+	live := cache.isLive(entry)
+	cache.forget(entry)
+	return live
+}
+
+func (cache *ARCCache) Peek(key interface{}) (value interface{}, ok bool) {
+	if entry := cache.live(key); entry != nil {
+		return entry.value, true
+	}
+	return nil, false
+}
+
+// Returns the keys used at least twice lately, then the ones used once, the most recently used first in both.
+func (cache *ARCCache) Keys() []interface{} {
+	return cacheKeys(cache)
+}
+
+func (cache *ARCCache) Stats() Stats {
+	return cache.stats
+}
+
+func (cache *ARCCache) Empty() bool {
+	return cache.Len() == 0
+}
+
+func (cache *ARCCache) Len() int {
+	return cache.t1.size + cache.t2.size
+}
+
+// check if the keys are cached, ghost keys are not
+func (cache *ARCCache) Contains(keys ...interface{}) bool {
+	for _, key := range keys {
+		if cache.live(key) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes all entries and ghost keys, the statistics are kept.
+func (cache *ARCCache) Clear() {
+	cache.items = make(map[interface{}]*cacheEntry)
+	cache.t1, cache.t2, cache.b1, cache.b2 = entryList{}, entryList{}, entryList{}, entryList{}
+	cache.p = 0
+}
+
+// Returns the values in the order of Keys.
+func (cache *ARCCache) Elements() []interface{} {
+	return cacheElements(cache)
+}
+
+// Returns a stateful iterator over the entries in the order of Keys.
+func (cache *ARCCache) Iterator() container.Iterator {
+	return &cacheIterator{
+		first: func() *cacheEntry {
+			if cache.t2.first != nil {
+				return cache.t2.first
+			}
+			return cache.t1.first
+		},
+		next: func(entry *cacheEntry) *cacheEntry {
+			if entry.next == nil && entry.list == &cache.t2 {
+				return cache.t1.first
+			}
+			return entry.next
+		},
+	}
+}
+
+func (cache *ARCCache) String() string {
+	return cacheString("ARCCache", cache)
+}
+
+// the cached entry of key, or nil if key is not cached or only a ghost
+func (cache *ARCCache) live(key interface{}) *cacheEntry {
+	if entry, ok := cache.items[key]; ok && cache.isLive(entry) {
+		return entry
+	}
+	return nil
+}
+
+func (cache *ARCCache) isLive(entry *cacheEntry) bool {
+	return entry.list == &cache.t1 || entry.list == &cache.t2
+}
+
+// Makes room in a full cache by turning the least recently used entry of t1 or t2 into a ghost,
+// t1 is chosen when it is above its target size p.
+func (cache *ARCCache) replace(inB2 bool) {
+	if cache.t1.size+cache.t2.size < cache.capacity {
+		return
+	}
+	if cache.t1.size > 0 && (cache.t1.size > cache.p || inB2 && cache.t1.size == cache.p) || cache.t2.size == 0 {
+		entry := cache.t1.last
+		cache.evict(entry)
+		cache.move(entry, &cache.b1)
+	} else {
+		entry := cache.t2.last
+		cache.evict(entry)
+		cache.move(entry, &cache.b2)
+	}
+}
+
+// reports the eviction of a cached entry and drops its value
+func (cache *ARCCache) evict(entry *cacheEntry) {
+	cache.stats.Evictions++
+	if cache.onEvict != nil {
+		cache.onEvict(entry.key, entry.value)
+	}
+	entry.value = nil
+}
+
+// moves the entry to the front of list
+func (cache *ARCCache) move(entry *cacheEntry, list *entryList) {
+	if entry.list != nil {
+		entry.list.remove(entry)
+	}
+	entry.list = list
+	list.pushFront(entry)
+}
+
+// drops the entry, cached or ghost, altogether
+func (cache *ARCCache) forget(entry *cacheEntry) {
+	delete(cache.items, entry.key)
+	entry.list.remove(entry)
+	entry.list = nil
+}
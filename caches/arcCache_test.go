@@ -0,0 +1,108 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestARCCache(t *testing.T) {
+	evicted := []interface{}{}
+	cache := NewARCCache(3, func(key, value interface{}) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put(1, "a")
+	cache.Put(2, "b")
+	cache.Put(3, "c")
+	cache.Get(1)      // 1 moves to t2
+	cache.Put(4, "d") // evicts 2, the oldest of t1
+
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys()), "[1 4 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(evicted), "[2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// 2 is a ghost now, not cached
+	if _, ok := cache.Get(2); ok || cache.Contains(2) {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	// putting the ghost back grows the target of t1 and lands in t2
+	cache.Put(2, "B")
+	if cache.p != 1 {
+		t.Errorf("Got %v expected %v", cache.p, 1)
+	}
+	if value, ok := cache.Peek(2); value != "B" || !ok {
+		t.Errorf("Got %v expected %v", value, "B")
+	}
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys(), cache.Len()), "[2 1 4] 3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := cache.Stats(), (Stats{Hits: 1, Misses: 1, Evictions: 2}); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !cache.Remove(1) || cache.Remove(3) || cache.Len() != 2 {
+		t.Errorf("Remove error, got %v", cache)
+	}
+
+	cache.Clear()
+	if !cache.Empty() || len(cache.items) != 0 {
+		t.Errorf("Got %v expected %v", cache.Len(), 0)
+	}
+}
+
+func TestARCCacheRandom(t *testing.T) {
+	const capacity = 16
+	cache := NewARCCache(capacity, nil)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		key := random.Intn(64)
+		switch random.Intn(4) {
+		case 0:
+			cache.Remove(key)
+		case 1:
+			cache.Get(key)
+		default:
+			cache.Put(key, key)
+		}
+
+		live := cache.t1.size + cache.t2.size
+		if live > capacity || cache.t1.size+cache.b1.size > capacity || live+cache.b1.size+cache.b2.size > 2*capacity {
+			t.Fatalf("Got %v %v %v %v over capacity %v", cache.t1.size, cache.t2.size, cache.b1.size, cache.b2.size, capacity)
+		}
+		if len(cache.items) != live+cache.b1.size+cache.b2.size || cache.p < 0 || cache.p > capacity {
+			t.Fatalf("Got %v items, p %v", len(cache.items), cache.p)
+		}
+		if value, ok := cache.Peek(key); ok && value != key {
+			t.Fatalf("Got %v expected %v", value, key)
+		}
+	}
+}
+
+func BenchmarkARCCache(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		cache := NewARCCache(100, nil)
+		for n := 0; n < 1000; n++ {
+			cache.Put(n, n)
+			cache.Get(n / 2)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package caches provides bounded key/value stores: LRUCache, LFUCache and ARCCache.
+// LRUCache and LFUCache bound the total weight of their entries, given by a WeightFunction.
+// ARCCache only bounds the entry count, as its adaptation compares the sizes of its lists and
+// ghost lists in entries, so it takes no WeightFunction.
+package caches
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"strings"
+)
+
+// CacheInterface is a bounded key/value store which evicts entries by its policy when full.
+// Contains, Elements and the iterators work on the keys and values without counting as accesses.
+type CacheInterface interface {
+	// Returns the value of key, the lookup counts as a hit or a miss.
+	Get(key interface{}) (value interface{}, ok bool)
+	// Puts the value under key, evicting other entries if needed.
+	// Returns false if the entry alone does not fit in the cache, it is not stored then.
+	Put(key interface{}, value interface{}) bool
+	// Removes key, without calling the eviction callback. Returns false if key was not cached.
+	Remove(key interface{}) bool
+	// Returns the value of key without updating its recency, frequency or the statistics.
+	Peek(key interface{}) (value interface{}, ok bool)
+	// Returns the cached keys, the most valuable ones by the eviction policy first.
+	Keys() []interface{}
+	// Returns the hit/miss statistics.
+	Stats() Stats
+	container.ContainerInterface
+}
+
+// Stats counts the lookups and evictions of a cache.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// Returns the share of lookups which were hits, 0 if there was no lookup.
+func (stats Stats) HitRate() float64 {
+	lookups := stats.Hits + stats.Misses
+	if lookups == 0 {
+		return 0
+	}
+	return float64(stats.Hits) / float64(lookups)
+}
+
+// WeightFunction tells how much of the capacity an entry takes, e.g. its size in bytes.
+// A nil WeightFunction weighs every entry 1, so the capacity bounds the entry count.
+type WeightFunction func(key, value interface{}) int
+
+// EvictCallback is called for every entry the cache evicts to make room,
+// not for the entries removed by Remove or Clear.
+type EvictCallback func(key, value interface{})
+
+type cacheEntry struct {
+	key    interface{}
+	value  interface{}
+	weight int
+	prev   *cacheEntry
+	next   *cacheEntry
+	// the frequency bucket of an LFU entry, the list of an ARC entry
+	bucket *frequencyBucket
+	list   *entryList
+}
+
+// entryList is a doubly linked list of entries, the most recently used first.
+// Unlike lists.DoublyLinkedList it hands out its nodes, so entries are moved and removed in O(1).
+type entryList struct {
+	first *cacheEntry
+	last  *cacheEntry
+	size  int
+}
+
+func (list *entryList) pushFront(entry *cacheEntry) {
+	entry.prev, entry.next = nil, list.first
+	if list.first == nil {
+		list.last = entry
+	} else {
+		list.first.prev = entry
+	}
+	list.first = entry
+	list.size++
+}
+
+func (list *entryList) remove(entry *cacheEntry) {
+	if entry.prev == nil {
+		list.first = entry.next
+	} else {
+		entry.prev.next = entry.next
+	}
+	if entry.next == nil {
+		list.last = entry.prev
+	} else {
+		entry.next.prev = entry.prev
+	}
+	entry.prev, entry.next = nil, nil
+	list.size--
+}
+
+func (list *entryList) moveToFront(entry *cacheEntry) {
+	if entry != list.first {
+		list.remove(entry)
+		list.pushFront(entry)
+	}
+}
+
+// weighs an entry, 1 without a weigher
+func weigh(weigher WeightFunction, key, value interface{}) int {
+	if weigher == nil {
+		return 1
+	}
+	return weigher(key, value)
+}
+
+// cacheIterator walks the entries of a cache through the given step function.
+type cacheIterator struct {
+	first func() *cacheEntry
+	next  func(*cacheEntry) *cacheEntry
+	entry *cacheEntry
+	done  bool
+}
+
+func (it *cacheIterator) Next() bool {
+	switch {
+	case it.done:
+		return false
+	case it.entry == nil:
+		it.entry = it.first()
+	default:
+		it.entry = it.next(it.entry)
+	}
+	it.done = it.entry == nil
+	return !it.done
+}
+
+func (it *cacheIterator) Value() interface{} {
+	return it.entry.value
+}
+
+func (it *cacheIterator) Key() interface{} {
+	return it.entry.key
+}
+
+func (it *cacheIterator) Reset() {
+	it.entry, it.done = nil, false
+}
+
+// collects the keys of a cache in iteration order
+func cacheKeys(cache CacheInterface) []interface{} {
+	keys := make([]interface{}, 0, cache.Len())
+	for it := cache.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// collects the values of a cache in iteration order
+func cacheElements(cache CacheInterface) []interface{} {
+	values := make([]interface{}, 0, cache.Len())
+	for it := cache.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func cacheString(name string, cache CacheInterface) string {
+	str := name + "{ "
+	values := []string{}
+	for it := cache.Iterator(); it.Next(); {
+		values = append(values, fmt.Sprintf("%v:%v", it.Key(), it.Value()))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
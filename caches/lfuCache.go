@@ -0,0 +1,263 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+// frequencyBucket holds the entries used the same number of times, the most recently used first.
+type frequencyBucket struct {
+	frequency int
+	entries   entryList
+	prev      *frequencyBucket
+	next      *frequencyBucket
+}
+
+// LFUCache evicts the least frequently used entries once the total weight exceeds the capacity,
+// the least recently used one among equally frequent entries.
+// The entries sit in a list of frequency buckets, so Get, Put and Remove are O(1).
+// It is not safe for concurrent use, see SyncCache.
+type LFUCache struct {
+	capacity int
+	weight   int
+	weigher  WeightFunction
+	onEvict  EvictCallback
+	items    map[interface{}]*cacheEntry
+	first    *frequencyBucket // lowest frequency
+	last     *frequencyBucket // highest frequency
+	stats    Stats
+}
+
+var _ CacheInterface = &LFUCache{}
+
+// Instantiates an LFU cache holding up to capacity of weight, weigher and onEvict may be nil.
+func NewLFUCache(capacity int, weigher WeightFunction, onEvict EvictCallback) *LFUCache {
+	return &LFUCache{
+		capacity: capacity,
+		weigher:  weigher,
+		onEvict:  onEvict,
+		items:    make(map[interface{}]*cacheEntry),
+	}
+}
+
+func (cache *LFUCache) Get(key interface{}) (value interface{}, ok bool) {
+	entry, ok := cache.items[key]
+	if !ok {
+		cache.stats.Misses++
+		return nil, false
+	}
+	cache.stats.Hits++
+	cache.touch(entry)
+	return entry.value, true
+}
+
+// Putting an existing key counts as a use of it.
+// An older value of key is dropped even when the new one does not fit.
+func (cache *LFUCache) Put(key interface{}, value interface{}) bool {
+	weight := weigh(cache.weigher, key, value)
+	if weight > cache.capacity {
+		cache.Remove(key)
+		return false
+	}
+
+	entry, ok := cache.items[key]
+	if ok {
+		cache.weight -= entry.weight
+		entry.value, entry.weight = value, weight
+	}
+	for cache.weight+weight > cache.capacity {
+		cache.evict(cache.victim(entry))
+	}
+	cache.weight += weight
+
+	if ok {
+		cache.touch(entry)
+		return true
+	}
+
+	entry = &cacheEntry{key: key, value: value, weight: weight}
+	cache.items[key] = entry
+	if cache.first == nil || cache.first.frequency != 1 {
+		cache.insertBucket(nil, 1)
+	}
+	entry.bucket = cache.first
+	cache.first.entries.pushFront(entry)
+	return true
+}
+
+func (cache *LFUCache) Remove(key interface{}) bool {
+	entry, ok := cache.items[key]
+	if ok {
+		cache.remove(entry)
+	}
+	return ok
+}
+
+func (cache *LFUCache) Peek(key interface{}) (value interface{}, ok bool) {
+	if entry, ok := cache.items[key]; ok {
+		return entry.value, true
+	}
+	return nil, false
+}
+
+// Returns the keys from the most frequently used to the least frequently used.
+func (cache *LFUCache) Keys() []interface{} {
+	return cacheKeys(cache)
+}
+
+// Returns how many times key was used, 0 if it is not cached.
+func (cache *LFUCache) Frequency(key interface{}) int {
+	if entry, ok := cache.items[key]; ok {
+		return entry.bucket.frequency
+	}
+	return 0
+}
+
+func (cache *LFUCache) Stats() Stats {
+	return cache.stats
+}
+
+// Returns the total weight of the entries, their number without a weigher.
+func (cache *LFUCache) Weight() int {
+	return cache.weight
+}
+
+func (cache *LFUCache) Empty() bool {
+	return cache.Len() == 0
+}
+
+func (cache *LFUCache) Len() int {
+	return len(cache.items)
+}
+
+// check if the keys are cached
+func (cache *LFUCache) Contains(keys ...interface{}) bool {
+	for _, key := range keys {
+		if _, ok := cache.items[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes all entries, the statistics are kept.
+func (cache *LFUCache) Clear() {
+	cache.items = make(map[interface{}]*cacheEntry)
+	cache.first, cache.last = nil, nil
+	cache.weight = 0
+}
+
+// Returns the values in the order of Keys.
+func (cache *LFUCache) Elements() []interface{} {
+	return cacheElements(cache)
+}
+
+// Returns a stateful iterator from the most frequently used entry to the least frequently used one.
+func (cache *LFUCache) Iterator() container.Iterator {
+	return &cacheIterator{
+		first: func() *cacheEntry {
+			if cache.last == nil {
+				return nil
+			}
+			return cache.last.entries.first
+		},
+		next: func(entry *cacheEntry) *cacheEntry {
+			if entry.next != nil {
+				return entry.next
+			}
+			if bucket := entry.bucket.prev; bucket != nil {
+				return bucket.entries.first
+			}
+			return nil
+		},
+	}
+}
+
+func (cache *LFUCache) String() string {
+	return cacheString("LFUCache", cache)
+}
+
+// moves the entry to the bucket of the next frequency
+func (cache *LFUCache) touch(entry *cacheEntry) {
+	bucket := entry.bucket
+	if bucket.next == nil || bucket.next.frequency != bucket.frequency+1 {
+		cache.insertBucket(bucket, bucket.frequency+1)
+	}
+	bucket.entries.remove(entry)
+	entry.bucket = bucket.next
+	entry.bucket.entries.pushFront(entry)
+	if bucket.entries.size == 0 {
+		cache.removeBucket(bucket)
+	}
+}
+
+// the least recently used entry of the lowest frequency, other than the excluded one
+func (cache *LFUCache) victim(exclude *cacheEntry) *cacheEntry {
+	for bucket := cache.first; bucket != nil; bucket = bucket.next {
+		for entry := bucket.entries.last; entry != nil; entry = entry.prev {
+			if entry != exclude {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+func (cache *LFUCache) evict(entry *cacheEntry) {
+	cache.remove(entry)
+	cache.stats.Evictions++
+	if cache.onEvict != nil {
+		cache.onEvict(entry.key, entry.value)
+	}
+}
+
+func (cache *LFUCache) remove(entry *cacheEntry) {
+	delete(cache.items, entry.key)
+	entry.bucket.entries.remove(entry)
+	if entry.bucket.entries.size == 0 {
+		cache.removeBucket(entry.bucket)
+	}
+	cache.weight -= entry.weight
+}
+
+// inserts an empty bucket after prev, or first if prev is nil
+func (cache *LFUCache) insertBucket(prev *frequencyBucket, frequency int) {
+	bucket := &frequencyBucket{frequency: frequency, prev: prev}
+	if prev == nil {
+		bucket.next = cache.first
+		cache.first = bucket
+	} else {
+		bucket.next = prev.next
+		prev.next = bucket
+	}
+	if bucket.next == nil {
+		cache.last = bucket
+	} else {
+		bucket.next.prev = bucket
+	}
+}
+
+func (cache *LFUCache) removeBucket(bucket *frequencyBucket) {
+	if bucket.prev == nil {
+		cache.first = bucket.next
+	} else {
+		bucket.prev.next = bucket.next
+	}
+	if bucket.next == nil {
+		cache.last = bucket.prev
+	} else {
+		bucket.next.prev = bucket.prev
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLFUCache(t *testing.T) {
+	evicted := []interface{}{}
+	cache := NewLFUCache(3, nil, func(key, value interface{}) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put(1, "a")
+	cache.Put(2, "b")
+	cache.Put(3, "c")
+	cache.Get(1)
+	cache.Get(1)
+	cache.Get(2)
+	cache.Put(4, "d") // evicts 3, used once
+	cache.Put(5, "e") // evicts 4, used as often as 3 but before it
+
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys()), "[1 2 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(evicted), "[3 4]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := cache.Frequency(1); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	// putting an existing key is a use, peeking is not
+	cache.Put(5, "E")
+	cache.Peek(5)
+	if actualValue := cache.Frequency(5); actualValue != 2 {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys(), cache.Elements()), "[1 5 2] [a E b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := cache.Stats(), (Stats{Hits: 3, Misses: 0, Evictions: 2}); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !cache.Remove(1) || cache.Remove(1) || cache.Frequency(1) != 0 {
+		t.Errorf("Remove error, got %v", cache)
+	}
+	if !cache.Contains(2, 5) || cache.Contains(1) || cache.Len() != 2 {
+		t.Errorf("Contains error, got %v", cache)
+	}
+
+	cache.Clear()
+	if !cache.Empty() || cache.Weight() != 0 || len(cache.Keys()) != 0 {
+		t.Errorf("Got %v expected %v", cache.Len(), 0)
+	}
+}
+
+func TestLFUCacheWeight(t *testing.T) {
+	cache := NewLFUCache(10, func(key, value interface{}) int {
+		return len(value.(string))
+	}, nil)
+
+	cache.Put("a", "xxxx")
+	cache.Put("b", "xxxx")
+	cache.Get("a")
+
+	// b is less frequent than a, it makes room for the bigger a
+	cache.Put("a", "xxxxxxxx")
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys(), cache.Weight()), "[a] 8"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if cache.Put("c", "xxxxxxxxxxx") || cache.Contains("c") {
+		t.Errorf("Got %v expected %v", cache, "no c")
+	}
+}
+
+func BenchmarkLFUCache(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		cache := NewLFUCache(100, nil, nil)
+		for n := 0; n < 1000; n++ {
+			cache.Put(n, n)
+			cache.Get(n / 2)
+		}
+	}
+}
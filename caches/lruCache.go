@@ -0,0 +1,164 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+// LRUCache evicts the least recently used entries once the total weight exceeds the capacity.
+// Get, Put and Remove are O(1). It is not safe for concurrent use, see SyncCache.
+type LRUCache struct {
+	capacity int
+	weight   int
+	weigher  WeightFunction
+	onEvict  EvictCallback
+	items    map[interface{}]*cacheEntry
+	list     entryList
+	stats    Stats
+}
+
+var _ CacheInterface = &LRUCache{}
+
+// Instantiates an LRU cache holding up to capacity of weight, weigher and onEvict may be nil.
+func NewLRUCache(capacity int, weigher WeightFunction, onEvict EvictCallback) *LRUCache {
+	return &LRUCache{
+		capacity: capacity,
+		weigher:  weigher,
+		onEvict:  onEvict,
+		items:    make(map[interface{}]*cacheEntry),
+	}
+}
+
+func (cache *LRUCache) Get(key interface{}) (value interface{}, ok bool) {
+	entry, ok := cache.items[key]
+	if !ok {
+		cache.stats.Misses++
+		return nil, false
+	}
+	cache.stats.Hits++
+	cache.list.moveToFront(entry)
+	return entry.value, true
+}
+
+// An older value of key is dropped even when the new one does not fit.
+func (cache *LRUCache) Put(key interface{}, value interface{}) bool {
+	weight := weigh(cache.weigher, key, value)
+	if weight > cache.capacity {
+		cache.Remove(key)
+		return false
+	}
+
+	if entry, ok := cache.items[key]; ok {
+		cache.weight += weight - entry.weight
+		entry.value, entry.weight = value, weight
+		cache.list.moveToFront(entry)
+	} else {
+		entry := &cacheEntry{key: key, value: value, weight: weight}
+		cache.items[key] = entry
+		cache.list.pushFront(entry)
+		cache.weight += weight
+	}
+
+	// the new entry is the most recent one, so it is never the victim
+	for cache.weight > cache.capacity {
+		cache.evict(cache.list.last)
+	}
+	return true
+}
+
+func (cache *LRUCache) Remove(key interface{}) bool {
+	entry, ok := cache.items[key]
+	if ok {
+		cache.remove(entry)
+	}
+	return ok
+}
+
+func (cache *LRUCache) Peek(key interface{}) (value interface{}, ok bool) {
+	if entry, ok := cache.items[key]; ok {
+		return entry.value, true
+	}
+	return nil, false
+}
+
+// Returns the keys from the most recently used to the least recently used.
+func (cache *LRUCache) Keys() []interface{} {
+	return cacheKeys(cache)
+}
+
+func (cache *LRUCache) Stats() Stats {
+	return cache.stats
+}
+
+// Returns the total weight of the entries, their number without a weigher.
+func (cache *LRUCache) Weight() int {
+	return cache.weight
+}
+
+func (cache *LRUCache) Empty() bool {
+	return cache.Len() == 0
+}
+
+func (cache *LRUCache) Len() int {
+	return len(cache.items)
+}
+
+// check if the keys are cached
+func (cache *LRUCache) Contains(keys ...interface{}) bool {
+	for _, key := range keys {
+		if _, ok := cache.items[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes all entries, the statistics are kept.
+func (cache *LRUCache) Clear() {
+	cache.items = make(map[interface{}]*cacheEntry)
+	cache.list = entryList{}
+	cache.weight = 0
+}
+
+// Returns the values in the order of Keys.
+func (cache *LRUCache) Elements() []interface{} {
+	return cacheElements(cache)
+}
+
+// Returns a stateful iterator from the most recently used entry to the least recently used one.
+func (cache *LRUCache) Iterator() container.Iterator {
+	return &cacheIterator{
+		first: func() *cacheEntry { return cache.list.first },
+		next:  func(entry *cacheEntry) *cacheEntry { return entry.next },
+	}
+}
+
+func (cache *LRUCache) String() string {
+	return cacheString("LRUCache", cache)
+}
+
+func (cache *LRUCache) evict(entry *cacheEntry) {
+	cache.remove(entry)
+	cache.stats.Evictions++
+	if cache.onEvict != nil {
+		cache.onEvict(entry.key, entry.value)
+	}
+}
+
+func (cache *LRUCache) remove(entry *cacheEntry) {
+	delete(cache.items, entry.key)
+	cache.list.remove(entry)
+	cache.weight -= entry.weight
+}
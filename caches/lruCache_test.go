@@ -0,0 +1,111 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLRUCache(t *testing.T) {
+	evicted := []interface{}{}
+	cache := NewLRUCache(3, nil, func(key, value interface{}) {
+		evicted = append(evicted, key)
+	})
+
+	cache.Put(1, "a")
+	cache.Put(2, "b")
+	cache.Put(3, "c")
+	if value, ok := cache.Get(1); value != "a" || !ok {
+		t.Errorf("Got %v expected %v", value, "a")
+	}
+	cache.Put(4, "d") // evicts 2, the least recently used
+
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys()), "[4 1 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(evicted), "[2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, ok := cache.Get(2); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	// Peek does not refresh 3, so it goes next
+	if value, ok := cache.Peek(3); value != "c" || !ok {
+		t.Errorf("Got %v expected %v", value, "c")
+	}
+	cache.Put(1, "A")
+	cache.Put(5, "e")
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys(), cache.Elements()), "[5 1 4] [e A d]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := cache.Stats(), (Stats{Hits: 1, Misses: 1, Evictions: 2}); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := cache.Stats().HitRate(); actualValue != 0.5 {
+		t.Errorf("Got %v expected %v", actualValue, 0.5)
+	}
+
+	if !cache.Contains(1, 4, 5) || cache.Contains(3) {
+		t.Errorf("Contains error, got %v", cache)
+	}
+	if !cache.Remove(4) || cache.Remove(4) || cache.Len() != 2 {
+		t.Errorf("Remove error, got %v", cache)
+	}
+	if actualValue, expectedValue := fmt.Sprint(evicted), "[2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	cache.Clear()
+	if !cache.Empty() || cache.Weight() != 0 {
+		t.Errorf("Got %v expected %v", cache.Len(), 0)
+	}
+}
+
+func TestLRUCacheWeight(t *testing.T) {
+	cache := NewLRUCache(10, func(key, value interface{}) int {
+		return len(value.(string))
+	}, nil)
+
+	cache.Put("a", "xxxx")
+	cache.Put("b", "xxxx")
+	cache.Put("c", "xxxx") // 12 > 10, evicts a
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys()), "[c b]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	cache.Put("b", "xxxxxxxx") // grows b, evicts c
+	if actualValue, expectedValue := fmt.Sprint(cache.Keys(), cache.Weight()), "[b] 8"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if cache.Put("b", "xxxxxxxxxxx") {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+	if !cache.Empty() {
+		t.Errorf("Got %v expected %v", cache, "an empty cache")
+	}
+}
+
+func BenchmarkLRUCache(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		cache := NewLRUCache(100, nil, nil)
+		for n := 0; n < 1000; n++ {
+			cache.Put(n, n)
+			cache.Get(n / 2)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"github.com/aiwuTech/container"
+	"sync"
+)
+
+// SyncCache guards any cache with a mutex, so it can be shared between goroutines.
+// A mutex rather than a read-write lock, since even Get updates the cache.
+type SyncCache struct {
+	cache CacheInterface
+	lock  *sync.Mutex
+}
+
+var _ CacheInterface = &SyncCache{}
+
+func NewSyncCache(cache CacheInterface) *SyncCache {
+	return &SyncCache{
+		cache: cache,
+		lock:  &sync.Mutex{},
+	}
+}
+
+func (cache *SyncCache) Get(key interface{}) (value interface{}, ok bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Get(key)
+}
+
+func (cache *SyncCache) Put(key interface{}, value interface{}) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Put(key, value)
+}
+
+func (cache *SyncCache) Remove(key interface{}) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Remove(key)
+}
+
+func (cache *SyncCache) Peek(key interface{}) (value interface{}, ok bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Peek(key)
+}
+
+func (cache *SyncCache) Keys() []interface{} {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Keys()
+}
+
+func (cache *SyncCache) Stats() Stats {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Stats()
+}
+
+func (cache *SyncCache) Empty() bool {
+	return cache.Len() == 0
+}
+
+func (cache *SyncCache) Len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Len()
+}
+
+func (cache *SyncCache) Contains(keys ...interface{}) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Contains(keys...)
+}
+
+func (cache *SyncCache) Clear() {
+	cache.lock.Lock()
+	cache.cache.Clear()
+	cache.lock.Unlock()
+}
+
+func (cache *SyncCache) Elements() []interface{} {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.Elements()
+}
+
+// Returns a stateful iterator of the wrapped cache, the lock is only held while stepping.
+func (cache *SyncCache) Iterator() container.Iterator {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return container.SyncIterator(cache.cache.Iterator(), cache.lock)
+}
+
+func (cache *SyncCache) String() string {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.cache.String()
+}
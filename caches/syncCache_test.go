@@ -0,0 +1,54 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package caches
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncCache(t *testing.T) {
+	cache := NewSyncCache(NewLRUCache(100, nil, nil))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for n := 0; n < 1000; n++ {
+				cache.Put(g*1000+n, n)
+				cache.Get(g*1000 + n/2)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if cache.Len() != 100 {
+		t.Errorf("Got %v expected %v", cache.Len(), 100)
+	}
+	if stats := cache.Stats(); stats.Hits+stats.Misses != 8000 || stats.Evictions != 7900 {
+		t.Errorf("Got %v expected %v lookups and %v evictions", stats, 8000, 7900)
+	}
+
+	count := 0
+	for it := cache.Iterator(); it.Next(); {
+		if value, ok := cache.Peek(it.Key()); !ok || value != it.Value() {
+			t.Errorf("Got %v expected %v", value, it.Value())
+		}
+		count++
+	}
+	if count != 100 {
+		t.Errorf("Got %v expected %v", count, 100)
+	}
+}
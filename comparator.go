@@ -14,6 +14,7 @@
 package container
 
 import (
+	"cmp"
 	"reflect"
 )
 
@@ -124,3 +125,26 @@ func StringCompareFunction(e1, e2 interface{}) int8 {
 	}
 	return 0
 }
+
+// Comparator is the type-parameterized counterpart of CompareFunction, used by
+// the generic containers (ArrayListOf, RBTreeOf, BinaryHeapOf, ...).
+// It follows the same contract: <0, 0, >0 for less, equal and greater.
+type Comparator[T any] func(T, T) int8
+
+// ascending comparator for any ordered type
+func OrderedCompareFunctionASC[T cmp.Ordered](e1, e2 T) int8 {
+	return int8(cmp.Compare(e1, e2))
+}
+
+// descending comparator for any ordered type
+func OrderedCompareFunctionDESC[T cmp.Ordered](e1, e2 T) int8 {
+	return -OrderedCompareFunctionASC(e1, e2)
+}
+
+// Untyped adapts the comparator to a CompareFunction, so generic callers can
+// still hand it to the interface{} based containers.
+func (comparator Comparator[T]) Untyped() CompareFunction {
+	return func(e1, e2 interface{}) int8 {
+		return comparator(e1.(T), e2.(T))
+	}
+}
@@ -13,7 +13,10 @@
 // under the License.
 package container
 
-import "reflect"
+import (
+	"iter"
+	"reflect"
+)
 
 type ContainerInterface interface {
 	Empty() bool
@@ -22,6 +25,83 @@ type ContainerInterface interface {
 	Clear()
 	Elements() []interface{}
 	String() string
+	Iterator() Iterator
+}
+
+// Iterator walks a container one element at a time, without copying its content.
+// A fresh (or Reset) iterator is positioned before the first element, so Next must be
+// called before Key and Value. The container must not be modified while iterating.
+type Iterator interface {
+	// moves to the next element, returns false when there is no more element
+	Next() bool
+	// value of the current element
+	Value() interface{}
+	// key of the current element: the index for lists, stacks and heaps,
+	// the key for trees and maps, the element itself for sets
+	Key() interface{}
+	// moves back before the first element
+	Reset()
+}
+
+// ReverseIterator is an Iterator which can also walk backwards,
+// provided by the containers whose structure allows it.
+type ReverseIterator interface {
+	Iterator
+	// moves to the previous element, returns false when there is no more element
+	Prev() bool
+	// moves past the last element, so Prev walks from the last one
+	End()
+}
+
+// Seq2 turns an iterator constructor into a range-over-func sequence of key/value pairs,
+// e.g. container.Seq2(tree.Iterator). Every range loop gets a fresh iterator, and
+// breaking out of the loop early leaves nothing behind.
+func Seq2(newIterator func() Iterator) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		it := newIterator()
+		for it.Next() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// BackwardSeq2 is like Seq2, but walks from the last element to the first one.
+func BackwardSeq2(newIterator func() ReverseIterator) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		it := newIterator()
+		it.End()
+		for it.Prev() {
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// KeySeq is like Seq2, but only yields the keys.
+func KeySeq(newIterator func() Iterator) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		it := newIterator()
+		for it.Next() {
+			if !yield(it.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// ValueSeq is like Seq2, but only yields the values.
+func ValueSeq(newIterator func() Iterator) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		it := newIterator()
+		for it.Next() {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
 }
 
 func Contains(element interface{}, target interface{}) bool {
@@ -0,0 +1,290 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package deques
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/lists"
+	"sort"
+	"strings"
+)
+
+const (
+	_BLOCK_SIZE = 64
+	_MIN_BLOCKS = 4
+)
+
+// Deque is a double-ended queue backed by a ring of fixed size blocks.
+// Pushing or popping at either end is O(1) and only allocates once per block,
+// and At(i) is O(1) as well.
+//
+// The elements occupy the positions [firstOffset, firstOffset+size) of the
+// blockCount blocks starting at blocks[firstBlock], no more blocks are kept.
+type Deque struct {
+	blocks      [][]interface{} // ring of blocks, its length is a power of two
+	firstBlock  int
+	firstOffset int
+	blockCount  int
+	size        int
+}
+
+var _ lists.ListInterface = &Deque{}
+
+func NewDeque() *Deque {
+	return &Deque{firstOffset: _BLOCK_SIZE / 2}
+}
+
+// Adds a value at the back of the deque
+func (deque *Deque) PushBack(value interface{}) {
+	if (deque.firstOffset+deque.size)/_BLOCK_SIZE == deque.blockCount {
+		deque.addBlockBack()
+	}
+	block, offset := deque.slot(deque.size)
+	block[offset] = value
+	deque.size++
+}
+
+// Adds a value at the front of the deque
+func (deque *Deque) PushFront(value interface{}) {
+	if deque.firstOffset == 0 || deque.blockCount == 0 {
+		deque.addBlockFront()
+		deque.firstOffset = _BLOCK_SIZE
+	}
+	deque.firstOffset--
+	block, offset := deque.slot(0)
+	block[offset] = value
+	deque.size++
+}
+
+// Removes the back element and returns it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to pop.
+func (deque *Deque) PopBack() (value interface{}, ok bool) {
+	if deque.size == 0 {
+		return nil, false
+	}
+	block, offset := deque.slot(deque.size - 1)
+	value = block[offset]
+	block[offset] = nil
+	deque.size--
+
+	if deque.size == 0 {
+		deque.firstOffset = _BLOCK_SIZE / 2
+	} else if (deque.firstOffset+deque.size)%_BLOCK_SIZE == 0 {
+		deque.blockCount--
+		deque.blocks[deque.ring(deque.blockCount)] = nil
+	}
+	return value, true
+}
+
+// Removes the front element and returns it, or nil if deque is empty.
+// Second return parameter is true, unless the deque was empty and there was nothing to pop.
+func (deque *Deque) PopFront() (value interface{}, ok bool) {
+	if deque.size == 0 {
+		return nil, false
+	}
+	block, offset := deque.slot(0)
+	value = block[offset]
+	block[offset] = nil
+	deque.firstOffset++
+	deque.size--
+
+	if deque.size == 0 {
+		deque.firstOffset = _BLOCK_SIZE / 2
+	} else if deque.firstOffset == _BLOCK_SIZE {
+		deque.blocks[deque.firstBlock] = nil
+		deque.firstBlock = deque.ring(1)
+		deque.blockCount--
+		deque.firstOffset = 0
+	}
+	return value, true
+}
+
+// Returns the front element without removing it, or nil if deque is empty.
+func (deque *Deque) Front() (value interface{}, ok bool) {
+	return deque.At(0)
+}
+
+// Returns the back element without removing it, or nil if deque is empty.
+func (deque *Deque) Back() (value interface{}, ok bool) {
+	return deque.At(deque.size - 1)
+}
+
+// Returns the element at index, counted from the front.
+// Second return parameter is true if index is within bounds of the deque, otherwise false.
+func (deque *Deque) At(idx int) (interface{}, bool) {
+	if !deque.inRange(idx) {
+		return nil, false
+	}
+	block, offset := deque.slot(idx)
+	return block[offset], true
+}
+
+// Same as At, for lists.ListInterface
+func (deque *Deque) Get(idx int) (interface{}, bool) {
+	return deque.At(idx)
+}
+
+// Same as PushBack for every element, for lists.ListInterface
+func (deque *Deque) Add(elements ...interface{}) {
+	for _, e := range elements {
+		deque.PushBack(e)
+	}
+}
+
+// Removes the element at idx, shifting the elements of the closer end.
+func (deque *Deque) Remove(idx int) {
+	if !deque.inRange(idx) {
+		return
+	}
+
+	if idx < deque.size/2 {
+		for i := idx; i > 0; i-- {
+			deque.set(i, deque.get(i-1))
+		}
+		deque.PopFront()
+	} else {
+		for i := idx; i < deque.size-1; i++ {
+			deque.set(i, deque.get(i+1))
+		}
+		deque.PopBack()
+	}
+}
+
+// check if the elements are in the deque
+func (deque *Deque) Contains(elements ...interface{}) bool {
+	for _, e := range elements {
+		if !deque.contain(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns all elements from the front to the back.
+func (deque *Deque) Elements() []interface{} {
+	elements := make([]interface{}, deque.size, deque.size)
+	for i := range elements {
+		elements[i] = deque.get(i)
+	}
+	return elements
+}
+
+// Returns true if deque does not contain any elements.
+func (deque *Deque) Empty() bool {
+	return deque.size == 0
+}
+
+// Returns number of elements within the deque.
+func (deque *Deque) Len() int {
+	return deque.size
+}
+
+// Removes all elements from the deque.
+func (deque *Deque) Clear() {
+	*deque = Deque{firstOffset: _BLOCK_SIZE / 2}
+}
+
+// Sorts the elements by comparator, does nothing without comparator.
+func (deque *Deque) Sort(comparators ...container.CompareFunction) {
+	if len(comparators) == 0 {
+		return
+	}
+
+	comparator := comparators[0]
+	elements := deque.Elements()
+	sort.SliceStable(elements, func(i, j int) bool {
+		return comparator(elements[i], elements[j]) < 0
+	})
+	for i, e := range elements {
+		deque.set(i, e)
+	}
+}
+
+func (deque *Deque) String() string {
+	str := "Deque{ "
+	values := []string{}
+	for _, value := range deque.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (deque *Deque) contain(e interface{}) bool {
+	for i := 0; i < deque.size; i++ {
+		if deque.get(i) == e {
+			return true
+		}
+	}
+	return false
+}
+
+// check whether the idx is within bounds of the deque
+func (deque *Deque) inRange(idx int) bool {
+	return idx >= 0 && idx < deque.size
+}
+
+func (deque *Deque) get(idx int) interface{} {
+	block, offset := deque.slot(idx)
+	return block[offset]
+}
+
+func (deque *Deque) set(idx int, value interface{}) {
+	block, offset := deque.slot(idx)
+	block[offset] = value
+}
+
+// block and offset of the idx-th element from the front
+func (deque *Deque) slot(idx int) ([]interface{}, int) {
+	position := deque.firstOffset + idx
+	return deque.blocks[deque.ring(position/_BLOCK_SIZE)], position % _BLOCK_SIZE
+}
+
+// ring position of the n-th block in use
+func (deque *Deque) ring(n int) int {
+	return (deque.firstBlock + n) & (len(deque.blocks) - 1)
+}
+
+func (deque *Deque) addBlockBack() {
+	if deque.blockCount == len(deque.blocks) {
+		deque.growRing()
+	}
+	deque.blocks[deque.ring(deque.blockCount)] = make([]interface{}, _BLOCK_SIZE)
+	deque.blockCount++
+}
+
+func (deque *Deque) addBlockFront() {
+	if deque.blockCount == len(deque.blocks) {
+		deque.growRing()
+	}
+	deque.firstBlock = deque.ring(-1)
+	deque.blocks[deque.firstBlock] = make([]interface{}, _BLOCK_SIZE)
+	deque.blockCount++
+}
+
+// doubles the ring of blocks, the blocks themselves are not copied
+func (deque *Deque) growRing() {
+	capacity := 2 * len(deque.blocks)
+	if capacity < _MIN_BLOCKS {
+		capacity = _MIN_BLOCKS
+	}
+	blocks := make([][]interface{}, capacity)
+	for n := 0; n < deque.blockCount; n++ {
+		blocks[n] = deque.blocks[deque.ring(n)]
+	}
+	deque.blocks = blocks
+	deque.firstBlock = 0
+}
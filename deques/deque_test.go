@@ -0,0 +1,206 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package deques
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+
+	deque := NewDeque()
+
+	if actualValue := deque.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	deque.PushBack(2)
+	deque.PushBack(3)
+	deque.PushFront(1)
+
+	if actualValue := deque.Elements(); actualValue[0].(int) != 1 || actualValue[1].(int) != 2 || actualValue[2].(int) != 3 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+	}
+
+	if actualValue := deque.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := deque.Front(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	if actualValue, ok := deque.Back(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := deque.At(1); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	if actualValue, ok := deque.PopFront(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	if actualValue, ok := deque.PopBack(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := deque.PopBack(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	if actualValue, ok := deque.PopFront(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	if actualValue, ok := deque.At(0); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	if actualValue := deque.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// lists.ListInterface
+	deque.Add("e", "f", "g", "a", "b", "c", "d")
+	deque.Sort(container.StringCompareFunction)
+	for i := 1; i < deque.Len(); i++ {
+		a, _ := deque.Get(i - 1)
+		b, _ := deque.Get(i)
+		if a.(string) > b.(string) {
+			t.Errorf("Not sorted! %s > %s", a, b)
+		}
+	}
+
+	deque.Remove(1)
+	deque.Remove(4)
+
+	if actualValue := deque.String(); actualValue != "Deque{ a, c, d, e, g }" {
+		t.Errorf("Got %v expected %v", actualValue, "Deque{ a, c, d, e, g }")
+	}
+
+	if actualValue := deque.Contains("a", "g"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue := deque.Contains("b"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	deque.Clear()
+
+	if actualValue := deque.Len(); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+}
+
+func TestDequeRandom(t *testing.T) {
+
+	deque := NewDeque()
+	reference := []int{}
+
+	rand.Seed(3)
+	for i := 0; i < 20000; i++ {
+		switch rand.Intn(5) {
+		case 0:
+			deque.PushFront(i)
+			reference = append([]int{i}, reference...)
+		case 1:
+			deque.PushBack(i)
+			reference = append(reference, i)
+		case 2:
+			value, ok := deque.PopFront()
+			if ok != (len(reference) > 0) || ok && value != reference[0] {
+				t.Fatalf("Got %v expected %v", value, reference)
+			}
+			if ok {
+				reference = reference[1:]
+			}
+		case 3:
+			value, ok := deque.PopBack()
+			if ok != (len(reference) > 0) || ok && value != reference[len(reference)-1] {
+				t.Fatalf("Got %v expected %v", value, reference)
+			}
+			if ok {
+				reference = reference[:len(reference)-1]
+			}
+		case 4:
+			if len(reference) > 0 {
+				idx := rand.Intn(len(reference))
+				deque.Remove(idx)
+				reference = append(reference[:idx:idx], reference[idx+1:]...)
+			}
+		}
+	}
+
+	if deque.Len() != len(reference) {
+		t.Fatalf("Got %v expected %v", deque.Len(), len(reference))
+	}
+	for idx, value := range deque.All() {
+		if value != reference[idx] {
+			t.Fatalf("Got %v expected %v", value, reference[idx])
+		}
+	}
+
+}
+
+func TestDequeIterator(t *testing.T) {
+
+	deque := NewDeque()
+	for i := 0; i < 200; i++ {
+		deque.PushFront(i)
+	}
+
+	it := deque.ReverseIterator()
+	expected := 199
+	for it.Next() {
+		if it.Value() != expected || it.Key() != 199-expected {
+			t.Errorf("Got %v expected %v", it.Value(), expected)
+		}
+		expected--
+	}
+	for it.Prev() {
+		expected++
+		if it.Value() != expected {
+			t.Errorf("Got %v expected %v", it.Value(), expected)
+		}
+	}
+	if expected != 199 {
+		t.Errorf("Got %v expected %v", expected, 199)
+	}
+
+	for idx, value := range deque.Backward() {
+		if idx != 199 || value != 0 {
+			t.Errorf("Got %v expected %v", value, 0)
+		}
+		break
+	}
+
+}
+
+func BenchmarkDeque(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		deque := NewDeque()
+		for n := 0; n < 1000; n++ {
+			deque.PushBack(i)
+		}
+		for !deque.Empty() {
+			deque.PopFront()
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package deques
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+type dequeIterator struct {
+	deque *Deque
+	index int
+}
+
+// Returns a stateful iterator from the front to the back, positioned before the front element.
+func (deque *Deque) Iterator() container.Iterator {
+	return deque.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the deque from the back.
+func (deque *Deque) ReverseIterator() container.ReverseIterator {
+	return &dequeIterator{deque: deque, index: -1}
+}
+
+func (it *dequeIterator) Next() bool {
+	if it.index < it.deque.size {
+		it.index++
+	}
+	return it.deque.inRange(it.index)
+}
+
+func (it *dequeIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.deque.inRange(it.index)
+}
+
+func (it *dequeIterator) Value() interface{} {
+	return it.deque.get(it.index)
+}
+
+func (it *dequeIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *dequeIterator) Reset() {
+	it.index = -1
+}
+
+func (it *dequeIterator) End() {
+	it.index = it.deque.size
+}
+
+// Returns a range-over-func sequence of (index, element) pairs from the front to the back.
+func (deque *Deque) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for idx := 0; idx < deque.size; idx++ {
+			if !yield(idx, deque.get(idx)) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs from the back to the front.
+func (deque *Deque) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for idx := deque.size - 1; idx >= 0; idx-- {
+			if !yield(idx, deque.get(idx)) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements from the front to the back.
+func (deque *Deque) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for idx := 0; idx < deque.size; idx++ {
+			if !yield(deque.get(idx)) {
+				return
+			}
+		}
+	}
+}
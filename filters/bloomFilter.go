@@ -0,0 +1,199 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// BloomFilter is a bit array in which every item sets k bits chosen by its hash,
+// an item is possibly present when all its bits are set.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    int    // number of bits per item
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*BloomFilter)(nil)
+	_ encoding.BinaryUnmarshaler = (*BloomFilter)(nil)
+)
+
+// Returns a Bloom filter sized to hold n items with a false positive rate of fpRate,
+// fpRate should be in (0, 1), otherwise it panics.
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+	m, k := optimalSize(n, fpRate)
+	return newBloomFilter(m, k)
+}
+
+func newBloomFilter(m uint64, k int) *BloomFilter {
+	return &BloomFilter{bits: make([]uint64, (m+63)/64), m: m, k: k}
+}
+
+// Adds the items to the filter.
+func (filter *BloomFilter) Add(items ...interface{}) {
+	for _, item := range items {
+		locations(hashOf(item), filter.k, filter.m, func(i uint64) bool {
+			filter.bits[i/64] |= 1 << (i % 64)
+			return true
+		})
+	}
+}
+
+// Returns true if all the items may have been added, false if any of them surely was not.
+func (filter *BloomFilter) Contains(items ...interface{}) bool {
+	for _, item := range items {
+		found := true
+		locations(hashOf(item), filter.k, filter.m, func(i uint64) bool {
+			found = filter.bits[i/64]&(1<<(i%64)) != 0
+			return found
+		})
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter *BloomFilter) Clear() {
+	clear(filter.bits)
+}
+
+// Returns true if no item was added.
+func (filter *BloomFilter) Empty() bool {
+	for _, word := range filter.bits {
+		if word != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the number of bits of the filter.
+func (filter *BloomFilter) Cap() int {
+	return int(filter.m)
+}
+
+// Returns the number of hash functions, which is the number of bits set by every item.
+func (filter *BloomFilter) HashCount() int {
+	return filter.k
+}
+
+// Returns an estimation of the number of distinct items added, from the number of set bits.
+func (filter *BloomFilter) EstimatedLen() int {
+	set := 0
+	for _, word := range filter.bits {
+		set += bits.OnesCount64(word)
+	}
+	return estimatedLen(uint64(set), filter.m, filter.k)
+}
+
+// Returns the probability of a false positive in the current state of the filter.
+func (filter *BloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, word := range filter.bits {
+		set += bits.OnesCount64(word)
+	}
+	return math.Pow(float64(set)/float64(filter.m), float64(filter.k))
+}
+
+// Returns a filter containing the items of both filters, it answers exactly as a filter
+// to which the items of both were added. Returns nil when the filters differ in size.
+func (filter *BloomFilter) Union(other *BloomFilter) *BloomFilter {
+	if !filter.compatible(other) {
+		return nil
+	}
+	union := newBloomFilter(filter.m, filter.k)
+	for i := range union.bits {
+		union.bits[i] = filter.bits[i] | other.bits[i]
+	}
+	return union
+}
+
+// Returns a filter containing the items added to both filters. Returns nil when the filters
+// differ in size. Its false positive rate may be higher than one to which only the common
+// items were added, since an item of either filter may find its bits set in the other.
+func (filter *BloomFilter) Intersect(other *BloomFilter) *BloomFilter {
+	if !filter.compatible(other) {
+		return nil
+	}
+	intersection := newBloomFilter(filter.m, filter.k)
+	for i := range intersection.bits {
+		intersection.bits[i] = filter.bits[i] & other.bits[i]
+	}
+	return intersection
+}
+
+func (filter *BloomFilter) compatible(other *BloomFilter) bool {
+	return other != nil && filter.m == other.m && filter.k == other.k
+}
+
+// Encodes the filter as its kind and version, the number of bits and of hash functions,
+// then the bits, in little endian.
+func (filter *BloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+8+4+8*len(filter.bits))
+	data = append(data, bloomFilterKind, version)
+	data = binary.LittleEndian.AppendUint64(data, filter.m)
+	data = binary.LittleEndian.AppendUint32(data, uint32(filter.k))
+	for _, word := range filter.bits {
+		data = binary.LittleEndian.AppendUint64(data, word)
+	}
+	return data, nil
+}
+
+// Replaces the filter with the one encoded by MarshalBinary.
+func (filter *BloomFilter) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, bloomFilterKind)
+	if err != nil {
+		return err
+	}
+	if len(data) < 12 {
+		return ErrInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data)
+	k := int(binary.LittleEndian.Uint32(data[8:]))
+	if m == 0 || k == 0 {
+		return ErrInvalidData
+	}
+	// (m+63)/64 would overflow for m near 2^64
+	count := m / 64
+	if m%64 != 0 {
+		count++
+	}
+	words, rest, err := readUint64s(data[12:], count)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return ErrInvalidData
+	}
+	filter.bits, filter.m, filter.k = words, m, k
+	return nil
+}
+
+func (filter *BloomFilter) String() string {
+	return fmt.Sprintf("BloomFilter{bits: %d, hashes: %d, estimated length: %d}", filter.m, filter.k, filter.EstimatedLen())
+}
+
+// estimatedLen estimates the number of items from the number of set bits (Swamidass and Baldi)
+func estimatedLen(set, m uint64, k int) int {
+	if set >= m {
+		return int(float64(m) / float64(k))
+	}
+	return int(math.Round(-float64(m) / float64(k) * math.Log1p(-float64(set)/float64(m))))
+}
@@ -0,0 +1,128 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestBloomFilter(t *testing.T) {
+
+	filter := NewBloomFilter(10000, 0.01)
+	if !filter.Empty() {
+		t.Errorf("Got %v expected %v", filter.Empty(), true)
+	}
+	for n := 0; n < 10000; n++ {
+		filter.Add(n)
+	}
+	for n := 0; n < 10000; n++ {
+		if !filter.Contains(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+
+	// keys of another type hashing apart from the added ones
+	falsePositives := 0
+	for n := 0; n < 10000; n++ {
+		if filter.Contains(string(rune(n)) + "x") {
+			falsePositives++
+		}
+	}
+	if falsePositives > 200 {
+		t.Errorf("Got %v expected %v", falsePositives, "at most 200")
+	}
+	if rate := filter.FalsePositiveRate(); rate < 0.005 || rate > 0.02 {
+		t.Errorf("Got %v expected %v", rate, 0.01)
+	}
+	if length := filter.EstimatedLen(); length < 9500 || length > 10500 {
+		t.Errorf("Got %v expected %v", length, 10000)
+	}
+
+	// the numeric types hash by value
+	if !filter.Contains(int64(42), uint8(42), 42) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+
+	filter.Clear()
+	if !filter.Empty() || filter.Contains(1) {
+		t.Errorf("Got %v expected %v", filter.Empty(), true)
+	}
+}
+
+func TestBloomFilterUnionIntersect(t *testing.T) {
+	one, other := NewBloomFilter(1000, 0.01), NewBloomFilter(1000, 0.01)
+	one.Add("a", "b", "c")
+	other.Add("b", "c", "d")
+
+	union := one.Union(other)
+	if !union.Contains("a", "b", "c", "d") {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+	intersection := one.Intersect(other)
+	if !intersection.Contains("b", "c") || intersection.Contains("a") || intersection.Contains("d") {
+		t.Errorf("Got %v expected %v", intersection, "b, c")
+	}
+	if one.Contains("d") {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+
+	if union := one.Union(NewBloomFilter(10, 0.01)); union != nil {
+		t.Errorf("Got %v expected %v", union, nil)
+	}
+}
+
+func TestBloomFilterMarshal(t *testing.T) {
+	filter := NewBloomFilter(1000, 0.001)
+	for n := 0; n < 1000; n++ {
+		filter.Add(n)
+	}
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	var decoded BloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if decoded.Cap() != filter.Cap() || decoded.HashCount() != filter.HashCount() {
+		t.Errorf("Got %v expected %v", &decoded, filter)
+	}
+	for n := 0; n < 2000; n++ {
+		if decoded.Contains(n) != filter.Contains(n) {
+			t.Errorf("Got %v expected %v", decoded.Contains(n), filter.Contains(n))
+		}
+	}
+
+	// a number of bits too large for its words to be counted without overflow
+	huge := binary.LittleEndian.AppendUint32(binary.LittleEndian.AppendUint64([]byte{bloomFilterKind, version}, ^uint64(0)), 3)
+	for _, invalid := range [][]byte{nil, data[:len(data)-1], append(data, 0), {countingBloomFilterKind, version}, huge} {
+		if err := decoded.UnmarshalBinary(invalid); err != ErrInvalidData {
+			t.Errorf("Got %v expected %v", err, ErrInvalidData)
+		}
+	}
+}
+
+func BenchmarkBloomFilter(b *testing.B) {
+	filter := NewBloomFilter(1000, 0.01)
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			filter.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			filter.Contains(n)
+		}
+	}
+}
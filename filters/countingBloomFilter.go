@@ -0,0 +1,200 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// CountingBloomFilter is a Bloom filter with a counter in place of every bit, so that
+// the items can be removed as well. A counter reaching 255 sticks there, since how many
+// items were counted in it is lost.
+type CountingBloomFilter struct {
+	counters []uint8
+	k        int // number of counters per item
+	size     int // number of items added and not removed
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*CountingBloomFilter)(nil)
+	_ encoding.BinaryUnmarshaler = (*CountingBloomFilter)(nil)
+)
+
+// Returns a counting Bloom filter sized to hold n items with a false positive rate of fpRate,
+// fpRate should be in (0, 1), otherwise it panics.
+func NewCountingBloomFilter(n int, fpRate float64) *CountingBloomFilter {
+	m, k := optimalSize(n, fpRate)
+	return &CountingBloomFilter{counters: make([]uint8, m), k: k}
+}
+
+// Adds the items to the filter, an item added twice has to be removed twice.
+func (filter *CountingBloomFilter) Add(items ...interface{}) {
+	for _, item := range items {
+		locations(hashOf(item), filter.k, uint64(len(filter.counters)), func(i uint64) bool {
+			if filter.counters[i] < math.MaxUint8 {
+				filter.counters[i]++
+			}
+			return true
+		})
+		filter.size++
+	}
+}
+
+// Removes the items from the filter. The items which are surely absent are ignored,
+// removing an item which was never added but is a false positive makes the filter
+// forget about some of the others.
+func (filter *CountingBloomFilter) Remove(items ...interface{}) {
+	for _, item := range items {
+		if !filter.Contains(item) {
+			continue
+		}
+		locations(hashOf(item), filter.k, uint64(len(filter.counters)), func(i uint64) bool {
+			if filter.counters[i] < math.MaxUint8 {
+				filter.counters[i]--
+			}
+			return true
+		})
+		filter.size--
+	}
+}
+
+// Returns true if all the items may be present, false if any of them surely is not.
+func (filter *CountingBloomFilter) Contains(items ...interface{}) bool {
+	for _, item := range items {
+		found := true
+		locations(hashOf(item), filter.k, uint64(len(filter.counters)), func(i uint64) bool {
+			found = filter.counters[i] != 0
+			return found
+		})
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns how many times the item may have been added and not removed, which is never less
+// than the actual count unless some counter of the item is stuck.
+func (filter *CountingBloomFilter) Count(item interface{}) int {
+	count := math.MaxUint8
+	locations(hashOf(item), filter.k, uint64(len(filter.counters)), func(i uint64) bool {
+		count = min(count, int(filter.counters[i]))
+		return count != 0
+	})
+	return count
+}
+
+func (filter *CountingBloomFilter) Clear() {
+	clear(filter.counters)
+	filter.size = 0
+}
+
+func (filter *CountingBloomFilter) Empty() bool {
+	return filter.size == 0
+}
+
+// Returns the number of items added and not removed, counting each addition.
+func (filter *CountingBloomFilter) Len() int {
+	return filter.size
+}
+
+// Returns the number of counters of the filter.
+func (filter *CountingBloomFilter) Cap() int {
+	return len(filter.counters)
+}
+
+// Returns the number of hash functions, which is the number of counters of every item.
+func (filter *CountingBloomFilter) HashCount() int {
+	return filter.k
+}
+
+// Returns the probability of a false positive in the current state of the filter.
+func (filter *CountingBloomFilter) FalsePositiveRate() float64 {
+	set := 0
+	for _, counter := range filter.counters {
+		if counter != 0 {
+			set++
+		}
+	}
+	return math.Pow(float64(set)/float64(len(filter.counters)), float64(filter.k))
+}
+
+// Returns a filter holding the items of both filters, the counts adding up.
+// Returns nil when the filters differ in size.
+func (filter *CountingBloomFilter) Union(other *CountingBloomFilter) *CountingBloomFilter {
+	if !filter.compatible(other) {
+		return nil
+	}
+	union := &CountingBloomFilter{counters: make([]uint8, len(filter.counters)), k: filter.k, size: filter.size + other.size}
+	for i, counter := range filter.counters {
+		union.counters[i] = uint8(min(int(counter)+int(other.counters[i]), math.MaxUint8))
+	}
+	return union
+}
+
+// Returns a filter holding the items added to both filters, each counter being the smaller
+// of the two. Returns nil when the filters differ in size. Its length is an upper bound,
+// the smaller of the two lengths, since the number of common items is unknown.
+func (filter *CountingBloomFilter) Intersect(other *CountingBloomFilter) *CountingBloomFilter {
+	if !filter.compatible(other) {
+		return nil
+	}
+	intersection := &CountingBloomFilter{counters: make([]uint8, len(filter.counters)), k: filter.k, size: min(filter.size, other.size)}
+	for i, counter := range filter.counters {
+		intersection.counters[i] = min(counter, other.counters[i])
+	}
+	return intersection
+}
+
+func (filter *CountingBloomFilter) compatible(other *CountingBloomFilter) bool {
+	return other != nil && len(filter.counters) == len(other.counters) && filter.k == other.k
+}
+
+// Encodes the filter as its kind and version, the number of counters, of hash functions
+// and of items, then the counters, in little endian.
+func (filter *CountingBloomFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+8+4+8+len(filter.counters))
+	data = append(data, countingBloomFilterKind, version)
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(filter.counters)))
+	data = binary.LittleEndian.AppendUint32(data, uint32(filter.k))
+	data = binary.LittleEndian.AppendUint64(data, uint64(filter.size))
+	return append(data, filter.counters...), nil
+}
+
+// Replaces the filter with the one encoded by MarshalBinary.
+func (filter *CountingBloomFilter) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, countingBloomFilterKind)
+	if err != nil {
+		return err
+	}
+	if len(data) < 20 {
+		return ErrInvalidData
+	}
+	m := binary.LittleEndian.Uint64(data)
+	k := int(binary.LittleEndian.Uint32(data[8:]))
+	size := int(binary.LittleEndian.Uint64(data[12:]))
+	if m == 0 || k == 0 || size < 0 || uint64(len(data)-20) != m {
+		return ErrInvalidData
+	}
+	filter.counters = append([]uint8(nil), data[20:]...)
+	filter.k, filter.size = k, size
+	return nil
+}
+
+func (filter *CountingBloomFilter) String() string {
+	return fmt.Sprintf("CountingBloomFilter{counters: %d, hashes: %d, length: %d}", len(filter.counters), filter.k, filter.size)
+}
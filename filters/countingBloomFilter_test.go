@@ -0,0 +1,135 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"testing"
+)
+
+func TestCountingBloomFilter(t *testing.T) {
+
+	filter := NewCountingBloomFilter(1000, 0.01)
+	for n := 0; n < 1000; n++ {
+		filter.Add(n)
+	}
+	filter.Add(7)
+	if filter.Len() != 1001 {
+		t.Errorf("Got %v expected %v", filter.Len(), 1001)
+	}
+	if count := filter.Count(7); count < 2 {
+		t.Errorf("Got %v expected %v", count, 2)
+	}
+
+	// removes the even numbers, and 7 once
+	for n := 0; n < 1000; n += 2 {
+		filter.Remove(n)
+	}
+	filter.Remove(7)
+	if filter.Len() != 500 {
+		t.Errorf("Got %v expected %v", filter.Len(), 500)
+	}
+	for n := 1; n < 1000; n += 2 {
+		if !filter.Contains(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+	falsePositives := 0
+	for n := 0; n < 1000; n += 2 {
+		if filter.Contains(n) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 20 {
+		t.Errorf("Got %v expected %v", falsePositives, "at most 20")
+	}
+
+	// removing surely absent items changes nothing
+	filter.Remove("absent")
+	if filter.Len() != 500 {
+		t.Errorf("Got %v expected %v", filter.Len(), 500)
+	}
+
+	filter.Clear()
+	if !filter.Empty() || filter.Contains(1) {
+		t.Errorf("Got %v expected %v", filter.Empty(), true)
+	}
+}
+
+func TestCountingBloomFilterUnionIntersect(t *testing.T) {
+	one, other := NewCountingBloomFilter(1000, 0.01), NewCountingBloomFilter(1000, 0.01)
+	one.Add("a", "b", "c")
+	other.Add("b", "c", "d")
+
+	union := one.Union(other)
+	if !union.Contains("a", "b", "c", "d") || union.Len() != 6 {
+		t.Errorf("Got %v expected %v", union, "a, b, b, c, c, d")
+	}
+	union.Remove("b")
+	if !union.Contains("b") {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+
+	intersection := one.Intersect(other)
+	if !intersection.Contains("b", "c") || intersection.Contains("a") || intersection.Contains("d") {
+		t.Errorf("Got %v expected %v", intersection, "b, c")
+	}
+
+	if union := one.Union(NewCountingBloomFilter(10, 0.01)); union != nil {
+		t.Errorf("Got %v expected %v", union, nil)
+	}
+}
+
+func TestCountingBloomFilterMarshal(t *testing.T) {
+	filter := NewCountingBloomFilter(100, 0.01)
+	for n := 0; n < 100; n++ {
+		filter.Add(n)
+	}
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	var decoded CountingBloomFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if decoded.String() != filter.String() {
+		t.Errorf("Got %v expected %v", decoded.String(), filter.String())
+	}
+	for n := 0; n < 100; n++ {
+		decoded.Remove(n)
+	}
+	if !decoded.Empty() || decoded.Contains(1) {
+		t.Errorf("Got %v expected %v", decoded.Empty(), true)
+	}
+
+	bloom, _ := NewBloomFilter(100, 0.01).MarshalBinary()
+	for _, invalid := range [][]byte{nil, data[:len(data)-1], bloom} {
+		if err := decoded.UnmarshalBinary(invalid); err != ErrInvalidData {
+			t.Errorf("Got %v expected %v", err, ErrInvalidData)
+		}
+	}
+}
+
+func BenchmarkCountingBloomFilter(b *testing.B) {
+	filter := NewCountingBloomFilter(1000, 0.01)
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			filter.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			filter.Remove(n)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+const (
+	_BUCKET_SIZE = 4
+	_MAX_KICKS   = 500
+	_LOAD_FACTOR = 0.95 // reachable with buckets of 4 before an insertion fails
+)
+
+// CuckooFilter keeps a short fingerprint of every item in one of its two candidate buckets
+// (Fan et al.), an item is possibly present when either bucket holds its fingerprint.
+// Unlike a Bloom filter it supports Remove, and uses less memory for low false positive rates.
+//
+// The second bucket is derived from the first and the fingerprint alone, so that a fingerprint
+// can be moved to make room without knowing its item, an insertion fails only when the filter
+// is nearly full. There is no Intersect, since fingerprints of different items in the same
+// buckets can not be told apart.
+type CuckooFilter struct {
+	slots  []uint16 // _BUCKET_SIZE slots per bucket, 0 is an empty slot
+	mask   uint16   // of the fingerprint bits
+	size   int
+	victim cuckooVictim
+}
+
+// the fingerprint which could not be placed by the last failed insertion,
+// kept aside so that no item is lost; the filter accepts no more items until it is placed
+type cuckooVictim struct {
+	bucket      uint64
+	fingerprint uint16
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*CuckooFilter)(nil)
+	_ encoding.BinaryUnmarshaler = (*CuckooFilter)(nil)
+)
+
+// Returns a cuckoo filter sized to hold n items with a false positive rate of fpRate,
+// fpRate should be in (0, 1), otherwise it panics. The fingerprints have at most 16 bits,
+// which bounds the false positive rate to about 1e-4.
+func NewCuckooFilter(n int, fpRate float64) *CuckooFilter {
+	if fpRate <= 0 || fpRate >= 1 {
+		panic(fmt.Sprintf("filters: false positive rate %v out of (0, 1)", fpRate))
+	}
+	// an absent item is compared to 2 buckets of fingerprints
+	fingerprintBits := min(max(int(math.Ceil(math.Log2(2*_BUCKET_SIZE/fpRate))), 4), 16)
+	buckets := uint64(math.Ceil(float64(max(n, 1)) / _BUCKET_SIZE / _LOAD_FACTOR))
+	// a power of two, so that the alternate bucket of the alternate bucket is the first
+	buckets = 1 << bits.Len64(buckets-1)
+	return &CuckooFilter{
+		slots: make([]uint16, buckets*_BUCKET_SIZE),
+		mask:  uint16(1<<fingerprintBits - 1),
+	}
+}
+
+func (filter *CuckooFilter) buckets() uint64 {
+	return uint64(len(filter.slots) / _BUCKET_SIZE)
+}
+
+// the first bucket and the fingerprint of item
+func (filter *CuckooFilter) locate(item interface{}) (uint64, uint16) {
+	hash := hashOf(item)
+	fingerprint := uint16(hash>>32) & filter.mask
+	if fingerprint == 0 {
+		fingerprint = 1
+	}
+	return hash & (filter.buckets() - 1), fingerprint
+}
+
+// the other bucket of a fingerprint in bucket
+func (filter *CuckooFilter) alternate(bucket uint64, fingerprint uint16) uint64 {
+	return (bucket ^ mix(uint64(fingerprint))) & (filter.buckets() - 1)
+}
+
+func (filter *CuckooFilter) bucket(i uint64) []uint16 {
+	return filter.slots[i*_BUCKET_SIZE : (i+1)*_BUCKET_SIZE]
+}
+
+// stores fingerprint in an empty slot of bucket, if any
+func (filter *CuckooFilter) store(i uint64, fingerprint uint16) bool {
+	bucket := filter.bucket(i)
+	for j, slot := range bucket {
+		if slot == 0 {
+			bucket[j] = fingerprint
+			return true
+		}
+	}
+	return false
+}
+
+// Adds the items to the filter, an item added twice has to be removed twice. Returns false
+// if the filter is full, which leaves the remaining items out.
+func (filter *CuckooFilter) Add(items ...interface{}) bool {
+	for _, item := range items {
+		i, fingerprint := filter.locate(item)
+		if !filter.insert(i, fingerprint) {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter *CuckooFilter) insert(i uint64, fingerprint uint16) bool {
+	if filter.victim.fingerprint != 0 {
+		return false
+	}
+	filter.size++
+	if filter.store(i, fingerprint) {
+		return true
+	}
+	i = filter.alternate(i, fingerprint)
+	if filter.store(i, fingerprint) {
+		return true
+	}
+	// kicks out random fingerprints to their other bucket until one finds room
+	for kick := 0; kick < _MAX_KICKS; kick++ {
+		bucket := filter.bucket(i)
+		j := rand.Intn(_BUCKET_SIZE)
+		fingerprint, bucket[j] = bucket[j], fingerprint
+		i = filter.alternate(i, fingerprint)
+		if filter.store(i, fingerprint) {
+			return true
+		}
+	}
+	filter.victim = cuckooVictim{bucket: i, fingerprint: fingerprint}
+	return true
+}
+
+// Removes one addition of each item. The items which are surely absent are ignored,
+// removing an item which was never added but is a false positive makes the filter
+// forget about another one.
+func (filter *CuckooFilter) Remove(items ...interface{}) {
+	for _, item := range items {
+		i, fingerprint := filter.locate(item)
+		alternate := filter.alternate(i, fingerprint)
+		switch victim := filter.victim; {
+		case filter.delete(i, fingerprint) || filter.delete(alternate, fingerprint):
+			filter.size--
+			// there is room for the victim now
+			if victim.fingerprint != 0 {
+				filter.victim = cuckooVictim{}
+				filter.size--
+				filter.insert(victim.bucket, victim.fingerprint)
+			}
+		case victim.fingerprint == fingerprint && (victim.bucket == i || victim.bucket == alternate):
+			filter.victim = cuckooVictim{}
+			filter.size--
+		}
+	}
+}
+
+// removes fingerprint from bucket, if there
+func (filter *CuckooFilter) delete(i uint64, fingerprint uint16) bool {
+	bucket := filter.bucket(i)
+	for j, slot := range bucket {
+		if slot == fingerprint {
+			bucket[j] = 0
+			return true
+		}
+	}
+	return false
+}
+
+// Returns true if all the items may be present, false if any of them surely is not.
+func (filter *CuckooFilter) Contains(items ...interface{}) bool {
+	for _, item := range items {
+		i, fingerprint := filter.locate(item)
+		alternate := filter.alternate(i, fingerprint)
+		victim := filter.victim
+		if !filter.find(i, fingerprint) && !filter.find(alternate, fingerprint) &&
+			!(victim.fingerprint == fingerprint && (victim.bucket == i || victim.bucket == alternate)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (filter *CuckooFilter) find(i uint64, fingerprint uint16) bool {
+	for _, slot := range filter.bucket(i) {
+		if slot == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+func (filter *CuckooFilter) Clear() {
+	clear(filter.slots)
+	filter.size = 0
+	filter.victim = cuckooVictim{}
+}
+
+func (filter *CuckooFilter) Empty() bool {
+	return filter.size == 0
+}
+
+// Returns the number of items added and not removed, counting each addition.
+func (filter *CuckooFilter) Len() int {
+	return filter.size
+}
+
+// Returns the number of fingerprints the filter has room for.
+func (filter *CuckooFilter) Cap() int {
+	return len(filter.slots)
+}
+
+// Returns the probability of a false positive in the current state of the filter.
+func (filter *CuckooFilter) FalsePositiveRate() float64 {
+	// each of the fingerprints in the 2 buckets matches with a probability of 1/mask
+	occupancy := float64(filter.size) / float64(len(filter.slots))
+	return 1 - math.Pow(1-1/float64(filter.mask), 2*_BUCKET_SIZE*occupancy)
+}
+
+// Returns a filter holding the items of both filters, or nil when the filters differ in size
+// or the union does not fit.
+func (filter *CuckooFilter) Union(other *CuckooFilter) *CuckooFilter {
+	if other == nil || len(filter.slots) != len(other.slots) || filter.mask != other.mask {
+		return nil
+	}
+	union := &CuckooFilter{slots: append([]uint16(nil), filter.slots...), mask: filter.mask, size: filter.size, victim: filter.victim}
+	for j, fingerprint := range other.slots {
+		if fingerprint != 0 && !union.insert(uint64(j/_BUCKET_SIZE), fingerprint) {
+			return nil
+		}
+	}
+	if victim := other.victim; victim.fingerprint != 0 && !union.insert(victim.bucket, victim.fingerprint) {
+		return nil
+	}
+	return union
+}
+
+// Encodes the filter as its kind and version, the number of slots, the fingerprint mask,
+// the number of items and the victim, then the slots, in little endian.
+func (filter *CuckooFilter) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+8+2+8+8+2+2*len(filter.slots))
+	data = append(data, cuckooFilterKind, version)
+	data = binary.LittleEndian.AppendUint64(data, uint64(len(filter.slots)))
+	data = binary.LittleEndian.AppendUint16(data, filter.mask)
+	data = binary.LittleEndian.AppendUint64(data, uint64(filter.size))
+	data = binary.LittleEndian.AppendUint64(data, filter.victim.bucket)
+	data = binary.LittleEndian.AppendUint16(data, filter.victim.fingerprint)
+	for _, slot := range filter.slots {
+		data = binary.LittleEndian.AppendUint16(data, slot)
+	}
+	return data, nil
+}
+
+// Replaces the filter with the one encoded by MarshalBinary.
+func (filter *CuckooFilter) UnmarshalBinary(data []byte) error {
+	data, err := readHeader(data, cuckooFilterKind)
+	if err != nil {
+		return err
+	}
+	if len(data) < 28 {
+		return ErrInvalidData
+	}
+	slots := binary.LittleEndian.Uint64(data)
+	mask := binary.LittleEndian.Uint16(data[8:])
+	size := int(binary.LittleEndian.Uint64(data[10:]))
+	victim := cuckooVictim{bucket: binary.LittleEndian.Uint64(data[18:]), fingerprint: binary.LittleEndian.Uint16(data[26:])}
+	data = data[28:]
+	buckets := slots / _BUCKET_SIZE
+	if buckets == 0 || slots%_BUCKET_SIZE != 0 || buckets&(buckets-1) != 0 || mask == 0 || mask&(mask+1) != 0 || size < 0 ||
+		victim.bucket >= buckets || uint64(len(data))/2 != slots || len(data)%2 != 0 {
+		return ErrInvalidData
+	}
+	filter.slots = make([]uint16, slots)
+	for i := range filter.slots {
+		filter.slots[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	filter.mask, filter.size, filter.victim = mask, size, victim
+	return nil
+}
+
+func (filter *CuckooFilter) String() string {
+	return fmt.Sprintf("CuckooFilter{slots: %d, fingerprint bits: %d, length: %d}", len(filter.slots), bits.OnesCount16(filter.mask), filter.size)
+}
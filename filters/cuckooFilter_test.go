@@ -0,0 +1,155 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package filters
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCuckooFilter(t *testing.T) {
+
+	filter := NewCuckooFilter(10000, 0.001)
+	for n := 0; n < 10000; n++ {
+		if !filter.Add(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+	if filter.Len() != 10000 {
+		t.Errorf("Got %v expected %v", filter.Len(), 10000)
+	}
+	for n := 0; n < 10000; n++ {
+		if !filter.Contains(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+	falsePositives := 0
+	for n := 10000; n < 20000; n++ {
+		if filter.Contains(n) {
+			falsePositives++
+		}
+	}
+	if falsePositives > 20 {
+		t.Errorf("Got %v expected %v", falsePositives, "at most 20")
+	}
+
+	for n := 0; n < 10000; n += 2 {
+		filter.Remove(n)
+	}
+	if filter.Len() != 5000 {
+		t.Errorf("Got %v expected %v", filter.Len(), 5000)
+	}
+	for n := 1; n < 10000; n += 2 {
+		if !filter.Contains(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+
+	filter.Clear()
+	if !filter.Empty() || filter.Contains(1) {
+		t.Errorf("Got %v expected %v", filter.Empty(), true)
+	}
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	filter := NewCuckooFilter(100, 0.01)
+	added := 0
+	for filter.Add(added) {
+		added++
+	}
+	if added < filter.Cap()*9/10 {
+		t.Errorf("Got %v expected %v", added, "at least 90% of the capacity")
+	}
+	// the fingerprint which did not fit is kept aside, no added item is lost
+	for n := 0; n < added; n++ {
+		if !filter.Contains(n) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+	// removing makes room for the fingerprint kept aside, and more
+	for n := 0; n < 50; n++ {
+		filter.Remove(n)
+	}
+	if !filter.Add(added) || !filter.Contains(added) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+}
+
+func TestCuckooFilterUnion(t *testing.T) {
+	one, other := NewCuckooFilter(1000, 0.01), NewCuckooFilter(1000, 0.01)
+	for n := 0; n < 300; n++ {
+		one.Add(fmt.Sprintf("one%d", n))
+		other.Add(fmt.Sprintf("other%d", n))
+	}
+
+	union := one.Union(other)
+	if union.Len() != 600 {
+		t.Errorf("Got %v expected %v", union.Len(), 600)
+	}
+	for n := 0; n < 300; n++ {
+		if !union.Contains(fmt.Sprintf("one%d", n), fmt.Sprintf("other%d", n)) {
+			t.Fatalf("Got %v expected %v", false, true)
+		}
+	}
+	if one.Len() != 300 {
+		t.Errorf("Got %v expected %v", one.Len(), 300)
+	}
+
+	if union := one.Union(NewCuckooFilter(10, 0.01)); union != nil {
+		t.Errorf("Got %v expected %v", union, nil)
+	}
+}
+
+func TestCuckooFilterMarshal(t *testing.T) {
+	filter := NewCuckooFilter(1000, 0.01)
+	for n := 0; n < 1000; n++ {
+		filter.Add(n)
+	}
+
+	data, err := filter.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	var decoded CuckooFilter
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("Got %v expected %v", err, nil)
+	}
+	if decoded.String() != filter.String() {
+		t.Errorf("Got %v expected %v", decoded.String(), filter.String())
+	}
+	for n := 0; n < 2000; n++ {
+		if decoded.Contains(n) != filter.Contains(n) {
+			t.Errorf("Got %v expected %v", decoded.Contains(n), filter.Contains(n))
+		}
+	}
+
+	bloom, _ := NewBloomFilter(100, 0.01).MarshalBinary()
+	for _, invalid := range [][]byte{nil, data[:len(data)-1], bloom} {
+		if err := decoded.UnmarshalBinary(invalid); err != ErrInvalidData {
+			t.Errorf("Got %v expected %v", err, ErrInvalidData)
+		}
+	}
+}
+
+func BenchmarkCuckooFilter(b *testing.B) {
+	filter := NewCuckooFilter(1000, 0.01)
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			filter.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			filter.Remove(n)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package filters provides probabilistic sets: they answer whether an item may have been
+// added, with a bounded rate of false positives but never a false negative, in a small
+// fraction of the memory of a set of the items themselves.
+//
+// The items are hashed from their value, not their identity, the same way in every process,
+// so that a filter marshaled by one program answers the same in another.
+package filters
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// ErrInvalidData is returned when unmarshaling data which is not a marshaled filter of the kind.
+var ErrInvalidData = errors.New("filters: invalid data")
+
+// the first byte of the marshaled filters, followed by the version
+const (
+	bloomFilterKind         byte = 'B'
+	countingBloomFilterKind byte = 'C'
+	cuckooFilterKind        byte = 'K'
+	version                 byte = 1
+)
+
+// hashOf returns the 64 bits FNV-1a hash of the item's value. Integers of any type
+// hash alike when equal, so do floats and the items formatted the same by %#v.
+func hashOf(item interface{}) uint64 {
+	hash := fnv.New64a()
+	var buffer [8]byte
+	value := reflect.ValueOf(item)
+	switch value.Kind() {
+	case reflect.String:
+		hash.Write([]byte(value.String()))
+	case reflect.Slice:
+		if bytes, ok := item.([]byte); ok {
+			hash.Write(bytes)
+		} else {
+			fmt.Fprintf(hash, "%#v", item)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hash.Write(binary.LittleEndian.AppendUint64(buffer[:0], uint64(value.Int())))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		hash.Write(binary.LittleEndian.AppendUint64(buffer[:0], value.Uint()))
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		if f == 0 {
+			// +0 == -0
+			f = 0
+		}
+		hash.Write(binary.LittleEndian.AppendUint64(buffer[:0], math.Float64bits(f)))
+	case reflect.Bool:
+		if value.Bool() {
+			hash.Write([]byte{1})
+		} else {
+			hash.Write([]byte{0})
+		}
+	default:
+		fmt.Fprintf(hash, "%#v", item)
+	}
+	return mix(hash.Sum64())
+}
+
+// the finalizer of splitmix64, FNV-1a alone spreads the short inputs poorly in the high bits
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// locations yields the k positions of hash among m by double hashing (Kirsch and Mitzenmacher),
+// the second hash being derived from the first.
+func locations(hash uint64, k int, m uint64, yield func(uint64) bool) {
+	h1, h2 := hash, mix(hash)|1
+	for i := 0; i < k; i++ {
+		if !yield((h1 + uint64(i)*h2) % m) {
+			return
+		}
+	}
+}
+
+// optimalSize returns the number of bits and of hash functions of a Bloom filter holding
+// n items with a false positive rate of fpRate.
+func optimalSize(n int, fpRate float64) (m uint64, k int) {
+	if n < 1 {
+		n = 1
+	}
+	if fpRate <= 0 || fpRate >= 1 {
+		panic(fmt.Sprintf("filters: false positive rate %v out of (0, 1)", fpRate))
+	}
+	m = uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	k = int(math.Round(float64(m) / float64(n) * math.Ln2))
+	return max(m, 64), max(k, 1)
+}
+
+// readHeader checks the kind and version at the beginning of data, and returns the rest
+func readHeader(data []byte, kind byte) ([]byte, error) {
+	if len(data) < 2 || data[0] != kind {
+		return nil, ErrInvalidData
+	}
+	if data[1] != version {
+		return nil, fmt.Errorf("filters: unsupported version %d", data[1])
+	}
+	return data[2:], nil
+}
+
+// readUint64s reads count little endian words from data, and returns the rest
+func readUint64s(data []byte, count uint64) ([]uint64, []byte, error) {
+	if uint64(len(data))/8 < count {
+		return nil, nil, ErrInvalidData
+	}
+	words := make([]uint64, count)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(data[8*i:])
+	}
+	return words, data[8*count:], nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// ArrayListOf is the type-parameterized version of ArrayList
+type ArrayListOf[T any] struct {
+	elements []T
+	size     int
+}
+
+func NewArrayListOf[T any]() *ArrayListOf[T] {
+	return &ArrayListOf[T]{}
+}
+
+// append elements at the end of the list
+func (list *ArrayListOf[T]) Add(elements ...T) {
+	list.expand(len(elements))
+	for _, e := range elements {
+		list.elements[list.size] = e
+		list.size += 1
+	}
+}
+
+// return the element at idx, if get the element, return element and true, otherwise zero value, false
+func (list *ArrayListOf[T]) Get(idx int) (T, bool) {
+	if !list.inRange(idx) {
+		var zero T
+		return zero, false
+	}
+
+	return list.elements[idx], true
+}
+
+// remove the element form the arraylist
+func (list *ArrayListOf[T]) Remove(idx int) {
+	if !list.inRange(idx) {
+		return
+	}
+
+	copy(list.elements[idx:], list.elements[idx+1:list.size])
+	var zero T
+	list.elements[list.size-1] = zero
+	list.size -= 1
+
+	list.shrink()
+}
+
+// check if the elements are in the array list
+func (list *ArrayListOf[T]) Contains(elements ...T) bool {
+	for _, e := range elements {
+		if !list.contain(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// return all the elements in the arraylist
+func (list *ArrayListOf[T]) Elements() []T {
+	newElements := make([]T, list.size, list.size)
+	copy(newElements, list.elements[:list.size])
+	return newElements
+}
+
+// return true if the list's size is zero
+func (list *ArrayListOf[T]) Empty() bool {
+	return list.Len() == 0
+}
+
+// return list's size
+func (list *ArrayListOf[T]) Len() int {
+	return list.size
+}
+
+// remove all the elements
+func (list *ArrayListOf[T]) Clear() {
+	list.size = 0
+	list.elements = []T{}
+}
+
+func (list *ArrayListOf[T]) Clone() *ArrayListOf[T] {
+	l := NewArrayListOf[T]()
+	l.Add(list.Elements()...)
+	return l
+}
+
+// Swaps values of two elements at the given indices.
+func (list *ArrayListOf[T]) Swap(i, j int) {
+	if list.inRange(i) && list.inRange(j) {
+		list.elements[i], list.elements[j] = list.elements[j], list.elements[i]
+	}
+}
+
+// sort the elements by comparator
+func (list *ArrayListOf[T]) Sort(comparator container.Comparator[T]) {
+	slices.SortFunc(list.elements[:list.size], func(e1, e2 T) int {
+		return int(comparator(e1, e2))
+	})
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (list *ArrayListOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for idx := 0; idx < list.size; idx++ {
+			if !yield(idx, list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs, from the last element to the first.
+func (list *ArrayListOf[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for idx := list.size - 1; idx >= 0; idx-- {
+			if !yield(idx, list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *ArrayListOf[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for idx := 0; idx < list.size; idx++ {
+			if !yield(list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// out format
+func (list *ArrayListOf[T]) String() string {
+	str := "ArrayList{ "
+	values := []string{}
+	for _, value := range list.elements[:list.size] {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+// return whether e in the array list
+func (list *ArrayListOf[T]) contain(e T) bool {
+	for _, le := range list.elements[:list.size] {
+		if interface{}(e) == interface{}(le) {
+			return true
+		}
+	}
+	return false
+}
+
+// check whether the idx is within bounds of the arraylist
+func (list *ArrayListOf[T]) inRange(idx int) bool {
+	return idx >= 0 && idx < list.size
+}
+
+// ReExpand the array list if necessary
+func (list *ArrayListOf[T]) expand(n int) {
+	curCap := cap(list.elements)
+	if list.size+n >= curCap {
+		newCap := int(_EXPAND_FACTOR * float64(curCap+n))
+		list.resize(newCap)
+	}
+}
+
+// Shrink the array list if necessary
+func (list *ArrayListOf[T]) shrink() {
+	curCap := cap(list.elements)
+	if list.size <= int(float64(curCap)*_SHRINK_FACTOR) {
+		list.resize(list.size)
+	}
+}
+
+func (list *ArrayListOf[T]) resize(cap int) {
+	newElements := make([]T, cap, cap)
+	copy(newElements, list.elements[:list.size])
+	list.elements = newElements
+}
@@ -0,0 +1,117 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"github.com/aiwuTech/container"
+	"testing"
+)
+
+func TestArrayListOf(t *testing.T) {
+
+	list := NewArrayListOf[string]()
+
+	list.Add("e", "f", "g", "a", "b", "c", "d")
+
+	list.Sort(container.OrderedCompareFunctionASC[string])
+	for i := 1; i < list.Len(); i++ {
+		a, _ := list.Get(i - 1)
+		b, _ := list.Get(i)
+		if a > b {
+			t.Errorf("Not sorted! %s > %s", a, b)
+		}
+	}
+
+	list.Clear()
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	list.Add("a")
+	list.Add("b", "c")
+
+	if actualValue := list.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := list.Get(2); actualValue != "c" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+
+	list.Swap(0, 1)
+
+	if actualValue, ok := list.Get(0); actualValue != "b" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "b")
+	}
+
+	list.Remove(2)
+
+	if actualValue, ok := list.Get(2); actualValue != "" || ok {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+
+	if actualValue := list.Contains("a", "b"); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue := list.Contains("a", "c"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	list.Remove(1)
+	list.Remove(0)
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+}
+
+func BenchmarkArrayListOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		list := NewArrayListOf[int]()
+		for n := 0; n < 1000; n++ {
+			list.Add(i)
+		}
+		for !list.Empty() {
+			list.Remove(0)
+		}
+	}
+}
+
+func TestArrayListOfSeq(t *testing.T) {
+
+	list := NewArrayListOf[int]()
+	list.Add(1, 2, 3)
+
+	sum := 0
+	for idx, value := range list.All() {
+		if value != idx+1 {
+			t.Errorf("Got %v expected %v", value, idx+1)
+		}
+		sum += value
+	}
+	if sum != 6 {
+		t.Errorf("Got %v expected %v", sum, 6)
+	}
+
+	for _, value := range list.Backward() {
+		if value != 3 {
+			t.Errorf("Got %v expected %v", value, 3)
+		}
+		break
+	}
+
+}
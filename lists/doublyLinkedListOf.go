@@ -0,0 +1,242 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"slices"
+	"strings"
+)
+
+type doublyLinkedElementOf[T any] struct {
+	value T
+	prev  *doublyLinkedElementOf[T]
+	next  *doublyLinkedElementOf[T]
+}
+
+// DoublyLinkedListOf is the type-parameterized version of DoublyLinkedList
+type DoublyLinkedListOf[T any] struct {
+	first *doublyLinkedElementOf[T]
+	last  *doublyLinkedElementOf[T]
+	size  int
+}
+
+func NewDoublyLinkedListOf[T any]() *DoublyLinkedListOf[T] {
+	return &DoublyLinkedListOf[T]{}
+}
+
+// Appends a value (one or more) at the end of the list (same as Append())
+func (list *DoublyLinkedListOf[T]) Add(values ...T) {
+	for _, value := range values {
+		newElement := &doublyLinkedElementOf[T]{
+			value: value,
+			prev:  list.last,
+		}
+		if list.size == 0 {
+			list.first = newElement
+			list.last = newElement
+		} else {
+			list.last.next = newElement
+			list.last = newElement
+		}
+		list.size++
+	}
+}
+
+// Appends a value (one or more) at the end of the list (same as Add())
+func (list *DoublyLinkedListOf[T]) Append(values ...T) {
+	list.Add(values...)
+}
+
+// Prepends a values (or more)
+func (list *DoublyLinkedListOf[T]) Prepend(values ...T) {
+	// in reverse to keep passed order i.e. ["c","d"] -> Prepend(["a","b"]) -> ["a","b","c",d"]
+	for v := len(values) - 1; v >= 0; v-- {
+		newElement := &doublyLinkedElementOf[T]{value: values[v], next: list.first}
+		if list.size == 0 {
+			list.first = newElement
+			list.last = newElement
+		} else {
+			list.first.prev = newElement
+			list.first = newElement
+		}
+		list.size++
+	}
+}
+
+// Returns the element at index.
+// Second return parameter is true if index is within bounds of the list, otherwise false.
+func (list *DoublyLinkedListOf[T]) Get(index int) (T, bool) {
+	if !list.inRange(index) {
+		var zero T
+		return zero, false
+	}
+
+	return list.element(index).value, true
+}
+
+// Removes the element at index from the list.
+func (list *DoublyLinkedListOf[T]) Remove(index int) {
+	if !list.inRange(index) {
+		return
+	}
+
+	element := list.element(index)
+	if element == list.first {
+		list.first = element.next
+	}
+	if element == list.last {
+		list.last = element.prev
+	}
+	if element.prev != nil {
+		element.prev.next = element.next
+	}
+	if element.next != nil {
+		element.next.prev = element.prev
+	}
+
+	list.size--
+}
+
+// Check if values (one or more) are present in the list.
+// Returns true if no arguments are passed at all.
+func (list *DoublyLinkedListOf[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if !list.contain(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns all elements in the list.
+func (list *DoublyLinkedListOf[T]) Elements() []T {
+	values := make([]T, list.size, list.size)
+	for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+		values[e] = element.value
+	}
+	return values
+}
+
+// Returns true if list does not contain any elements.
+func (list *DoublyLinkedListOf[T]) Empty() bool {
+	return list.size == 0
+}
+
+// Returns number of elements within the list.
+func (list *DoublyLinkedListOf[T]) Len() int {
+	return list.size
+}
+
+// Removes all elements from the list.
+func (list *DoublyLinkedListOf[T]) Clear() {
+	list.size = 0
+	list.first = nil
+	list.last = nil
+}
+
+// Sorts values by comparator.
+// Values are sorted in a slice and written back, so the cost is O(n log n).
+func (list *DoublyLinkedListOf[T]) Sort(comparator container.Comparator[T]) {
+	values := list.Elements()
+	slices.SortFunc(values, func(e1, e2 T) int {
+		return int(comparator(e1, e2))
+	})
+	for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+		element.value = values[e]
+	}
+}
+
+// Swaps values of two elements at the given indices.
+func (list *DoublyLinkedListOf[T]) Swap(i, j int) {
+	if list.inRange(i) && list.inRange(j) && i != j {
+		element1, element2 := list.element(i), list.element(j)
+		element1.value, element2.value = element2.value, element1.value
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (list *DoublyLinkedListOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs, from the last element to the first.
+func (list *DoublyLinkedListOf[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for e, element := list.size-1, list.last; element != nil; e, element = e-1, element.prev {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *DoublyLinkedListOf[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := list.first; element != nil; element = element.next {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+func (list *DoublyLinkedListOf[T]) String() string {
+	str := "DoublyLinkedList{ "
+	values := []string{}
+	for element := list.first; element != nil; element = element.next {
+		values = append(values, fmt.Sprintf("%v", element.value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (list *DoublyLinkedListOf[T]) contain(value T) bool {
+	for element := list.first; element != nil; element = element.next {
+		if interface{}(element.value) == interface{}(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the list element at index, which must be in range
+func (list *DoublyLinkedListOf[T]) element(index int) *doublyLinkedElementOf[T] {
+	// determine traversal direction, last to first or first to last
+	if list.size-index < index {
+		element := list.last
+		for e := list.size - 1; e != index; e, element = e-1, element.prev {
+		}
+		return element
+	}
+	element := list.first
+	for e := 0; e != index; e, element = e+1, element.next {
+	}
+	return element
+}
+
+// Check that the index is withing bounds of the list
+func (list *DoublyLinkedListOf[T]) inRange(index int) bool {
+	return index >= 0 && index < list.size
+}
@@ -0,0 +1,92 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"github.com/aiwuTech/container"
+	"testing"
+)
+
+func TestDoublyLinkedListOf(t *testing.T) {
+
+	list := NewDoublyLinkedListOf[string]()
+
+	list.Add("e", "f", "g", "a", "b", "c", "d")
+
+	list.Sort(container.OrderedCompareFunctionASC[string])
+	for i := 1; i < list.Len(); i++ {
+		a, _ := list.Get(i - 1)
+		b, _ := list.Get(i)
+		if a > b {
+			t.Errorf("Not sorted! %s > %s", a, b)
+		}
+	}
+
+	list.Clear()
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	list.Add("b", "c")
+	list.Prepend("a")
+
+	if actualValue := list.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := list.Get(0); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+
+	list.Swap(0, 2)
+
+	if actualValue := list.Elements(); actualValue[0] != "c" || actualValue[1] != "b" || actualValue[2] != "a" {
+		t.Errorf("Got %v expected %v", actualValue, "[c,b,a]")
+	}
+
+	list.Remove(1)
+
+	if actualValue, ok := list.Get(1); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+
+	if actualValue := list.Contains("a", "b"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	list.Remove(1)
+	list.Remove(0)
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue, ok := list.Get(0); actualValue != "" || ok {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+
+}
+
+func BenchmarkDoublyLinkedListOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		list := NewDoublyLinkedListOf[int]()
+		for n := 0; n < 1000; n++ {
+			list.Add(i)
+		}
+		for !list.Empty() {
+			list.Remove(0)
+		}
+	}
+}
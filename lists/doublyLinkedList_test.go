@@ -122,3 +122,75 @@ func BenchmarkDoublyLinkedList(b *testing.B) {
 		}
 	}
 }
+
+func TestDoublyLinkedListIterator(t *testing.T) {
+
+	list := NewDoublyLinkedList()
+	list.Add("a", "b", "c")
+
+	it := list.ReverseIterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Value(), []string{"a", "b", "c"}[it.Key().(int)]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+	for it.Prev() {
+		count--
+		if actualValue, expectedValue := it.Value(), []string{"a", "b", "c"}[count]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if count != 0 {
+		t.Errorf("Got %v expected %v", count, 0)
+	}
+
+	it.End()
+	if !it.Prev() || it.Value() != "c" {
+		t.Errorf("Got %v expected %v", it.Value(), "c")
+	}
+
+	it.Reset()
+	if !it.Next() || it.Value() != "a" {
+		t.Errorf("Got %v expected %v", it.Value(), "a")
+	}
+
+}
+
+func TestDoublyLinkedListSeq(t *testing.T) {
+
+	list := NewDoublyLinkedList()
+	list.Add("a", "b", "c")
+
+	expected := []string{"a", "b", "c"}
+	count := 0
+	for idx, value := range list.All() {
+		if value != expected[idx] {
+			t.Errorf("Got %v expected %v", value, expected[idx])
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+	for idx, value := range list.Backward() {
+		count--
+		if idx != count || value != expected[idx] {
+			t.Errorf("Got %v expected %v", value, expected[count])
+		}
+	}
+
+	for value := range list.Values() {
+		if value != "a" {
+			t.Errorf("Got %v expected %v", value, "a")
+		}
+		break
+	}
+
+}
@@ -0,0 +1,259 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+type arrayListIterator struct {
+	list  *ArrayList
+	index int
+}
+
+// Returns a stateful iterator over the list, positioned before the first element.
+func (list *ArrayList) Iterator() container.Iterator {
+	return list.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the list backwards.
+func (list *ArrayList) ReverseIterator() container.ReverseIterator {
+	return &arrayListIterator{list: list, index: -1}
+}
+
+func (it *arrayListIterator) Next() bool {
+	if it.index < it.list.size {
+		it.index++
+	}
+	return it.list.inRange(it.index)
+}
+
+func (it *arrayListIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.list.inRange(it.index)
+}
+
+func (it *arrayListIterator) Value() interface{} {
+	return it.list.elements[it.index]
+}
+
+func (it *arrayListIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *arrayListIterator) Reset() {
+	it.index = -1
+}
+
+func (it *arrayListIterator) End() {
+	it.index = it.list.size
+}
+
+type doublyLinkedListIterator struct {
+	list    *DoublyLinkedList
+	element *doublyLinkedElement
+	index   int
+}
+
+// Returns a stateful iterator over the list, positioned before the first element.
+func (list *DoublyLinkedList) Iterator() container.Iterator {
+	return list.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the list backwards.
+func (list *DoublyLinkedList) ReverseIterator() container.ReverseIterator {
+	return &doublyLinkedListIterator{list: list, index: -1}
+}
+
+func (it *doublyLinkedListIterator) Next() bool {
+	if it.index < it.list.size {
+		it.index++
+	}
+	if !it.list.inRange(it.index) {
+		it.element = nil
+		return false
+	}
+	if it.index == 0 {
+		it.element = it.list.first
+	} else {
+		it.element = it.element.next
+	}
+	return true
+}
+
+func (it *doublyLinkedListIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	if !it.list.inRange(it.index) {
+		it.element = nil
+		return false
+	}
+	if it.index == it.list.size-1 {
+		it.element = it.list.last
+	} else {
+		it.element = it.element.prev
+	}
+	return true
+}
+
+func (it *doublyLinkedListIterator) Value() interface{} {
+	return it.element.value
+}
+
+func (it *doublyLinkedListIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *doublyLinkedListIterator) Reset() {
+	it.index = -1
+	it.element = nil
+}
+
+func (it *doublyLinkedListIterator) End() {
+	it.index = it.list.size
+	it.element = nil
+}
+
+// singly linked elements can only be walked forwards
+type singlyLinkedListIterator struct {
+	list    *SinglyLinkedList
+	element *singlyLinkedElemnt
+	index   int
+}
+
+// Returns a stateful iterator over the list, positioned before the first element.
+func (list *SinglyLinkedList) Iterator() container.Iterator {
+	return &singlyLinkedListIterator{list: list, index: -1}
+}
+
+func (it *singlyLinkedListIterator) Next() bool {
+	if it.index < it.list.size {
+		it.index++
+	}
+	if !it.list.inRange(it.index) {
+		it.element = nil
+		return false
+	}
+	if it.index == 0 {
+		it.element = it.list.first
+	} else {
+		it.element = it.element.next
+	}
+	return true
+}
+
+func (it *singlyLinkedListIterator) Value() interface{} {
+	return it.element.value
+}
+
+func (it *singlyLinkedListIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *singlyLinkedListIterator) Reset() {
+	it.index = -1
+	it.element = nil
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (list *ArrayList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for idx := 0; idx < list.size; idx++ {
+			if !yield(idx, list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs, from the last element to the first.
+func (list *ArrayList) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for idx := list.size - 1; idx >= 0; idx-- {
+			if !yield(idx, list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *ArrayList) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for idx := 0; idx < list.size; idx++ {
+			if !yield(list.elements[idx]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (list *DoublyLinkedList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs, from the last element to the first.
+func (list *DoublyLinkedList) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for e, element := list.size-1, list.last; element != nil; e, element = e-1, element.prev {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *DoublyLinkedList) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for element := list.first; element != nil; element = element.next {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (list *SinglyLinkedList) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *SinglyLinkedList) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for element := list.first; element != nil; element = element.next {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"slices"
+	"strings"
+)
+
+type singlyLinkedElementOf[T any] struct {
+	value T
+	next  *singlyLinkedElementOf[T]
+}
+
+// SinglyLinkedListOf is the type-parameterized version of SinglyLinkedList
+type SinglyLinkedListOf[T any] struct {
+	first *singlyLinkedElementOf[T]
+	last  *singlyLinkedElementOf[T]
+	size  int
+}
+
+func NewSinglyLinkedListOf[T any]() *SinglyLinkedListOf[T] {
+	return &SinglyLinkedListOf[T]{}
+}
+
+// Appends a value (one or more) at the end of the list (same as Append())
+func (list *SinglyLinkedListOf[T]) Add(values ...T) {
+	for _, value := range values {
+		newElement := &singlyLinkedElementOf[T]{value: value}
+		if list.size == 0 {
+			list.first = newElement
+			list.last = newElement
+		} else {
+			list.last.next = newElement
+			list.last = newElement
+		}
+		list.size++
+	}
+}
+
+// Appends a value (one or more) at the end of the list (same as Add())
+func (list *SinglyLinkedListOf[T]) Append(values ...T) {
+	list.Add(values...)
+}
+
+// Prepends a values (or more)
+func (list *SinglyLinkedListOf[T]) Prepend(values ...T) {
+	// in reverse to keep passed order i.e. ["c","d"] -> Prepend(["a","b"]) -> ["a","b","c",d"]
+	for v := len(values) - 1; v >= 0; v-- {
+		newElement := &singlyLinkedElementOf[T]{value: values[v], next: list.first}
+		list.first = newElement
+		if list.size == 0 {
+			list.last = newElement
+		}
+		list.size++
+	}
+}
+
+// Returns the element at index.
+// Second return parameter is true if index is within bounds of the list, otherwise false.
+func (list *SinglyLinkedListOf[T]) Get(index int) (T, bool) {
+	if !list.inRange(index) {
+		var zero T
+		return zero, false
+	}
+
+	return list.element(index).value, true
+}
+
+// Removes the element at index from the list.
+func (list *SinglyLinkedListOf[T]) Remove(index int) {
+	if !list.inRange(index) {
+		return
+	}
+
+	var beforeElement *singlyLinkedElementOf[T]
+	element := list.first
+	if index > 0 {
+		beforeElement = list.element(index - 1)
+		element = beforeElement.next
+	}
+
+	if element == list.first {
+		list.first = element.next
+	}
+	if element == list.last {
+		list.last = beforeElement
+	}
+	if beforeElement != nil {
+		beforeElement.next = element.next
+	}
+
+	list.size--
+}
+
+// Check if values (one or more) are present in the list.
+// Returns true if no arguments are passed at all.
+func (list *SinglyLinkedListOf[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if !list.contain(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns all elements in the list.
+func (list *SinglyLinkedListOf[T]) Elements() []T {
+	values := make([]T, list.size, list.size)
+	for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+		values[e] = element.value
+	}
+	return values
+}
+
+// Returns true if list does not contain any elements.
+func (list *SinglyLinkedListOf[T]) Empty() bool {
+	return list.size == 0
+}
+
+// Returns number of elements within the list.
+func (list *SinglyLinkedListOf[T]) Len() int {
+	return list.size
+}
+
+// Removes all elements from the list.
+func (list *SinglyLinkedListOf[T]) Clear() {
+	list.size = 0
+	list.first = nil
+	list.last = nil
+}
+
+// Sorts values by comparator.
+// Values are sorted in a slice and written back, so the cost is O(n log n).
+func (list *SinglyLinkedListOf[T]) Sort(comparator container.Comparator[T]) {
+	values := list.Elements()
+	slices.SortFunc(values, func(e1, e2 T) int {
+		return int(comparator(e1, e2))
+	})
+	for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+		element.value = values[e]
+	}
+}
+
+// Swaps values of two elements at the given indices.
+func (list *SinglyLinkedListOf[T]) Swap(i, j int) {
+	if list.inRange(i) && list.inRange(j) && i != j {
+		element1, element2 := list.element(i), list.element(j)
+		element1.value, element2.value = element2.value, element1.value
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+// There is no Backward, the elements are only linked forwards.
+func (list *SinglyLinkedListOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for e, element := 0, list.first; element != nil; e, element = e+1, element.next {
+			if !yield(e, element.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (list *SinglyLinkedListOf[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := list.first; element != nil; element = element.next {
+			if !yield(element.value) {
+				return
+			}
+		}
+	}
+}
+
+func (list *SinglyLinkedListOf[T]) String() string {
+	str := "SinglyLinkedList{ "
+	values := []string{}
+	for element := list.first; element != nil; element = element.next {
+		values = append(values, fmt.Sprintf("%v", element.value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (list *SinglyLinkedListOf[T]) contain(value T) bool {
+	for element := list.first; element != nil; element = element.next {
+		if interface{}(element.value) == interface{}(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the list element at index, which must be in range
+func (list *SinglyLinkedListOf[T]) element(index int) *singlyLinkedElementOf[T] {
+	element := list.first
+	for e := 0; e != index; e, element = e+1, element.next {
+	}
+	return element
+}
+
+// Check that the index is withing bounds of the list
+func (list *SinglyLinkedListOf[T]) inRange(index int) bool {
+	return index >= 0 && index < list.size
+}
@@ -0,0 +1,96 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package lists
+
+import (
+	"github.com/aiwuTech/container"
+	"slices"
+	"testing"
+)
+
+func TestSinglyLinkedListOf(t *testing.T) {
+
+	list := NewSinglyLinkedListOf[string]()
+
+	list.Add("e", "f", "g", "a", "b", "c", "d")
+
+	list.Sort(container.OrderedCompareFunctionASC[string])
+	if actualValue, expectedValue := list.Elements(), []string{"a", "b", "c", "d", "e", "f", "g"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	list.Clear()
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	list.Add("b", "c")
+	list.Prepend("a")
+
+	if actualValue := list.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := list.Get(0); actualValue != "a" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+
+	list.Swap(0, 2)
+
+	if actualValue, expectedValue := list.Elements(), []string{"c", "b", "a"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the last element, then appending again
+	list.Remove(2)
+	list.Append("d")
+	list.Remove(1)
+
+	if actualValue, expectedValue := slices.Collect(list.Values()), []string{"c", "d"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := list.Contains("c", "b"); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	if actualValue := list.String(); actualValue != "SinglyLinkedList{ c, d }" {
+		t.Errorf("Got %v expected %v", actualValue, "SinglyLinkedList{ c, d }")
+	}
+
+	list.Remove(1)
+	list.Remove(0)
+
+	if actualValue := list.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue, ok := list.Get(0); actualValue != "" || ok {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+
+}
+
+func BenchmarkSinglyLinkedListOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		list := NewSinglyLinkedListOf[int]()
+		for n := 0; n < 1000; n++ {
+			list.Add(n)
+		}
+		for !list.Empty() {
+			list.Remove(0)
+		}
+	}
+}
@@ -122,3 +122,31 @@ func BenchmarkSinglyLinkedList(b *testing.B) {
 		}
 	}
 }
+
+func TestSinglyLinkedListIterator(t *testing.T) {
+
+	list := NewSinglyLinkedList()
+	list.Add("a", "b", "c")
+
+	it := list.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Value(), []string{"a", "b", "c"}[it.Key().(int)]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+	if it.Next() {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+
+	it.Reset()
+	if !it.Next() || it.Value() != "a" {
+		t.Errorf("Got %v expected %v", it.Value(), "a")
+	}
+
+}
@@ -0,0 +1,420 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Clock告诉ExpiringMap当前时间, 测试时可以注入假的时钟
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type expiringEntry struct {
+	key      interface{}
+	elem     interface{}
+	deadline time.Time // zero means never
+}
+
+func (entry *expiringEntry) expired(now time.Time) bool {
+	return !entry.deadline.IsZero() && !now.Before(entry.deadline)
+}
+
+// ExpiringMap是带过期时间的map, 过期的键值对不会再出现在Get/Keys/Len等的结果中。
+// 过期的键值对在访问时被删除, 也可以调用Sweep或者启动后台的janitor定期清理。
+// 所有有过期时间的键值对按过期时间放在一个二叉堆里, 键值被覆盖或删除后, 堆中旧的项在出堆时跳过。
+type ExpiringMap struct {
+	m         map[interface{}]*expiringEntry
+	deadlines *trees.BinaryHeap
+	keyType   reflect.Type
+	elemType  reflect.Type
+	ttl       time.Duration
+	clock     Clock
+	lock      *sync.Mutex
+	stop      chan struct{}
+	stopped   chan struct{}
+}
+
+var _ MapInterface = &ExpiringMap{}
+
+// ttl是Put使用的默认过期时间, <= 0表示不过期; clock为nil时使用系统时间
+func NewExpiringMap(keyType, elemType reflect.Type, ttl time.Duration, clock Clock) *ExpiringMap {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &ExpiringMap{
+		m:         make(map[interface{}]*expiringEntry),
+		deadlines: trees.NewBinaryHeap(compareDeadline),
+		keyType:   keyType,
+		elemType:  elemType,
+		ttl:       ttl,
+		clock:     clock,
+		lock:      &sync.Mutex{},
+	}
+}
+
+func compareDeadline(e1, e2 interface{}) int8 {
+	return int8(e1.(*expiringEntry).deadline.Compare(e2.(*expiringEntry).deadline))
+}
+
+func (m *ExpiringMap) isAcceptableKey(key interface{}) bool {
+	if key == nil {
+		return false
+	}
+
+	if reflect.TypeOf(key) != m.KeyType() {
+		return false
+	}
+
+	return true
+}
+
+func (m *ExpiringMap) isAcceptableElem(elem interface{}) bool {
+	if elem == nil {
+		return false
+	}
+
+	if reflect.TypeOf(elem) != m.ElemType() {
+		return false
+	}
+
+	return true
+}
+
+func (m *ExpiringMap) Get(key interface{}) interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if entry := m.live(key, m.clock.Now()); entry != nil {
+		return entry.elem
+	}
+	return nil
+}
+
+// 使用默认过期时间添加键值对
+func (m *ExpiringMap) Put(key interface{}, elem interface{}) (interface{}, bool) {
+	return m.PutWithTTL(key, elem, m.ttl)
+}
+
+// 添加键值对, ttl <= 0表示不过期, 返回没有过期的旧元素值
+func (m *ExpiringMap) PutWithTTL(key interface{}, elem interface{}, ttl time.Duration) (interface{}, bool) {
+	if !m.isAcceptableKey(key) || !m.isAcceptableElem(elem) {
+		return nil, false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := m.clock.Now()
+	var oldElem interface{}
+	if entry := m.live(key, now); entry != nil {
+		oldElem = entry.elem
+	}
+
+	// a new entry, so that the heap item of the old one turns stale
+	entry := &expiringEntry{key: key, elem: elem}
+	if ttl > 0 {
+		entry.deadline = now.Add(ttl)
+		m.deadlines.Push(entry)
+	}
+	m.m[key] = entry
+	m.compact()
+
+	return oldElem, true
+}
+
+func (m *ExpiringMap) Remove(key interface{}) interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry := m.live(key, m.clock.Now())
+	if entry == nil {
+		return nil
+	}
+	delete(m.m, key)
+	return entry.elem
+}
+
+// 键值对剩余的过期时间, 不过期的返回0; 键值不存在或已过期则第二个返回值为false
+func (m *ExpiringMap) TTL(key interface{}) (time.Duration, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := m.clock.Now()
+	entry := m.live(key, now)
+	if entry == nil {
+		return 0, false
+	}
+	if entry.deadline.IsZero() {
+		return 0, true
+	}
+	return entry.deadline.Sub(now), true
+}
+
+// 删除所有已过期的键值对, 返回删除的个数
+func (m *ExpiringMap) Sweep() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.sweep(m.clock.Now())
+}
+
+// 启动后台的janitor, 每隔interval调用一次Sweep; 已经启动则什么都不做
+func (m *ExpiringMap) StartJanitor(interval time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.stop != nil {
+		return
+	}
+	m.stop, m.stopped = make(chan struct{}), make(chan struct{})
+	go m.janitor(interval, m.stop, m.stopped)
+}
+
+// 停止后台的janitor, 返回时janitor已经退出
+func (m *ExpiringMap) StopJanitor() {
+	m.lock.Lock()
+	stop, stopped := m.stop, m.stopped
+	m.stop, m.stopped = nil, nil
+	m.lock.Unlock()
+	if stop != nil {
+		close(stop)
+		<-stopped
+	}
+}
+
+func (m *ExpiringMap) janitor(interval time.Duration, stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Sweep()
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (m *ExpiringMap) Clear() {
+	m.lock.Lock()
+	m.m = make(map[interface{}]*expiringEntry)
+	m.deadlines.Clear()
+	m.lock.Unlock()
+}
+
+// number of entries which have not expired
+func (m *ExpiringMap) Len() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sweep(m.clock.Now())
+	return len(m.m)
+}
+
+func (m *ExpiringMap) Empty() bool {
+	return m.Len() == 0
+}
+
+// whether all the keys are in the map and have not expired
+func (m *ExpiringMap) Contains(keys ...interface{}) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := m.clock.Now()
+	for _, key := range keys {
+		if m.live(key, now) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// keys which have not expired, the order is unspecified
+func (m *ExpiringMap) Keys() []interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sweep(m.clock.Now())
+	keys := make([]interface{}, 0, len(m.m))
+	for key := range m.m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// elements which have not expired, the order is unspecified
+func (m *ExpiringMap) Elements() []interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sweep(m.clock.Now())
+	elems := make([]interface{}, 0, len(m.m))
+	for _, entry := range m.m {
+		elems = append(elems, entry.elem)
+	}
+	return elems
+}
+
+func (m *ExpiringMap) ToMap() map[interface{}]interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.sweep(m.clock.Now())
+	replica := make(map[interface{}]interface{}, len(m.m))
+	for key, entry := range m.m {
+		replica[key] = entry.elem
+	}
+	return replica
+}
+
+func (m *ExpiringMap) KeyType() reflect.Type {
+	return m.keyType
+}
+
+func (m *ExpiringMap) ElemType() reflect.Type {
+	return m.elemType
+}
+
+// Returns a stateful iterator over the keys present when it is created, in unspecified order.
+// Keys which expire or are removed meanwhile are skipped.
+func (m *ExpiringMap) Iterator() container.Iterator {
+	return &expiringMapIterator{m: m, keys: m.Keys(), index: -1}
+}
+
+// Returns a range-over-func sequence of (key, element) pairs, the order is unspecified.
+// The walk is over the keys taken when it starts, so the loop body may use the map.
+func (m *ExpiringMap) All() iter.Seq2[interface{}, interface{}] {
+	return container.Seq2(m.Iterator)
+}
+
+// Returns a range-over-func sequence of the keys, the order is unspecified.
+func (m *ExpiringMap) KeySeq() iter.Seq[interface{}] {
+	return container.KeySeq(m.Iterator)
+}
+
+// Returns a range-over-func sequence of the elements, the order is unspecified.
+func (m *ExpiringMap) Values() iter.Seq[interface{}] {
+	return container.ValueSeq(m.Iterator)
+}
+
+func (m *ExpiringMap) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("ExpiringMap<")
+	buf.WriteString(m.KeyType().Kind().String())
+	buf.WriteString(",")
+	buf.WriteString(m.ElemType().Kind().String())
+	buf.WriteString(">{")
+	first := true
+	for key, elem := range m.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", elem))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+// the entry of key if it has not expired, an expired one is deleted; the lock must be held
+func (m *ExpiringMap) live(key interface{}, now time.Time) *expiringEntry {
+	entry, ok := m.m[key]
+	if !ok {
+		return nil
+	}
+	if entry.expired(now) {
+		delete(m.m, key)
+		return nil
+	}
+	return entry
+}
+
+// pops the due deadlines, deleting the entries which are still current; the lock must be held
+func (m *ExpiringMap) sweep(now time.Time) int {
+	count := 0
+	for {
+		top, ok := m.deadlines.Peek()
+		if !ok || !top.(*expiringEntry).expired(now) {
+			return count
+		}
+		m.deadlines.Pop()
+		entry := top.(*expiringEntry)
+		if m.m[entry.key] == entry {
+			delete(m.m, entry.key)
+			count++
+		}
+	}
+}
+
+// rebuilds the heap once the stale items outnumber the live ones; the lock must be held
+func (m *ExpiringMap) compact() {
+	if m.deadlines.Len() <= 2*len(m.m)+16 {
+		return
+	}
+	m.deadlines.Clear()
+	for _, entry := range m.m {
+		if !entry.deadline.IsZero() {
+			m.deadlines.Push(entry)
+		}
+	}
+}
+
+type expiringMapIterator struct {
+	m     *ExpiringMap
+	keys  []interface{}
+	index int
+	key   interface{}
+	elem  interface{}
+}
+
+func (it *expiringMapIterator) Next() bool {
+	it.m.lock.Lock()
+	defer it.m.lock.Unlock()
+	now := it.m.clock.Now()
+	for it.index < len(it.keys) {
+		it.index++
+		if it.index == len(it.keys) {
+			break
+		}
+		if entry := it.m.live(it.keys[it.index], now); entry != nil {
+			it.key, it.elem = entry.key, entry.elem
+			return true
+		}
+	}
+	it.key, it.elem = nil, nil
+	return false
+}
+
+func (it *expiringMapIterator) Value() interface{} {
+	return it.elem
+}
+
+func (it *expiringMapIterator) Key() interface{} {
+	return it.key
+}
+
+func (it *expiringMapIterator) Reset() {
+	it.index = -1
+	it.key, it.elem = nil, nil
+}
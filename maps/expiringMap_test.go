@@ -0,0 +1,172 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.now = clock.now.Add(d)
+}
+
+func TestExpiringMap(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewExpiringMap(reflect.TypeOf(""), reflect.TypeOf(0), time.Minute, clock)
+
+	m.Put("a", 1)
+	m.PutWithTTL("b", 2, time.Second)
+	m.PutWithTTL("c", 3, 0)
+	if _, ok := m.Put(4, 4); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	if actualValue := m.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if ttl, ok := m.TTL("a"); ttl != time.Minute || !ok {
+		t.Errorf("Got %v expected %v", ttl, time.Minute)
+	}
+
+	clock.Advance(time.Second)
+	if actualValue := m.Get("b"); actualValue != nil {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+	if m.Contains("b") || !m.Contains("a", "c") {
+		t.Errorf("Contains error, got %v", m)
+	}
+
+	// overwriting renews the deadline
+	if oldElem, _ := m.Put("a", 10); oldElem != 1 {
+		t.Errorf("Got %v expected %v", oldElem, 1)
+	}
+	clock.Advance(59 * time.Second)
+	if actualValue := m.Get("a"); actualValue != 10 {
+		t.Errorf("Got %v expected %v", actualValue, 10)
+	}
+
+	clock.Advance(time.Second)
+	if actualValue, expectedValue := fmt.Sprint(m.Keys(), m.Elements(), m.ToMap()), "[c] [3] map[c:3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if ttl, ok := m.TTL("c"); ttl != 0 || !ok {
+		t.Errorf("Got %v expected %v", ttl, 0)
+	}
+
+	if actualValue := m.Remove("c"); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if !m.Empty() {
+		t.Errorf("Got %v expected %v", m, "an empty map")
+	}
+}
+
+func TestExpiringMapSweep(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	m := NewExpiringMap(reflect.TypeOf(0), reflect.TypeOf(0), 0, clock)
+
+	for i := 1; i <= 10; i++ {
+		m.PutWithTTL(i, i, time.Duration(i)*time.Second)
+	}
+	// stale deadlines of overwritten and removed keys are skipped
+	m.PutWithTTL(1, 1, time.Hour)
+	m.Remove(2)
+
+	clock.Advance(5 * time.Second)
+	if actualValue := m.Sweep(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	keys := m.Keys()
+	slices.SortFunc(keys, func(k1, k2 interface{}) int { return k1.(int) - k2.(int) })
+	if actualValue, expectedValue := fmt.Sprint(keys), "[1 6 7 8 9 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	count := 0
+	for key, elem := range m.All() {
+		if key != elem {
+			t.Errorf("Got %v expected %v", elem, key)
+		}
+		clock.Advance(time.Second) // keys expiring while iterating are skipped
+		count++
+	}
+	if count >= 6 {
+		t.Errorf("Got %v expected %v", count, "less than 6")
+	}
+
+	// the heap does not grow with overwrites
+	for i := 0; i < 1000; i++ {
+		m.PutWithTTL(0, i, time.Hour)
+	}
+	if m.deadlines.Len() > 2*len(m.m)+16 {
+		t.Errorf("Got %v expected %v", m.deadlines.Len(), "a compacted heap")
+	}
+}
+
+// the loop body may use the map: the keys it puts or removes are odd, the even ones are all seen once
+func TestExpiringMapWriteDuringRange(t *testing.T) {
+	m := NewExpiringMap(reflect.TypeOf(0), reflect.TypeOf(0), time.Hour, &fakeClock{now: time.Unix(0, 0)})
+	for n := 0; n < 200; n++ {
+		m.Put(n, n)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	seen := map[interface{}]int{}
+	for key := range m.KeySeq() {
+		seen[key]++
+		for i := 0; i < 3; i++ {
+			if odd := 2*random.Intn(100) + 1; random.Intn(2) == 0 {
+				m.Put(odd, odd)
+			} else {
+				m.Remove(odd)
+			}
+		}
+	}
+	for n := 0; n < 200; n += 2 {
+		if seen[n] != 1 {
+			t.Errorf("Got %v seen %v times expected %v", n, seen[n], 1)
+		}
+	}
+}
+
+func TestExpiringMapJanitor(t *testing.T) {
+	m := NewExpiringMap(reflect.TypeOf(0), reflect.TypeOf(0), time.Nanosecond, nil)
+	m.Put(1, 1)
+	m.StartJanitor(time.Millisecond)
+	m.StartJanitor(time.Millisecond)
+	defer m.StopJanitor()
+
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		m.lock.Lock()
+		length := len(m.m)
+		m.lock.Unlock()
+		if length == 0 {
+			return
+		}
+	}
+	t.Errorf("Got %v expected %v", m, "an empty map")
+}
@@ -0,0 +1,63 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+// Returns a stateful iterator over the map in key order, positioned before the first key.
+// The value of each key is the slice of all its elements.
+func (m *omap) Iterator() container.Iterator {
+	return m.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the map in reverse key order.
+// The map's lock is only held while stepping, never between two calls.
+func (m *omap) ReverseIterator() container.ReverseIterator {
+	return container.SyncReverseIterator(m.tree.ReverseIterator(), m.lock)
+}
+
+// Returns a range-over-func sequence of (key, elements) pairs in key order.
+// The lock is not held while the loop body runs, which may use the map: the walk goes on with
+// the key after the current one, so keys put ahead of it are seen and keys removed are not.
+func (m *omap) All() iter.Seq2[interface{}, interface{}] {
+	return container.Seq2(m.Iterator)
+}
+
+// Returns a range-over-func sequence of (key, elements) pairs in reverse key order.
+func (m *omap) Backward() iter.Seq2[interface{}, interface{}] {
+	return container.BackwardSeq2(m.ReverseIterator)
+}
+
+// Returns a range-over-func sequence of the keys in order.
+// It is not named Keys, which already returns a slice.
+func (m *omap) KeySeq() iter.Seq[interface{}] {
+	return container.KeySeq(m.Iterator)
+}
+
+// Returns a range-over-func sequence of every element in key order,
+// a key holding several elements yields each of them.
+func (m *omap) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, elems := range m.All() {
+			for _, elem := range elems.([]interface{}) {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -68,15 +68,17 @@ func (k *keys) Add(elem interface{}) bool {
 		return false
 	}
 
-	k.container = append(k.container, elem)
-	sort.Sort(k)
+	// the keys are kept sorted, so a binary search finds the place to insert
+	index, _ := k.Search(elem)
+	k.container = append(k.container, nil)
+	copy(k.container[index+1:], k.container[index:])
+	k.container[index] = elem
 
 	return true
 }
 
 func (k *keys) Remove(elem interface{}) bool {
 	index, contains := k.Search(elem)
-	fmt.Println(index)
 	if !contains {
 		return false
 	}
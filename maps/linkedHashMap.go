@@ -0,0 +1,440 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+type linkedHashEntry struct {
+	key  interface{}
+	elem interface{}
+	prev *linkedHashEntry
+	next *linkedHashEntry
+	// 放到两端的次序: 放到最后时递增, 放到最前时递减, 删除或清空后为0。
+	// 链表总是按seq递增排列, 见linkedHashMapIterator
+	seq int64
+}
+
+// LinkedHashMap是按插入顺序遍历的map, Get/Put/Remove 均为 O(1)。
+// accessOrder为true时按访问顺序排列, Get和Put都会把键值对移到最后,
+// 第一个键值对就是最久没有访问的。
+type LinkedHashMap struct {
+	m           map[interface{}]*linkedHashEntry
+	first       *linkedHashEntry
+	last        *linkedHashEntry
+	keyType     reflect.Type
+	elemType    reflect.Type
+	accessOrder bool
+	frontSeq    int64 // seq of the last entry put in front
+	backSeq     int64 // seq of the last entry put at the back
+	lock        *sync.Mutex
+}
+
+var _ MapInterface = &LinkedHashMap{}
+
+func NewLinkedHashMap(keyType, elemType reflect.Type, accessOrder bool) *LinkedHashMap {
+	return &LinkedHashMap{
+		m:           make(map[interface{}]*linkedHashEntry),
+		keyType:     keyType,
+		elemType:    elemType,
+		accessOrder: accessOrder,
+		lock:        &sync.Mutex{},
+	}
+}
+
+func (m *LinkedHashMap) isAcceptableKey(key interface{}) bool {
+	if key == nil {
+		return false
+	}
+
+	if reflect.TypeOf(key) != m.KeyType() {
+		return false
+	}
+
+	return true
+}
+
+func (m *LinkedHashMap) isAcceptableElem(elem interface{}) bool {
+	if elem == nil {
+		return false
+	}
+
+	if reflect.TypeOf(elem) != m.ElemType() {
+		return false
+	}
+
+	return true
+}
+
+func (m *LinkedHashMap) Get(key interface{}) interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.m[key]
+	if !ok {
+		return nil
+	}
+	if m.accessOrder {
+		m.moveToBack(entry)
+	}
+	return entry.elem
+}
+
+func (m *LinkedHashMap) Put(key interface{}, elem interface{}) (interface{}, bool) {
+	if !m.isAcceptableKey(key) || !m.isAcceptableElem(elem) {
+		return nil, false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if entry, ok := m.m[key]; ok {
+		oldElem := entry.elem
+		entry.elem = elem
+		if m.accessOrder {
+			m.moveToBack(entry)
+		}
+		return oldElem, true
+	}
+
+	entry := &linkedHashEntry{key: key, elem: elem}
+	m.m[key] = entry
+	m.pushBack(entry)
+	return nil, true
+}
+
+func (m *LinkedHashMap) Remove(key interface{}) interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.m[key]
+	if !ok {
+		return nil
+	}
+	delete(m.m, key)
+	m.unlink(entry)
+	entry.seq = 0
+	return entry.elem
+}
+
+// 把键值对移到最前, 键值不存在则返回false
+func (m *LinkedHashMap) MoveToFront(key interface{}) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.m[key]
+	if !ok {
+		return false
+	}
+	if entry != m.first {
+		m.unlink(entry)
+		m.pushFront(entry)
+	}
+	return true
+}
+
+// 把键值对移到最后, 键值不存在则返回false
+func (m *LinkedHashMap) MoveToBack(key interface{}) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	entry, ok := m.m[key]
+	if !ok {
+		return false
+	}
+	m.moveToBack(entry)
+	return true
+}
+
+func (m *LinkedHashMap) Clear() {
+	m.lock.Lock()
+	// the running iterators must see the dropped entries as removed
+	for _, entry := range m.m {
+		entry.seq = 0
+	}
+	m.m = make(map[interface{}]*linkedHashEntry)
+	m.first, m.last = nil, nil
+	m.lock.Unlock()
+}
+
+func (m *LinkedHashMap) Len() int {
+	m.lock.Lock()
+	length := len(m.m)
+	m.lock.Unlock()
+	return length
+}
+
+func (m *LinkedHashMap) Empty() bool {
+	return m.Len() == 0
+}
+
+// whether all the keys are in the map, it does not count as an access
+func (m *LinkedHashMap) Contains(keys ...interface{}) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, key := range keys {
+		if _, ok := m.m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// keys in order
+func (m *LinkedHashMap) Keys() []interface{} {
+	m.lock.Lock()
+	keys := make([]interface{}, 0, len(m.m))
+	for entry := m.first; entry != nil; entry = entry.next {
+		keys = append(keys, entry.key)
+	}
+	m.lock.Unlock()
+	return keys
+}
+
+// elements in the order of their keys
+func (m *LinkedHashMap) Elements() []interface{} {
+	m.lock.Lock()
+	elems := make([]interface{}, 0, len(m.m))
+	for entry := m.first; entry != nil; entry = entry.next {
+		elems = append(elems, entry.elem)
+	}
+	m.lock.Unlock()
+	return elems
+}
+
+func (m *LinkedHashMap) ToMap() map[interface{}]interface{} {
+	m.lock.Lock()
+	replica := make(map[interface{}]interface{}, len(m.m))
+	for key, entry := range m.m {
+		replica[key] = entry.elem
+	}
+	m.lock.Unlock()
+
+	return replica
+}
+
+func (m *LinkedHashMap) KeyType() reflect.Type {
+	return m.keyType
+}
+
+func (m *LinkedHashMap) ElemType() reflect.Type {
+	return m.elemType
+}
+
+func (m *LinkedHashMap) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("LinkedHashMap<")
+	buf.WriteString(m.KeyType().Kind().String())
+	buf.WriteString(",")
+	buf.WriteString(m.ElemType().Kind().String())
+	buf.WriteString(">{")
+	first := true
+	for key, elem := range m.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", elem))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+// Returns a stateful iterator over the map in order, positioned before the first key.
+// Iterating does not count as an access.
+func (m *LinkedHashMap) Iterator() container.Iterator {
+	return m.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the map in reverse order.
+func (m *LinkedHashMap) ReverseIterator() container.ReverseIterator {
+	return container.SyncReverseIterator(&linkedHashMapIterator{m: m}, m.lock)
+}
+
+// Returns a range-over-func sequence of (key, element) pairs in order.
+// The loop body may use the map: the keys it moves to either end, as Get does with accessOrder,
+// are not visited again, nor are the keys it puts or removes, and the others are all visited once.
+func (m *LinkedHashMap) All() iter.Seq2[interface{}, interface{}] {
+	return container.Seq2(m.Iterator)
+}
+
+// Returns a range-over-func sequence of (key, element) pairs in reverse order.
+func (m *LinkedHashMap) Backward() iter.Seq2[interface{}, interface{}] {
+	return container.BackwardSeq2(m.ReverseIterator)
+}
+
+// Returns a range-over-func sequence of the keys in order.
+func (m *LinkedHashMap) KeySeq() iter.Seq[interface{}] {
+	return container.KeySeq(m.Iterator)
+}
+
+// Returns a range-over-func sequence of the elements in the order of their keys.
+func (m *LinkedHashMap) Values() iter.Seq[interface{}] {
+	return container.ValueSeq(m.Iterator)
+}
+
+func (m *LinkedHashMap) moveToBack(entry *linkedHashEntry) {
+	if entry != m.last {
+		m.unlink(entry)
+		m.pushBack(entry)
+	}
+}
+
+func (m *LinkedHashMap) pushBack(entry *linkedHashEntry) {
+	entry.prev, entry.next = m.last, nil
+	m.backSeq++
+	entry.seq = m.backSeq
+	if m.last == nil {
+		m.first = entry
+	} else {
+		m.last.next = entry
+	}
+	m.last = entry
+}
+
+func (m *LinkedHashMap) pushFront(entry *linkedHashEntry) {
+	entry.prev, entry.next = nil, m.first
+	m.frontSeq--
+	entry.seq = m.frontSeq
+	if m.first == nil {
+		m.last = entry
+	} else {
+		m.first.prev = entry
+	}
+	m.first = entry
+}
+
+// takes the entry out of the list, its own links are left as they are
+// so that an iterator which saw it removed can still move on
+func (m *LinkedHashMap) unlink(entry *linkedHashEntry) {
+	if entry.prev == nil {
+		m.first = entry.next
+	} else {
+		entry.prev.next = entry.next
+	}
+	if entry.next == nil {
+		m.last = entry.prev
+	} else {
+		entry.next.prev = entry.prev
+	}
+}
+
+// linkedHashMapIterator is not guarded itself, LinkedHashMap wraps it with container.SyncReverseIterator.
+// As the list is in seq order, the walk visits the entries by increasing seq, from the seq of its
+// entry to the last one given when the walk began: the entries put at either end after that are not
+// visited again, otherwise a walk touching every key would never end. When the loop body moved
+// or removed the entry (a Get with accessOrder does), the iterator goes on from the neighbours the
+// entry had, the removed ones keeping their links; if those were moved too, it looks for the next
+// seq from the other end of the list.
+type linkedHashMapIterator struct {
+	m          *LinkedHashMap
+	entry      *linkedHashEntry
+	prev, next *linkedHashEntry // of entry when the iterator moved to it
+	seq        int64            // of entry when the iterator moved to it
+	frontSeq   int64            // of the map when the walk began
+	backSeq    int64
+	atEnd      bool
+}
+
+func (it *linkedHashMapIterator) begin() {
+	it.frontSeq, it.backSeq = it.m.frontSeq, it.m.backSeq
+}
+
+// whether entry has not been moved nor removed since the iterator moved to it
+func (it *linkedHashMapIterator) inPlace() bool {
+	return it.entry.seq == it.seq
+}
+
+func (it *linkedHashMapIterator) moveTo(entry *linkedHashEntry) {
+	it.entry = entry
+	if entry != nil {
+		it.prev, it.next, it.seq = entry.prev, entry.next, entry.seq
+	}
+}
+
+func (it *linkedHashMapIterator) Next() bool {
+	var entry *linkedHashEntry
+	switch {
+	case it.atEnd:
+		return false
+	case it.entry == nil:
+		it.begin()
+		entry = it.m.first
+	case it.inPlace():
+		entry = it.entry.next
+	default:
+		// the removed entries keep the links they had, and lead back to the list
+		for entry = it.next; entry != nil && entry.seq == 0; entry = entry.next {
+		}
+		if entry != nil && (entry.seq <= it.seq || entry.seq > it.backSeq) {
+			// moved as well, its former successor is lost
+			for entry = it.m.first; entry != nil && entry.seq <= it.seq; entry = entry.next {
+			}
+		}
+	}
+	if entry != nil && entry.seq > it.backSeq {
+		entry = nil
+	}
+	it.moveTo(entry)
+	it.atEnd = entry == nil
+	return !it.atEnd
+}
+
+func (it *linkedHashMapIterator) Prev() bool {
+	var entry *linkedHashEntry
+	switch {
+	case it.atEnd:
+		it.begin()
+		entry = it.m.last
+	case it.entry == nil:
+		return false
+	case it.inPlace():
+		entry = it.entry.prev
+	default:
+		for entry = it.prev; entry != nil && entry.seq == 0; entry = entry.prev {
+		}
+		if entry != nil && (entry.seq >= it.seq || entry.seq < it.frontSeq) {
+			for entry = it.m.last; entry != nil && entry.seq >= it.seq; entry = entry.prev {
+			}
+		}
+	}
+	if entry != nil && entry.seq < it.frontSeq {
+		entry = nil
+	}
+	it.moveTo(entry)
+	it.atEnd = false
+	return entry != nil
+}
+
+func (it *linkedHashMapIterator) Value() interface{} {
+	return it.entry.elem
+}
+
+func (it *linkedHashMapIterator) Key() interface{} {
+	return it.entry.key
+}
+
+func (it *linkedHashMapIterator) Reset() {
+	it.entry, it.atEnd = nil, false
+}
+
+func (it *linkedHashMapIterator) End() {
+	it.entry, it.atEnd = nil, true
+}
@@ -0,0 +1,251 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestLinkedHashMap(t *testing.T) {
+	m := NewLinkedHashMap(reflect.TypeOf(0), reflect.TypeOf(""), false)
+
+	for _, key := range []int{3, 1, 2} {
+		m.Put(key, string(rune('a'+key)))
+	}
+	if _, ok := m.Put("4", "e"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := m.Put(4, 4); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if oldElem, ok := m.Put(1, "x"); oldElem != "b" || !ok {
+		t.Errorf("Got %v expected %v", oldElem, "b")
+	}
+
+	// insertion order, neither Get nor overwriting moves a key
+	m.Get(3)
+	if actualValue, expectedValue := m.Keys(), []interface{}{3, 1, 2}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Elements(), []interface{}{"d", "x", "c"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !m.MoveToBack(3) || !m.MoveToFront(2) || m.MoveToFront(5) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+	if actualValue, expectedValue := m.Keys(), []interface{}{2, 1, 3}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(m.KeySeq()), []interface{}{2, 1, 3}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	backward := []interface{}{}
+	for key := range m.Backward() {
+		backward = append(backward, key)
+	}
+	if expectedValue := []interface{}{3, 1, 2}; !reflect.DeepEqual(backward, expectedValue) {
+		t.Errorf("Got %v expected %v", backward, expectedValue)
+	}
+
+	it := m.ReverseIterator()
+	it.End()
+	if !it.Prev() || it.Key() != 3 || !it.Prev() || it.Key() != 1 || !it.Next() || it.Key() != 3 || it.Next() {
+		t.Errorf("Got %v expected %v", it.Key(), 3)
+	}
+
+	if elem := m.Remove(1); elem != "x" || m.Len() != 2 || m.Contains(1) {
+		t.Errorf("Got %v expected %v", elem, "x")
+	}
+	if actualValue, expectedValue := m.String(), "LinkedHashMap<int,string>{2:c 3:d}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	m.Clear()
+	if !m.Empty() || len(m.Keys()) != 0 {
+		t.Errorf("Got %v expected %v", m.Keys(), "none")
+	}
+}
+
+func TestLinkedHashMapAccessOrder(t *testing.T) {
+	m := NewLinkedHashMap(reflect.TypeOf(0), reflect.TypeOf(0), true)
+	for n := 0; n < 5; n++ {
+		m.Put(n, n)
+	}
+
+	m.Get(1)
+	m.Put(0, 10)
+	m.Get(7)
+	if actualValue, expectedValue := m.Keys(), []interface{}{2, 3, 4, 1, 0}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// Contains does not count as an access
+	m.Contains(2)
+	if first := m.Keys()[0]; first != 2 {
+		t.Errorf("Got %v expected %v", first, 2)
+	}
+}
+
+// a Get in the loop body moves the key to the back, the loop goes on in the former order
+func TestLinkedHashMapGetDuringRange(t *testing.T) {
+	m := NewLinkedHashMap(reflect.TypeOf(0), reflect.TypeOf(0), true)
+	for n := 0; n < 5; n++ {
+		m.Put(n, n)
+	}
+
+	var keys []interface{}
+	for key := range m.KeySeq() {
+		keys = append(keys, key)
+		m.Get(key)
+	}
+	if expectedValue := []interface{}{0, 1, 2, 3, 4}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+	if actualValue, expectedValue := m.Keys(), []interface{}{0, 1, 2, 3, 4}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// only every other key is touched, and the next key is removed
+	keys = nil
+	for key, elem := range m.All() {
+		keys = append(keys, key)
+		if key.(int)%2 == 0 {
+			m.Get(key)
+		}
+		if elem == 1 {
+			m.Remove(2)
+		}
+	}
+	if expectedValue := []interface{}{0, 1, 3, 4}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+	if actualValue, expectedValue := m.Keys(), []interface{}{1, 3, 0, 4}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys = nil
+	for key := range m.Backward() {
+		keys = append(keys, key)
+		m.Get(key)
+		m.MoveToFront(key)
+	}
+	if expectedValue := []interface{}{4, 0, 3, 1}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+}
+
+func TestLinkedHashMapWriteDuringRange(t *testing.T) {
+	m := NewLinkedHashMap(reflect.TypeOf(""), reflect.TypeOf(0), false)
+	for i, key := range []string{"A", "B", "C", "D"} {
+		m.Put(key, i)
+	}
+
+	// the next key is moved away as well
+	var keys []interface{}
+	for key := range m.KeySeq() {
+		keys = append(keys, key)
+		if key == "A" {
+			m.MoveToBack("A")
+			m.MoveToBack("B")
+		}
+	}
+	if expectedValue := []interface{}{"A", "C", "D"}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+
+	keys = nil
+	for key := range m.Backward() {
+		keys = append(keys, key)
+		if key == "B" {
+			m.MoveToFront("B")
+			m.MoveToFront("A")
+		}
+	}
+	if expectedValue := []interface{}{"B", "D", "C"}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+
+	keys = nil
+	for key := range m.KeySeq() {
+		keys = append(keys, key)
+		m.Clear()
+		m.Put("E", 4)
+	}
+	if expectedValue := []interface{}{"A"}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+
+	// the loop body moves, removes and puts back odd keys, the even ones must all be seen once and in order
+	for _, backward := range []bool{false, true} {
+		m := NewLinkedHashMap(reflect.TypeOf(0), reflect.TypeOf(0), true)
+		for n := 0; n < 200; n++ {
+			m.Put(n, n)
+		}
+		all := m.KeySeq()
+		if backward {
+			all = func(yield func(interface{}) bool) {
+				for key := range m.Backward() {
+					if !yield(key) {
+						return
+					}
+				}
+			}
+		}
+
+		random := rand.New(rand.NewSource(1))
+		var evens []int
+		for key := range all {
+			if key.(int)%2 == 0 {
+				evens = append(evens, key.(int))
+			}
+			for i := 0; i < 3; i++ {
+				switch odd := 2*random.Intn(100) + 1; random.Intn(4) {
+				case 0:
+					m.Get(odd)
+				case 1:
+					m.MoveToFront(odd)
+				case 2:
+					m.Remove(odd)
+				default:
+					m.Put(odd, odd)
+				}
+			}
+		}
+		if backward {
+			slices.Reverse(evens)
+		}
+		if len(evens) != 100 || !slices.IsSorted(evens) || evens[0] != 0 || evens[99] != 198 {
+			t.Errorf("Got %v expected %v", evens, "0, 2, ..., 198")
+		}
+	}
+}
+
+func BenchmarkLinkedHashMap(b *testing.B) {
+	m := NewLinkedHashMap(reflect.TypeOf(0), reflect.TypeOf(0), true)
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			m.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			m.Get(n)
+		}
+		for n := 0; n < 1000; n++ {
+			m.Remove(n)
+		}
+	}
+}
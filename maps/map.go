@@ -18,13 +18,10 @@ import (
 	"github.com/aiwuTech/container"
 )
 
-type MapInterface interface {
+// 只读的map, 不可变的map只实现这一部分
+type ReadOnlyMapInterface interface {
 	// 获取键值对应的元素值, 没有则返回nil
 	Get(key interface{}) interface{}
-	// 添加键值对，并返回旧的元素值，若没有则返回nil，true
-	Put(key interface{}, elem interface{}) (interface{}, bool)
-	// 删除键值对，返回旧的元素值，若没有返回nil
-	Remove(key interface{}) interface{}
 	// 获取所有键值
 	Keys() []interface{}
 	// 键值对的字典
@@ -33,5 +30,20 @@ type MapInterface interface {
 	KeyType() reflect.Type
 	// 获取元素的类型
 	ElemType() reflect.Type
+	Empty() bool
+	Len() int
+	// 是否包含所有的键
+	Contains(keys ...interface{}) bool
+	Elements() []interface{}
+	String() string
+	Iterator() container.Iterator
+}
+
+type MapInterface interface {
+	ReadOnlyMapInterface
+	// 添加键值对，并返回旧的元素值，若没有则返回nil，true
+	Put(key interface{}, elem interface{}) (interface{}, bool)
+	// 删除键值对，返回旧的元素值，若没有返回nil
+	Remove(key interface{}) interface{}
 	container.ContainerInterface
 }
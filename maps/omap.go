@@ -16,6 +16,9 @@ package maps
 import (
 	"bytes"
 	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
 	"reflect"
 	"sync"
 )
@@ -35,38 +38,73 @@ type OrderedMapper interface {
 	Head(toKey interface{}) OrderedMapper
 	// 获取 [fromKey, toKey)区间的OrderMapper
 	Sub(fromKey, toKey interface{}) OrderedMapper
-	// 获取 > fromKey的键值的OrderMapper
+	// 获取 >= fromKey的键值的OrderMapper
 	Tail(fromKey interface{}) OrderedMapper
+	// 按键值顺序遍历 (键, 所有元素值)
+	All() iter.Seq2[interface{}, interface{}]
+	// 按键值逆序遍历 (键, 所有元素值)
+	Backward() iter.Seq2[interface{}, interface{}]
+	// 按顺序遍历所有键值
+	KeySeq() iter.Seq[interface{}]
+	// 按键值顺序遍历所有元素值
+	Values() iter.Seq[interface{}]
+	// O(1)获取当前时刻的快照, 快照与原map互不影响各自之后的修改
+	Snapshot() OrderedMapper
 }
 
+// omap把键值存放在红黑树中, 每个键值对应其所有元素值组成的slice,
+// Put/Remove/Get 均为 O(log n)
 type omap struct {
-	keys     Keys
-	elemType reflect.Type
-	m        map[interface{}][]interface{}
-	lock     *sync.Mutex
+	tree        *trees.RBTree
+	compareFunc container.CompareFunction
+	keyType     reflect.Type
+	elemType    reflect.Type
+	length      int
+	lock        *sync.Mutex
 }
 
+// Returns the elements of key as a []interface{}, which is nil if the key is missing.
 func (m *omap) Get(key interface{}) interface{} {
+	if !m.isAcceptableKey(key) {
+		return []interface{}(nil)
+	}
+
 	m.lock.Lock()
-	e := m.m[key]
+	elems := m.elemsOf(key)
 	m.lock.Unlock()
-	return e
+	return elems
+}
+
+// the elements of key, nil if it is missing
+func (m *omap) elemsOf(key interface{}) []interface{} {
+	elems, _ := m.tree.Get(key)
+	slice, _ := elems.([]interface{})
+	return slice
 }
 
 func (m *omap) GetFirst(key interface{}) interface{} {
-	m.lock.Lock()
-	var e interface{}
-	if len(m.m[key]) == 0 {
-		e = nil
-	} else {
-		e = m.m[key][0]
+	elems := m.GetAll(key)
+	if len(elems) == 0 {
+		return nil
 	}
-	m.lock.Unlock()
-	return e
+	return elems[0]
 }
 
 func (m *omap) GetAll(key interface{}) []interface{} {
-	return m.Get(key).([]interface{})
+	elems, _ := m.Get(key).([]interface{})
+	return elems
+}
+
+func (m *omap) isAcceptableKey(key interface{}) bool {
+	if key == nil {
+		return false
+	}
+
+	if reflect.TypeOf(key) != m.KeyType() {
+		return false
+	}
+
+	return true
 }
 
 func (m *omap) isAcceptableElem(elem interface{}) bool {
@@ -82,49 +120,47 @@ func (m *omap) isAcceptableElem(elem interface{}) bool {
 }
 
 func (m *omap) Put(key interface{}, elem interface{}) (interface{}, bool) {
-	if !m.isAcceptableElem(elem) {
+	if !m.isAcceptableKey(key) || !m.isAcceptableElem(elem) {
 		return nil, false
 	}
 
 	m.lock.Lock()
-	oldElems, ok := m.m[key]
-	if ok {
-		m.m[key] = append(m.m[key], elem)
-	} else {
-		m.keys.Add(key)
-		m.m[key] = make([]interface{}, 0)
-		m.m[key] = append(m.m[key], elem)
-	}
+	oldElems := m.elemsOf(key)
+	// the returned old elements must not see the new one
+	m.tree.Put(key, append(oldElems[:len(oldElems):len(oldElems)], elem))
+	m.length++
 	m.lock.Unlock()
 
 	return oldElems, true
 }
 
 func (m *omap) Remove(key interface{}) interface{} {
+	if !m.isAcceptableKey(key) {
+		return []interface{}(nil)
+	}
+
 	m.lock.Lock()
-	oldElem, ok := m.m[key]
-	if ok {
-		delete(m.m, key)
-		m.keys.Remove(key)
+	oldElems := m.elemsOf(key)
+	if _, ok := m.tree.Get(key); ok {
+		m.tree.Remove(key)
+		m.length -= len(oldElems)
 	}
 	m.lock.Unlock()
 
-	return oldElem
+	return oldElems
 }
 
 func (m *omap) Clear() {
 	m.lock.Lock()
-	m.m = make(map[interface{}][]interface{})
-	m.keys.Clear()
+	m.tree.Clear()
+	m.length = 0
 	m.lock.Unlock()
 }
 
+// number of elements, a key holding several elements counts each of them
 func (m *omap) Len() int {
 	m.lock.Lock()
-	length := 0
-	for _, val := range m.m {
-		length += len(val)
-	}
+	length := m.length
 	m.lock.Unlock()
 
 	return length
@@ -132,13 +168,15 @@ func (m *omap) Len() int {
 
 func (m *omap) Contains(keys ...interface{}) bool {
 	m.lock.Lock()
-	for key := range keys {
-		if _, ok := m.m[key]; !ok {
-			m.lock.Unlock()
+	defer m.lock.Unlock()
+	for _, key := range keys {
+		if !m.isAcceptableKey(key) {
+			return false
+		}
+		if _, ok := m.tree.Get(key); !ok {
 			return false
 		}
 	}
-	m.lock.Unlock()
 	return true
 }
 
@@ -147,50 +185,50 @@ func (m *omap) Empty() bool {
 }
 
 func (m *omap) FirstKey() interface{} {
-	if m.Len() == 0 {
-		return nil
-	}
+	m.lock.Lock()
+	key, _, _ := m.tree.Min()
+	m.lock.Unlock()
 
-	return m.keys.Get(0)
+	return key
 }
 
 func (m *omap) LastKey() interface{} {
-	len := m.Len()
-	if len == 0 {
-		return nil
-	}
+	m.lock.Lock()
+	key, _, _ := m.tree.Max()
+	m.lock.Unlock()
 
-	return m.keys.Get(len - 1)
+	return key
 }
 
+// a nil bound means unbounded
 func (m *omap) Sub(fromKey, toKey interface{}) OrderedMapper {
-	newOmap := NewOrderMap(NewKeys(m.keys.CompareFunc(), m.keys.ElemType()), m.ElemType())
-	omapLen := m.Len()
-	if omapLen == 0 {
-		return newOmap
-	}
+	newOmap := m.empty()
 
-	beginIndex, contains := m.keys.Search(fromKey)
-	if !contains {
-		beginIndex = 0
-	}
-	endIndex, contains := m.keys.Search(toKey)
-	if !contains {
-		endIndex = omapLen
+	m.lock.Lock()
+	for key, elems := range m.tree.Range(fromKey, toKey, true, false) {
+		elems := elems.([]interface{})
+		newOmap.tree.Put(key, append([]interface{}{}, elems...))
+		newOmap.length += len(elems)
 	}
+	m.lock.Unlock()
 
-	var key interface{}
-	var elems []interface{}
-	for i := beginIndex; i < endIndex; i++ {
-		key = m.keys.Get(i)
-		elems = m.GetAll(key)
+	return newOmap
+}
 
-		for _, elem := range elems {
-			newOmap.Put(key, elem)
-		}
-	}
+// 快照与原map共享红黑树节点, 修改时才复制路径上的节点,
+// 元素值的slice从不原地修改, 因此也可以共享
+func (m *omap) Snapshot() OrderedMapper {
+	m.lock.Lock()
+	defer m.lock.Unlock()
 
-	return newOmap
+	return &omap{
+		tree:        m.tree.Snapshot(),
+		compareFunc: m.compareFunc,
+		keyType:     m.keyType,
+		elemType:    m.elemType,
+		length:      m.length,
+		lock:        &sync.Mutex{},
+	}
 }
 
 func (m *omap) Head(toKey interface{}) OrderedMapper {
@@ -202,22 +240,28 @@ func (m *omap) Tail(fromKey interface{}) OrderedMapper {
 }
 
 func (m *omap) Keys() []interface{} {
-	return m.keys.GetAll()
+	m.lock.Lock()
+	keys := m.tree.Keys()
+	m.lock.Unlock()
+
+	return keys
 }
 
 func (m *omap) Elements() []interface{} {
-	elems := make([]interface{}, 0)
-	for _, key := range m.Keys() {
-		elems = append(elems, m.GetAll(key)...)
+	m.lock.Lock()
+	elems := make([]interface{}, 0, m.length)
+	for values := range m.tree.Values() {
+		elems = append(elems, values.([]interface{})...)
 	}
+	m.lock.Unlock()
 
 	return elems
 }
 
 func (m *omap) ToMap() map[interface{}]interface{} {
 	m.lock.Lock()
-	replica := make(map[interface{}]interface{})
-	for k, v := range m.m {
+	replica := make(map[interface{}]interface{}, m.tree.Len())
+	for k, v := range m.tree.All() {
 		replica[k] = v
 	}
 	m.lock.Unlock()
@@ -226,7 +270,7 @@ func (m *omap) ToMap() map[interface{}]interface{} {
 }
 
 func (m *omap) KeyType() reflect.Type {
-	return m.keys.ElemType()
+	return m.keyType
 }
 
 func (m *omap) ElemType() reflect.Type {
@@ -241,29 +285,42 @@ func (m *omap) String() string {
 	buf.WriteString(m.ElemType().Kind().String())
 	buf.WriteString(">{")
 	first := true
-	omapLen := m.Len()
-	for i := 0; i < omapLen; i++ {
+	for key, elems := range m.All() {
 		if first {
 			first = false
 		} else {
 			buf.WriteString(" ")
 		}
 
-		key := m.keys.Get(i)
 		buf.WriteString(fmt.Sprintf("%v", key))
 		buf.WriteString(":")
-		buf.WriteString(fmt.Sprintf("%+v", m.Get(key)))
+		buf.WriteString(fmt.Sprintf("%+v", elems))
 	}
 	buf.WriteString("}")
 
 	return buf.String()
 }
 
-func NewOrderMap(keys Keys, elemType reflect.Type) OrderedMapper {
+// an empty omap with the same key order and types
+func (m *omap) empty() *omap {
+	return newOmap(m.compareFunc, m.keyType, m.elemType)
+}
+
+func newOmap(compareFunc container.CompareFunction, keyType, elemType reflect.Type) *omap {
 	return &omap{
-		keys:     keys,
-		elemType: elemType,
-		m:        make(map[interface{}][]interface{}),
-		lock:     &sync.Mutex{},
+		tree:        trees.NewRBTree(compareFunc),
+		compareFunc: compareFunc,
+		keyType:     keyType,
+		elemType:    elemType,
+		lock:        &sync.Mutex{},
+	}
+}
+
+// keys提供键值的比较函数和类型, 其中已有的键值也会加入map, 暂无元素值
+func NewOrderMap(keys Keys, elemType reflect.Type) OrderedMapper {
+	m := newOmap(keys.CompareFunc(), keys.ElemType(), elemType)
+	for _, key := range keys.GetAll() {
+		m.tree.Put(key, []interface{}{})
 	}
+	return m
 }
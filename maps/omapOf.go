@@ -0,0 +1,263 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"sync"
+)
+
+// OrderedMapOf is the type-parameterized version of OrderedMapper.
+// Like OrderedMapper every key may hold several elements, kept in insertion order.
+type OrderedMapOf[K comparable, V any] struct {
+	tree       *trees.RBTreeOf[K, []V]
+	comparator container.Comparator[K]
+	length     int
+	lock       *sync.Mutex
+}
+
+func NewOrderedMapOf[K comparable, V any](comparator container.Comparator[K]) *OrderedMapOf[K, V] {
+	return &OrderedMapOf[K, V]{
+		tree:       trees.NewRBTreeOf[K, []V](comparator),
+		comparator: comparator,
+		lock:       &sync.Mutex{},
+	}
+}
+
+// 获取键值对应的所有元素值, 没有则返回nil
+func (m *OrderedMapOf[K, V]) Get(key K) []V {
+	m.lock.Lock()
+	elems, _ := m.tree.Get(key)
+	m.lock.Unlock()
+	return elems
+}
+
+// 获取key对应的第一个值
+func (m *OrderedMapOf[K, V]) GetFirst(key K) (elem V, ok bool) {
+	elems := m.Get(key)
+	if len(elems) == 0 {
+		return
+	}
+	return elems[0], true
+}
+
+// 获取key对应的所有值
+func (m *OrderedMapOf[K, V]) GetAll(key K) []V {
+	return m.Get(key)
+}
+
+// 添加键值对，并返回旧的元素值
+func (m *OrderedMapOf[K, V]) Put(key K, elem V) []V {
+	m.lock.Lock()
+	oldElems, _ := m.tree.Get(key)
+	m.tree.Put(key, append(oldElems[:len(oldElems):len(oldElems)], elem))
+	m.length++
+	m.lock.Unlock()
+
+	return oldElems
+}
+
+// 删除键值对，返回旧的元素值
+func (m *OrderedMapOf[K, V]) Remove(key K) []V {
+	m.lock.Lock()
+	oldElems, ok := m.tree.Get(key)
+	if ok {
+		m.tree.Remove(key)
+		m.length -= len(oldElems)
+	}
+	m.lock.Unlock()
+
+	return oldElems
+}
+
+func (m *OrderedMapOf[K, V]) Clear() {
+	m.lock.Lock()
+	m.tree.Clear()
+	m.length = 0
+	m.lock.Unlock()
+}
+
+// number of elements, a key holding several elements counts each of them
+func (m *OrderedMapOf[K, V]) Len() int {
+	m.lock.Lock()
+	length := m.length
+	m.lock.Unlock()
+	return length
+}
+
+func (m *OrderedMapOf[K, V]) Empty() bool {
+	return m.Len() == 0
+}
+
+// whether all the keys are in the map
+func (m *OrderedMapOf[K, V]) Contains(keys ...K) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, key := range keys {
+		if !m.tree.ContainsKey(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// 获取第一个键值, 没有则第二个返回值为false
+func (m *OrderedMapOf[K, V]) FirstKey() (key K, ok bool) {
+	m.lock.Lock()
+	key, _, ok = m.tree.Min()
+	m.lock.Unlock()
+	return
+}
+
+// 获取最后一个键值, 没有则第二个返回值为false
+func (m *OrderedMapOf[K, V]) LastKey() (key K, ok bool) {
+	m.lock.Lock()
+	key, _, ok = m.tree.Max()
+	m.lock.Unlock()
+	return
+}
+
+// 获取 [fromKey, toKey)区间的OrderedMapOf
+func (m *OrderedMapOf[K, V]) Sub(fromKey, toKey K) *OrderedMapOf[K, V] {
+	return m.sub(&fromKey, &toKey)
+}
+
+// 获取 < toKey的键值的OrderedMapOf
+func (m *OrderedMapOf[K, V]) Head(toKey K) *OrderedMapOf[K, V] {
+	return m.sub(nil, &toKey)
+}
+
+// 获取 >= fromKey的键值的OrderedMapOf
+func (m *OrderedMapOf[K, V]) Tail(fromKey K) *OrderedMapOf[K, V] {
+	return m.sub(&fromKey, nil)
+}
+
+// nil bound means unbounded
+func (m *OrderedMapOf[K, V]) sub(fromKey, toKey *K) *OrderedMapOf[K, V] {
+	newOmap := NewOrderedMapOf[K, V](m.comparator)
+
+	m.lock.Lock()
+	for _, key := range m.tree.Keys() {
+		if fromKey != nil && m.comparator(key, *fromKey) < 0 {
+			continue
+		}
+		if toKey != nil && m.comparator(key, *toKey) >= 0 {
+			break
+		}
+		elems, _ := m.tree.Get(key)
+		newOmap.tree.Put(key, append([]V{}, elems...))
+		newOmap.length += len(elems)
+	}
+	m.lock.Unlock()
+
+	return newOmap
+}
+
+// 获取所有键值
+func (m *OrderedMapOf[K, V]) Keys() []K {
+	m.lock.Lock()
+	keys := m.tree.Keys()
+	m.lock.Unlock()
+	return keys
+}
+
+// all the elements ordered by key
+func (m *OrderedMapOf[K, V]) Elements() []V {
+	m.lock.Lock()
+	elems := make([]V, 0, m.length)
+	for _, values := range m.tree.Elements() {
+		elems = append(elems, values...)
+	}
+	m.lock.Unlock()
+
+	return elems
+}
+
+// 键值对的字典
+func (m *OrderedMapOf[K, V]) ToMap() map[K][]V {
+	m.lock.Lock()
+	replica := make(map[K][]V, m.tree.Len())
+	for _, key := range m.tree.Keys() {
+		elems, _ := m.tree.Get(key)
+		replica[key] = elems
+	}
+	m.lock.Unlock()
+
+	return replica
+}
+
+// Returns a range-over-func sequence of (key, elements) pairs in key order.
+// The keys are taken when the loop starts and the lock is not held while the loop
+// body runs, so the body may use the map; keys removed meanwhile are skipped.
+func (m *OrderedMapOf[K, V]) All() iter.Seq2[K, []V] {
+	return func(yield func(K, []V) bool) {
+		for _, key := range m.Keys() {
+			m.lock.Lock()
+			elems, ok := m.tree.Get(key)
+			m.lock.Unlock()
+			if ok && !yield(key, elems) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the keys in order.
+// It is not named Keys, which already returns a slice.
+func (m *OrderedMapOf[K, V]) KeySeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of every element in key order.
+func (m *OrderedMapOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, elems := range m.All() {
+			for _, elem := range elems {
+				if !yield(elem) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (m *OrderedMapOf[K, V]) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("OrderedMap{")
+	first := true
+	for _, key := range m.Keys() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", m.Get(key)))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
@@ -0,0 +1,123 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"github.com/aiwuTech/container"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMapOf(t *testing.T) {
+	m := NewOrderedMapOf[int, string](container.OrderedCompareFunctionASC[int])
+
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+	if oldElems := m.Put(1, "x"); !slices.Equal(oldElems, []string{"a"}) {
+		t.Errorf("Got %v expected %v", oldElems, []string{"a"})
+	}
+
+	if actualValue, expectedValue := m.GetAll(1), []string{"a", "x"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if elem, ok := m.GetFirst(1); elem != "a" || !ok {
+		t.Errorf("Got %v expected %v", elem, "a")
+	}
+	if elem, ok := m.GetFirst(5); elem != "" || ok || m.Get(5) != nil {
+		t.Errorf("Got %v expected %v", elem, "")
+	}
+	if m.Len() != 4 || !m.Contains(1, 2, 3) || m.Contains(4) {
+		t.Errorf("Got %v expected %v", m.Len(), 4)
+	}
+	if key, ok := m.FirstKey(); key != 1 || !ok {
+		t.Errorf("Got %v expected %v", key, 1)
+	}
+	if key, ok := m.LastKey(); key != 3 || !ok {
+		t.Errorf("Got %v expected %v", key, 3)
+	}
+
+	if actualValue, expectedValue := m.Keys(), []int{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Elements(), []string{"a", "x", "b", "c"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(m.Values()), []string{"a", "x", "b", "c"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	replica := m.ToMap()
+	if !maps.EqualFunc(replica, map[int][]string{1: {"a", "x"}, 2: {"b"}, 3: {"c"}}, slices.Equal) {
+		t.Errorf("Got %v expected %v", replica, "1:[a x] 2:[b] 3:[c]")
+	}
+	if actualValue, expectedValue := m.String(), "OrderedMap{1:[a x] 2:[b] 3:[c]}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if oldElems := m.Remove(1); !slices.Equal(oldElems, []string{"a", "x"}) {
+		t.Errorf("Got %v expected %v", oldElems, []string{"a", "x"})
+	}
+	if m.Remove(1) != nil || m.Len() != 2 {
+		t.Errorf("Got %v expected %v", m.Len(), 2)
+	}
+
+	m.Clear()
+	if _, ok := m.FirstKey(); ok || !m.Empty() {
+		t.Errorf("Got %v expected %v", m, "empty")
+	}
+}
+
+func TestOrderedMapOfSub(t *testing.T) {
+	m := NewOrderedMapOf[int, int](container.OrderedCompareFunctionASC[int])
+	for n := 1; n <= 5; n++ {
+		m.Put(n, n*10)
+	}
+
+	// [fromKey, toKey), < toKey, >= fromKey
+	if actualValue, expectedValue := m.Sub(2, 4).Keys(), []int{2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Head(3).Keys(), []int{1, 2}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tail := m.Tail(3)
+	if actualValue, expectedValue := tail.Keys(), []int{3, 4, 5}; !slices.Equal(actualValue, expectedValue) || tail.Len() != 3 {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the loop body may use the map, the keys removed meanwhile are skipped
+	var keys []int
+	for key := range m.KeySeq() {
+		keys = append(keys, key)
+		if key == 2 {
+			m.Remove(3)
+		}
+	}
+	if expectedValue := []int{1, 2, 4, 5}; !slices.Equal(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+}
+
+func BenchmarkOrderedMapOf(b *testing.B) {
+	m := NewOrderedMapOf[int, int](container.OrderedCompareFunctionASC[int])
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			m.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			m.Remove(n)
+		}
+	}
+}
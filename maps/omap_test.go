@@ -0,0 +1,265 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func newTestOrderMap() OrderedMapper {
+	return NewOrderMap(NewKeys(container.IntCompareFunctionASC, reflect.TypeOf(0)), reflect.TypeOf(""))
+}
+
+func TestOrderMap(t *testing.T) {
+	m := newTestOrderMap()
+
+	m.Put(3, "c")
+	m.Put(1, "a")
+	m.Put(2, "b")
+	if oldElems, ok := m.Put(1, "x"); !ok || !reflect.DeepEqual(oldElems, []interface{}{"a"}) {
+		t.Errorf("Got %v expected %v", oldElems, []interface{}{"a"})
+	}
+	if _, ok := m.Put("4", "d"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := m.Put(4, 4); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	// every key holds all its elements in insertion order
+	if actualValue, expectedValue := m.GetAll(1), []interface{}{"a", "x"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.GetFirst(1); actualValue != "a" {
+		t.Errorf("Got %v expected %v", actualValue, "a")
+	}
+	if actualValue := m.GetAll(5); len(actualValue) != 0 || m.GetFirst(5) != nil {
+		t.Errorf("Got %v expected %v", actualValue, "none")
+	}
+	// a missing key has a nil []interface{}, as when the elements were kept in a Go map
+	oldElems, _ := m.Put(5, "e")
+	for _, elems := range []interface{}{m.Get(6), m.Get("1"), oldElems, m.Remove(6), m.Remove("1")} {
+		if slice, ok := elems.([]interface{}); !ok || slice != nil {
+			t.Errorf("Got %#v expected %#v", elems, []interface{}(nil))
+		}
+	}
+	m.Remove(5)
+	if m.Len() != 4 || !m.Contains(1, 2, 3) || m.Contains(4) {
+		t.Errorf("Got %v expected %v", m.Len(), 4)
+	}
+	if first, last := m.FirstKey(), m.LastKey(); first != 1 || last != 3 {
+		t.Errorf("Got %v expected %v", []interface{}{first, last}, []interface{}{1, 3})
+	}
+
+	if actualValue, expectedValue := m.Keys(), []interface{}{1, 2, 3}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Elements(), []interface{}{"a", "x", "b", "c"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.String(), "OrderedMap<int,string>{1:[a x] 2:[b] 3:[c]}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// Remove drops all the elements of the key
+	if oldElems := m.Remove(1); !reflect.DeepEqual(oldElems, []interface{}{"a", "x"}) {
+		t.Errorf("Got %v expected %v", oldElems, []interface{}{"a", "x"})
+	}
+	if len(m.GetAll(1)) != 0 || m.Len() != 2 || m.FirstKey() != 2 {
+		t.Errorf("Got %v expected %v", m.Len(), 2)
+	}
+
+	m.Clear()
+	if !m.Empty() || m.FirstKey() != nil || m.LastKey() != nil {
+		t.Errorf("Got %v expected %v", m, "empty")
+	}
+}
+
+func TestOrderMapSub(t *testing.T) {
+	m := newTestOrderMap()
+	for n := 1; n <= 5; n++ {
+		m.Put(n, string(rune('a'+n)))
+	}
+	m.Put(3, "z")
+
+	// [fromKey, toKey)
+	sub := m.Sub(2, 4)
+	if actualValue, expectedValue := sub.Keys(), []interface{}{2, 3}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if sub.Len() != 3 {
+		t.Errorf("Got %v expected %v", sub.Len(), 3)
+	}
+	// < toKey
+	if actualValue, expectedValue := m.Head(3).Keys(), []interface{}{1, 2}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	// >= fromKey
+	if actualValue, expectedValue := m.Tail(3).Keys(), []interface{}{3, 4, 5}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue := m.Sub(4, 2); !actualValue.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, "empty")
+	}
+
+	// the submaps are copies
+	sub.Put(2, "y")
+	sub.Remove(3)
+	if actualValue, expectedValue := m.GetAll(2), []interface{}{"c"}; !reflect.DeepEqual(actualValue, expectedValue) || !m.Contains(3) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+func TestOrderMapIterator(t *testing.T) {
+	m := newTestOrderMap()
+	for _, n := range []int{2, 3, 1} {
+		m.Put(n, string(rune('a'+n)))
+	}
+	m.Put(2, "x")
+
+	it := m.Iterator()
+	var keys []interface{}
+	for it.Next() {
+		keys = append(keys, it.Key())
+		if it.Key() == 2 && !reflect.DeepEqual(it.Value(), []interface{}{"c", "x"}) {
+			t.Errorf("Got %v expected %v", it.Value(), []interface{}{"c", "x"})
+		}
+	}
+	if expectedValue := []interface{}{1, 2, 3}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+	it.Reset()
+	if !it.Next() || it.Key() != 1 {
+		t.Errorf("Got %v expected %v", it.Key(), 1)
+	}
+
+	keys = nil
+	for key := range m.Backward() {
+		keys = append(keys, key)
+	}
+	if expectedValue := []interface{}{3, 2, 1}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(m.Values()), []interface{}{"b", "c", "x", "d"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// the keys already in the Keys given to NewOrderMap are in the map, without elements
+func TestNewOrderMapSeededKeys(t *testing.T) {
+	keys := NewKeys(container.IntCompareFunctionASC, reflect.TypeOf(0))
+	keys.Add(2)
+	keys.Add(1)
+	m := NewOrderMap(keys, reflect.TypeOf(""))
+
+	if actualValue, expectedValue := m.Keys(), []interface{}{1, 2}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !m.Contains(1, 2) || m.Len() != 0 || len(m.GetAll(1)) != 0 {
+		t.Errorf("Got %v expected %v", m, "keys without elements")
+	}
+	m.Put(1, "a")
+	if actualValue, expectedValue := m.GetAll(1), []interface{}{"a"}; !reflect.DeepEqual(actualValue, expectedValue) || m.Len() != 1 {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// writes to the map after Snapshot are not seen by the snapshot, and the other way round
+func TestOrderMapSnapshot(t *testing.T) {
+	m := newTestOrderMap()
+	for n := 1; n <= 100; n++ {
+		m.Put(n, "a")
+	}
+	m.Put(1, "b")
+
+	snapshot := m.Snapshot()
+	m.Put(1, "c")
+	m.Put(101, "a")
+	m.Remove(50)
+	snapshot.Put(2, "x")
+
+	if actualValue, expectedValue := snapshot.GetAll(1), []interface{}{"a", "b"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.GetAll(1), []interface{}{"a", "b", "c"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.GetAll(2), []interface{}{"a"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if snapshot.Len() != 102 || m.Len() != 102 {
+		t.Errorf("Got %v expected %v", []int{snapshot.Len(), m.Len()}, []int{102, 102})
+	}
+	if snapshot.Contains(101) || !snapshot.Contains(50) || m.Contains(50) {
+		t.Errorf("Got %v expected %v", snapshot.Contains(50), true)
+	}
+
+	var keys []interface{}
+	for key := range snapshot.KeySeq() {
+		keys = append(keys, key)
+	}
+	if len(keys) != 100 || keys[0] != 1 || keys[49] != 50 || keys[99] != 100 {
+		t.Errorf("Got %v expected %v", keys, "1 to 100")
+	}
+	if actualValue := snapshot.LastKey(); actualValue != 100 {
+		t.Errorf("Got %v expected %v", actualValue, 100)
+	}
+}
+
+// the loop body may use the map: the keys it puts or removes are odd, the even ones are all seen once
+func TestOrderMapWriteDuringRange(t *testing.T) {
+	m := newTestOrderMap()
+	for n := 0; n < 200; n++ {
+		m.Put(n, "a")
+	}
+
+	random := rand.New(rand.NewSource(1))
+	var evens []interface{}
+	for key := range m.Backward() {
+		if key.(int)%2 == 0 {
+			evens = append(evens, key)
+		}
+		for i := 0; i < 3; i++ {
+			if odd := 2*random.Intn(100) + 1; random.Intn(2) == 0 {
+				m.Put(odd, "b")
+			} else {
+				m.Remove(odd)
+			}
+		}
+	}
+	if len(evens) != 100 || evens[0] != 198 || evens[99] != 0 {
+		t.Errorf("Got %v expected %v", evens, "198, 196, ..., 0")
+	}
+	for i := 1; i < len(evens); i++ {
+		if evens[i-1].(int) <= evens[i].(int) {
+			t.Errorf("Got %v after %v", evens[i], evens[i-1])
+		}
+	}
+}
+
+func BenchmarkOrderMap(b *testing.B) {
+	m := newTestOrderMap()
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			m.Put(n, "a")
+		}
+		for n := 0; n < 1000; n++ {
+			m.Remove(n)
+		}
+	}
+}
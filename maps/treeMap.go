@@ -0,0 +1,213 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// TreeMap是基于红黑树的有序map, 每个键只对应一个元素值,
+// Get/Put/Remove/Contains 均为 O(log n)
+type TreeMap struct {
+	tree     *trees.RBTree
+	keyType  reflect.Type
+	elemType reflect.Type
+	lock     *sync.Mutex
+}
+
+var _ MapInterface = &TreeMap{}
+
+func NewTreeMap(compareFunc container.CompareFunction, keyType, elemType reflect.Type) *TreeMap {
+	return &TreeMap{
+		tree:     trees.NewRBTree(compareFunc),
+		keyType:  keyType,
+		elemType: elemType,
+		lock:     &sync.Mutex{},
+	}
+}
+
+func (m *TreeMap) isAcceptableKey(key interface{}) bool {
+	return key != nil && reflect.TypeOf(key) == m.keyType
+}
+
+func (m *TreeMap) isAcceptableElem(elem interface{}) bool {
+	return elem != nil && reflect.TypeOf(elem) == m.elemType
+}
+
+func (m *TreeMap) Get(key interface{}) interface{} {
+	if !m.isAcceptableKey(key) {
+		return nil
+	}
+
+	m.lock.Lock()
+	e, _ := m.tree.Get(key)
+	m.lock.Unlock()
+	return e
+}
+
+func (m *TreeMap) Put(key interface{}, elem interface{}) (interface{}, bool) {
+	if !m.isAcceptableKey(key) || !m.isAcceptableElem(elem) {
+		return nil, false
+	}
+
+	m.lock.Lock()
+	oldElem, _ := m.tree.Get(key)
+	m.tree.Put(key, elem)
+	m.lock.Unlock()
+
+	return oldElem, true
+}
+
+func (m *TreeMap) Remove(key interface{}) interface{} {
+	if !m.isAcceptableKey(key) {
+		return nil
+	}
+
+	m.lock.Lock()
+	oldElem, ok := m.tree.Get(key)
+	if ok {
+		m.tree.Remove(key)
+	}
+	m.lock.Unlock()
+
+	return oldElem
+}
+
+func (m *TreeMap) Clear() {
+	m.lock.Lock()
+	m.tree.Clear()
+	m.lock.Unlock()
+}
+
+func (m *TreeMap) Len() int {
+	m.lock.Lock()
+	length := m.tree.Len()
+	m.lock.Unlock()
+	return length
+}
+
+func (m *TreeMap) Empty() bool {
+	return m.Len() == 0
+}
+
+// whether all the keys are in the map, O(log n) for each key
+func (m *TreeMap) Contains(keys ...interface{}) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, key := range keys {
+		if !m.isAcceptableKey(key) {
+			return false
+		}
+		if _, ok := m.tree.Get(key); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// keys in order
+func (m *TreeMap) Keys() []interface{} {
+	m.lock.Lock()
+	keys := m.tree.Keys()
+	m.lock.Unlock()
+	return keys
+}
+
+// elements in the order of their keys
+func (m *TreeMap) Elements() []interface{} {
+	m.lock.Lock()
+	elems := m.tree.Elements()
+	m.lock.Unlock()
+	return elems
+}
+
+func (m *TreeMap) ToMap() map[interface{}]interface{} {
+	m.lock.Lock()
+	replica := make(map[interface{}]interface{}, m.tree.Len())
+	for k, v := range m.tree.All() {
+		replica[k] = v
+	}
+	m.lock.Unlock()
+
+	return replica
+}
+
+func (m *TreeMap) KeyType() reflect.Type {
+	return m.keyType
+}
+
+func (m *TreeMap) ElemType() reflect.Type {
+	return m.elemType
+}
+
+// Returns a stateful iterator over the map in key order, positioned before the first key.
+func (m *TreeMap) Iterator() container.Iterator {
+	return m.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the map in reverse key order.
+func (m *TreeMap) ReverseIterator() container.ReverseIterator {
+	return container.SyncReverseIterator(m.tree.ReverseIterator(), m.lock)
+}
+
+// Returns a range-over-func sequence of (key, element) pairs in key order.
+// The loop body may put and remove keys, the walk goes on with the key after the current one.
+func (m *TreeMap) All() iter.Seq2[interface{}, interface{}] {
+	return container.Seq2(m.Iterator)
+}
+
+// Returns a range-over-func sequence of (key, element) pairs in reverse key order.
+func (m *TreeMap) Backward() iter.Seq2[interface{}, interface{}] {
+	return container.BackwardSeq2(m.ReverseIterator)
+}
+
+// Returns a range-over-func sequence of the keys in order.
+func (m *TreeMap) KeySeq() iter.Seq[interface{}] {
+	return container.KeySeq(m.Iterator)
+}
+
+// Returns a range-over-func sequence of the elements in the order of their keys.
+func (m *TreeMap) Values() iter.Seq[interface{}] {
+	return container.ValueSeq(m.Iterator)
+}
+
+func (m *TreeMap) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("TreeMap<")
+	buf.WriteString(m.KeyType().Kind().String())
+	buf.WriteString(",")
+	buf.WriteString(m.ElemType().Kind().String())
+	buf.WriteString(">{")
+	first := true
+	for key, elem := range m.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", elem))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
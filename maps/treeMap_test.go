@@ -0,0 +1,160 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package maps
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	m := NewTreeMap(container.IntCompareFunctionASC, reflect.TypeOf(0), reflect.TypeOf(""))
+
+	for _, key := range []int{5, 3, 4, 1, 2} {
+		if _, ok := m.Put(key, string(rune('a'+key))); !ok {
+			t.Errorf("Got %v expected %v", ok, true)
+		}
+	}
+	if oldElem, ok := m.Put(1, "x"); oldElem != "b" || !ok {
+		t.Errorf("Got %v expected %v", oldElem, "b")
+	}
+	if _, ok := m.Put("6", "g"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := m.Put(6, 6); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if m.KeyType() != reflect.TypeOf(0) || m.ElemType() != reflect.TypeOf("") {
+		t.Errorf("Got %v expected %v", m.KeyType(), reflect.TypeOf(0))
+	}
+
+	if actualValue := m.Get(1); actualValue != "x" {
+		t.Errorf("Got %v expected %v", actualValue, "x")
+	}
+	if m.Get(6) != nil || m.Get("1") != nil {
+		t.Errorf("Got %v expected %v", m.Get("1"), nil)
+	}
+	if m.Len() != 5 || !m.Contains(1, 5) || m.Contains(6) || m.Contains("1") {
+		t.Errorf("Got %v expected %v", m.Len(), 5)
+	}
+
+	if actualValue, expectedValue := m.Keys(), []interface{}{1, 2, 3, 4, 5}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.Elements(), []interface{}{"x", "c", "d", "e", "f"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.ToMap(), map[interface{}]interface{}{1: "x", 2: "c", 3: "d", 4: "e", 5: "f"}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := m.String(), "TreeMap<int,string>{1:x 2:c 3:d 4:e 5:f}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if oldElem := m.Remove(3); oldElem != "d" || m.Remove(3) != nil || m.Remove("4") != nil {
+		t.Errorf("Got %v expected %v", oldElem, "d")
+	}
+	if m.Len() != 4 || m.Contains(3) {
+		t.Errorf("Got %v expected %v", m.Len(), 4)
+	}
+
+	m.Clear()
+	if !m.Empty() || len(m.Keys()) != 0 {
+		t.Errorf("Got %v expected %v", m, "empty")
+	}
+}
+
+func TestTreeMapIterator(t *testing.T) {
+	m := NewTreeMap(container.IntCompareFunctionASC, reflect.TypeOf(0), reflect.TypeOf(0))
+	for _, key := range []int{3, 1, 2} {
+		m.Put(key, key*10)
+	}
+
+	it := m.Iterator()
+	var keys []interface{}
+	for it.Next() {
+		if it.Value() != it.Key().(int)*10 {
+			t.Errorf("Got %v expected %v", it.Value(), it.Key().(int)*10)
+		}
+		keys = append(keys, it.Key())
+	}
+	if expectedValue := []interface{}{1, 2, 3}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+
+	rit := m.ReverseIterator()
+	rit.End()
+	keys = nil
+	for rit.Prev() {
+		keys = append(keys, rit.Key())
+	}
+	if expectedValue := []interface{}{3, 2, 1}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+
+	keys = nil
+	for key := range m.Backward() {
+		keys = append(keys, key)
+	}
+	if expectedValue := []interface{}{3, 2, 1}; !reflect.DeepEqual(keys, expectedValue) {
+		t.Errorf("Got %v expected %v", keys, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(m.KeySeq()), []interface{}{1, 2, 3}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := slices.Collect(m.Values()), []interface{}{10, 20, 30}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+}
+
+// the loop body may use the map: the keys it puts or removes are odd, the even ones are all seen once
+func TestTreeMapWriteDuringRange(t *testing.T) {
+	m := NewTreeMap(container.IntCompareFunctionASC, reflect.TypeOf(0), reflect.TypeOf(0))
+	for n := 0; n < 200; n++ {
+		m.Put(n, n)
+	}
+
+	random := rand.New(rand.NewSource(1))
+	var evens []interface{}
+	for key := range m.KeySeq() {
+		if key.(int)%2 == 0 {
+			evens = append(evens, key)
+		}
+		for i := 0; i < 3; i++ {
+			if odd := 2*random.Intn(100) + 1; random.Intn(2) == 0 {
+				m.Put(odd, odd)
+			} else {
+				m.Remove(odd)
+			}
+		}
+	}
+	if len(evens) != 100 || evens[0] != 0 || evens[99] != 198 || !slices.IsSortedFunc(evens, func(a, b interface{}) int { return a.(int) - b.(int) }) {
+		t.Errorf("Got %v expected %v", evens, "0, 2, ..., 198")
+	}
+}
+
+func BenchmarkTreeMap(b *testing.B) {
+	m := NewTreeMap(container.IntCompareFunctionASC, reflect.TypeOf(0), reflect.TypeOf(0))
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			m.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			m.Remove(n)
+		}
+	}
+}
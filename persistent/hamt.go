@@ -0,0 +1,226 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package persistent provides immutable containers: every update returns a new version
+// and leaves the old one as it was, the two sharing most of their structure.
+// Since no version ever changes, they can be shared between goroutines without locks.
+package persistent
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"reflect"
+)
+
+const (
+	_BITS  = 5
+	_WIDTH = 1 << _BITS
+	_MASK  = _WIDTH - 1
+)
+
+type hamtEntry struct {
+	hash uint64
+	key  interface{}
+	elem interface{}
+}
+
+// a slot holds either a child node or the entries of one hash, several of them only
+// when the whole hashes collide
+type hamtSlot struct {
+	node    *hamtNode
+	entries []hamtEntry
+}
+
+// hamtNode is a node of a hash array mapped trie: each level takes the next _BITS bits
+// of the hash, and the bitmap tells which of the _WIDTH possible slots are present,
+// so that only those are allocated. Nodes are never modified once built.
+type hamtNode struct {
+	bitmap uint32
+	slots  []hamtSlot
+}
+
+var emptyHamtNode = &hamtNode{}
+
+// position of the slot of hash at shift, found tells if it is present
+func (node *hamtNode) slot(hash uint64, shift uint) (position int, found bool) {
+	bit := uint32(1) << ((hash >> shift) & _MASK)
+	return bits.OnesCount32(node.bitmap & (bit - 1)), node.bitmap&bit != 0
+}
+
+func (node *hamtNode) get(hash uint64, key interface{}) (interface{}, bool) {
+	for shift := uint(0); ; shift += _BITS {
+		position, found := node.slot(hash, shift)
+		if !found {
+			return nil, false
+		}
+		slot := node.slots[position]
+		if slot.node == nil {
+			for _, entry := range slot.entries {
+				if entry.hash == hash && entry.key == key {
+					return entry.elem, true
+				}
+			}
+			return nil, false
+		}
+		node = slot.node
+	}
+}
+
+// returns a copy of the node with the slot at position replaced
+func (node *hamtNode) withSlot(position int, slot hamtSlot) *hamtNode {
+	slots := append([]hamtSlot{}, node.slots...)
+	slots[position] = slot
+	return &hamtNode{bitmap: node.bitmap, slots: slots}
+}
+
+// returns the node with entry put in, added tells if its key was not there
+func (node *hamtNode) put(entry hamtEntry, shift uint) (*hamtNode, bool) {
+	position, found := node.slot(entry.hash, shift)
+	if !found {
+		slots := make([]hamtSlot, 0, len(node.slots)+1)
+		slots = append(append(append(slots, node.slots[:position]...), hamtSlot{entries: []hamtEntry{entry}}), node.slots[position:]...)
+		return &hamtNode{bitmap: node.bitmap | 1<<((entry.hash>>shift)&_MASK), slots: slots}, true
+	}
+
+	slot := node.slots[position]
+	if slot.node != nil {
+		child, added := slot.node.put(entry, shift+_BITS)
+		return node.withSlot(position, hamtSlot{node: child}), added
+	}
+	if slot.entries[0].hash != entry.hash {
+		child := mergeEntries(slot.entries, entry, shift+_BITS)
+		return node.withSlot(position, hamtSlot{node: child}), true
+	}
+	entries := append([]hamtEntry{}, slot.entries...)
+	for i := range entries {
+		if entries[i].key == entry.key {
+			entries[i] = entry
+			return node.withSlot(position, hamtSlot{entries: entries}), false
+		}
+	}
+	return node.withSlot(position, hamtSlot{entries: append(entries, entry)}), true
+}
+
+// builds the nodes separating entries from entry, whose hashes differ
+func mergeEntries(entries []hamtEntry, entry hamtEntry, shift uint) *hamtNode {
+	index1, index2 := (entries[0].hash>>shift)&_MASK, (entry.hash>>shift)&_MASK
+	if index1 == index2 {
+		return &hamtNode{
+			bitmap: 1 << index1,
+			slots:  []hamtSlot{{node: mergeEntries(entries, entry, shift+_BITS)}},
+		}
+	}
+	slots := []hamtSlot{{entries: entries}, {entries: []hamtEntry{entry}}}
+	if index2 < index1 {
+		slots[0], slots[1] = slots[1], slots[0]
+	}
+	return &hamtNode{bitmap: 1<<index1 | 1<<index2, slots: slots}
+}
+
+// returns the node without key, removed tells if it was there
+func (node *hamtNode) remove(hash uint64, key interface{}, shift uint) (*hamtNode, bool) {
+	position, found := node.slot(hash, shift)
+	if !found {
+		return node, false
+	}
+
+	slot := node.slots[position]
+	if slot.node != nil {
+		child, removed := slot.node.remove(hash, key, shift+_BITS)
+		switch {
+		case !removed:
+			return node, false
+		case len(child.slots) == 0:
+			return node.withoutSlot(position, hash, shift), true
+		case len(child.slots) == 1 && child.slots[0].node == nil:
+			// a lone entry moves up, keeping the trie as shallow as the hashes allow
+			return node.withSlot(position, child.slots[0]), true
+		}
+		return node.withSlot(position, hamtSlot{node: child}), true
+	}
+
+	for i, entry := range slot.entries {
+		if entry.hash == hash && entry.key == key {
+			if len(slot.entries) == 1 {
+				return node.withoutSlot(position, hash, shift), true
+			}
+			entries := append(append([]hamtEntry{}, slot.entries[:i]...), slot.entries[i+1:]...)
+			return node.withSlot(position, hamtSlot{entries: entries}), true
+		}
+	}
+	return node, false
+}
+
+func (node *hamtNode) withoutSlot(position int, hash uint64, shift uint) *hamtNode {
+	slots := append(append([]hamtSlot{}, node.slots[:position]...), node.slots[position+1:]...)
+	return &hamtNode{bitmap: node.bitmap &^ (1 << ((hash >> shift) & _MASK)), slots: slots}
+}
+
+// calls fn on every entry until it returns false; returns false if it was stopped
+func (node *hamtNode) each(fn func(entry *hamtEntry) bool) bool {
+	for _, slot := range node.slots {
+		if slot.node != nil {
+			if !slot.node.each(fn) {
+				return false
+			}
+			continue
+		}
+		for i := range slot.entries {
+			if !fn(&slot.entries[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var hashSeed = maphash.MakeSeed()
+
+// hashes key so that keys equal with == have the same hash
+func hashOf(key interface{}) uint64 {
+	value := reflect.ValueOf(key)
+	switch value.Kind() {
+	case reflect.String:
+		return maphash.String(hashSeed, value.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return mix(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		if f := value.Float(); f != 0 {
+			return mix(math.Float64bits(f))
+		}
+		// +0 == -0
+		return mix(0)
+	case reflect.Bool:
+		if value.Bool() {
+			return mix(1)
+		}
+		return mix(0)
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return mix(uint64(value.Pointer()))
+	}
+	return maphash.String(hashSeed, fmt.Sprintf("%#v", key))
+}
+
+// the finalizer of splitmix64, which spreads the bits of consecutive integers
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
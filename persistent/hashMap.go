@@ -0,0 +1,183 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/maps"
+	"iter"
+	"reflect"
+)
+
+// HashMap is a persistent map on a hash array mapped trie. Put and Remove return a new
+// map which shares all the nodes but the O(log32 n) ones on the path of the key.
+// The keys are compared with ==, and iterate in the order of their hashes.
+type HashMap struct {
+	root     *hamtNode
+	size     int
+	keyType  reflect.Type
+	elemType reflect.Type
+}
+
+var _ maps.ReadOnlyMapInterface = &HashMap{}
+
+// Returns an empty map.
+func NewHashMap(keyType, elemType reflect.Type) *HashMap {
+	return &HashMap{
+		root:     emptyHamtNode,
+		keyType:  keyType,
+		elemType: elemType,
+	}
+}
+
+func (m *HashMap) isAcceptableKey(key interface{}) bool {
+	return key != nil && reflect.TypeOf(key) == m.keyType
+}
+
+func (m *HashMap) isAcceptableElem(elem interface{}) bool {
+	return elem != nil && reflect.TypeOf(elem) == m.elemType
+}
+
+func (m *HashMap) Get(key interface{}) interface{} {
+	elem, _ := m.Lookup(key)
+	return elem
+}
+
+// Returns the element of key, second return parameter is false if key is not in the map.
+func (m *HashMap) Lookup(key interface{}) (elem interface{}, found bool) {
+	if !m.isAcceptableKey(key) {
+		return nil, false
+	}
+	return m.root.get(hashOf(key), key)
+}
+
+// Returns a new map where key has elem, this map is left unchanged.
+// Second return parameter is false, and the map is this one, if key or elem is not of the map's types.
+func (m *HashMap) Put(key interface{}, elem interface{}) (*HashMap, bool) {
+	if !m.isAcceptableKey(key) || !m.isAcceptableElem(elem) {
+		return m, false
+	}
+
+	root, added := m.root.put(hamtEntry{hash: hashOf(key), key: key, elem: elem}, 0)
+	size := m.size
+	if added {
+		size++
+	}
+	return &HashMap{root: root, size: size, keyType: m.keyType, elemType: m.elemType}, true
+}
+
+// Returns a new map without key, or this map if key is not in it.
+func (m *HashMap) Remove(key interface{}) *HashMap {
+	if !m.isAcceptableKey(key) {
+		return m
+	}
+
+	root, removed := m.root.remove(hashOf(key), key, 0)
+	if !removed {
+		return m
+	}
+	return &HashMap{root: root, size: m.size - 1, keyType: m.keyType, elemType: m.elemType}
+}
+
+func (m *HashMap) Len() int {
+	return m.size
+}
+
+func (m *HashMap) Empty() bool {
+	return m.size == 0
+}
+
+// whether all the keys are in the map
+func (m *HashMap) Contains(keys ...interface{}) bool {
+	for _, key := range keys {
+		if _, found := m.Lookup(key); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *HashMap) Keys() []interface{} {
+	keys := make([]interface{}, 0, m.size)
+	for key := range m.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// elements in the order of their keys
+func (m *HashMap) Elements() []interface{} {
+	elems := make([]interface{}, 0, m.size)
+	for _, elem := range m.All() {
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+func (m *HashMap) ToMap() map[interface{}]interface{} {
+	replica := make(map[interface{}]interface{}, m.size)
+	for key, elem := range m.All() {
+		replica[key] = elem
+	}
+	return replica
+}
+
+func (m *HashMap) KeyType() reflect.Type {
+	return m.keyType
+}
+
+func (m *HashMap) ElemType() reflect.Type {
+	return m.elemType
+}
+
+// Returns a stateful iterator over the map, positioned before the first key.
+func (m *HashMap) Iterator() container.Iterator {
+	return &hamtIterator{root: m.root}
+}
+
+// Returns a range-over-func sequence of (key, element) pairs.
+// Since the map never changes, the loop body may do anything.
+func (m *HashMap) All() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		m.root.each(func(entry *hamtEntry) bool {
+			return yield(entry.key, entry.elem)
+		})
+	}
+}
+
+func (m *HashMap) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("HashMap<")
+	buf.WriteString(m.KeyType().Kind().String())
+	buf.WriteString(",")
+	buf.WriteString(m.ElemType().Kind().String())
+	buf.WriteString(">{")
+	first := true
+	for key, elem := range m.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", elem))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
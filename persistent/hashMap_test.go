@@ -0,0 +1,154 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestHashMap(t *testing.T) {
+
+	empty := NewHashMap(reflect.TypeOf(""), reflect.TypeOf(0))
+	m1, ok := empty.Put("a", 1)
+	if !ok {
+		t.Errorf("Got %v expected %v", ok, true)
+	}
+	m1, _ = m1.Put("b", 2)
+	m2, _ := m1.Put("a", 10)
+	m3 := m2.Remove("b")
+
+	if actualValue, expectedValue := fmt.Sprint(empty.Len(), m1.Len(), m2.Len(), m3.Len()), "0 2 2 1"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if elem := m1.Get("a"); elem != 1 {
+		t.Errorf("Got %v expected %v", elem, 1)
+	}
+	if elem := m2.Get("a"); elem != 10 {
+		t.Errorf("Got %v expected %v", elem, 10)
+	}
+	if !m2.Contains("a", "b") || m3.Contains("b") || m3.Contains(1) {
+		t.Errorf("Contains error, got %v", m3)
+	}
+	if actualValue, expectedValue := m3.String(), "HashMap<string,int>{a:10}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if m, ok := m1.Put("c", "3"); ok || m != m1 {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if m3.Remove("x") != m3 {
+		t.Errorf("Got %v expected %v", m3.Remove("x"), m3)
+	}
+
+}
+
+func TestHashMapRandom(t *testing.T) {
+
+	random := rand.New(rand.NewSource(1))
+	m := NewHashMap(reflect.TypeOf(0), reflect.TypeOf(0))
+	reference := map[interface{}]interface{}{}
+	versions, references := []*HashMap{}, []map[interface{}]interface{}{}
+	for i := 0; i < 20000; i++ {
+		if key := random.Intn(3000); random.Intn(3) == 0 {
+			m = m.Remove(key)
+			delete(reference, key)
+		} else {
+			m, _ = m.Put(key, i)
+			reference[key] = i
+		}
+		if i%2000 == 0 {
+			versions = append(versions, m)
+			references = append(references, maps.Clone(reference))
+		}
+	}
+
+	versions, references = append(versions, m), append(references, reference)
+	for i, version := range versions {
+		if !reflect.DeepEqual(version.ToMap(), references[i]) || version.Len() != len(references[i]) {
+			t.Fatalf("version %v: got %v keys expected %v", i, version.Len(), len(references[i]))
+		}
+		count := 0
+		for it := version.Iterator(); it.Next(); count++ {
+			if elem := references[i][it.Key()]; it.Value() != elem {
+				t.Fatalf("Got %v expected %v", it.Value(), elem)
+			}
+		}
+		if count != version.Len() {
+			t.Fatalf("Got %v expected %v", count, version.Len())
+		}
+	}
+
+	for key := range reference {
+		m = m.Remove(key)
+	}
+	if !m.Empty() || len(m.root.slots) != 0 {
+		t.Errorf("Got %v expected %v", m, "empty map")
+	}
+
+}
+
+// forges the hashes to go through full collisions and deep nodes
+func TestHamtCollisions(t *testing.T) {
+
+	hashes := []uint64{0, 0, 1 << 60, 1<<60 | 1, 1 << 60, 31}
+	root := emptyHamtNode
+	for i, hash := range hashes {
+		root, _ = root.put(hamtEntry{hash: hash, key: i, elem: i}, 0)
+	}
+	for i, hash := range hashes {
+		if elem, found := root.get(hash, i); elem != i || !found {
+			t.Errorf("Got %v expected %v", elem, i)
+		}
+	}
+	if _, found := root.get(0, 2); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+
+	keys := []int{}
+	root.each(func(entry *hamtEntry) bool {
+		keys = append(keys, entry.key.(int))
+		return true
+	})
+	slices.Sort(keys)
+	if actualValue, expectedValue := fmt.Sprint(keys), "[0 1 2 3 4 5]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for i, hash := range hashes {
+		var removed bool
+		if root, removed = root.remove(hash, i, 0); !removed {
+			t.Errorf("Got %v expected %v", removed, true)
+		}
+	}
+	if len(root.slots) != 0 || root.bitmap != 0 {
+		t.Errorf("Got %v slots expected %v", len(root.slots), 0)
+	}
+
+}
+
+func BenchmarkHashMap(b *testing.B) {
+	m := NewHashMap(reflect.TypeOf(0), reflect.TypeOf(0))
+	for i := 0; i < b.N; i++ {
+		for n := 0; n < 1000; n++ {
+			m, _ = m.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			m.Get(n)
+		}
+	}
+}
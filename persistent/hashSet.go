@@ -0,0 +1,141 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"reflect"
+)
+
+// HashSet is a persistent set on a hash array mapped trie, see HashMap.
+type HashSet struct {
+	root     *hamtNode
+	size     int
+	elemType reflect.Type
+}
+
+// Returns an empty set.
+func NewHashSet(elemType reflect.Type) *HashSet {
+	return &HashSet{root: emptyHamtNode, elemType: elemType}
+}
+
+func (set *HashSet) isAcceptableElem(elem interface{}) bool {
+	return elem != nil && reflect.TypeOf(elem) == set.elemType
+}
+
+// Returns a new set with the elements added, this set is left unchanged.
+// The elements not of the set's element type are ignored.
+func (set *HashSet) Add(elements ...interface{}) *HashSet {
+	root, size := set.root, set.size
+	for _, e := range elements {
+		if !set.isAcceptableElem(e) {
+			continue
+		}
+		var added bool
+		if root, added = root.put(hamtEntry{hash: hashOf(e), key: e}, 0); added {
+			size++
+		}
+	}
+	return &HashSet{root: root, size: size, elemType: set.elemType}
+}
+
+// Returns a new set without the elements, this set is left unchanged.
+func (set *HashSet) Remove(elements ...interface{}) *HashSet {
+	root, size := set.root, set.size
+	for _, e := range elements {
+		if !set.isAcceptableElem(e) {
+			continue
+		}
+		var removed bool
+		if root, removed = root.remove(hashOf(e), e, 0); removed {
+			size--
+		}
+	}
+	return &HashSet{root: root, size: size, elemType: set.elemType}
+}
+
+// whether all the elements are in the set
+func (set *HashSet) Contains(elements ...interface{}) bool {
+	for _, e := range elements {
+		if !set.isAcceptableElem(e) {
+			return false
+		}
+		if _, found := set.root.get(hashOf(e), e); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *HashSet) Len() int {
+	return set.size
+}
+
+func (set *HashSet) Empty() bool {
+	return set.size == 0
+}
+
+func (set *HashSet) Same(other *HashSet) bool {
+	if other == nil || set.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(set.Elements()...)
+}
+
+func (set *HashSet) Elements() []interface{} {
+	snapshot := make([]interface{}, 0, set.size)
+	for e := range set.All() {
+		snapshot = append(snapshot, e)
+	}
+	return snapshot
+}
+
+func (set *HashSet) ElemType() reflect.Type {
+	return set.elemType
+}
+
+// Returns a stateful iterator over the set, positioned before the first element.
+func (set *HashSet) Iterator() container.Iterator {
+	return &hashSetIterator{hamtIterator{root: set.root}}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (set *HashSet) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		set.root.each(func(entry *hamtEntry) bool {
+			return yield(entry.key)
+		})
+	}
+}
+
+func (set *HashSet) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("HashSet{ ")
+	first := true
+	for e := range set.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", e))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestHashSet(t *testing.T) {
+
+	empty := NewHashSet(reflect.TypeOf(0))
+	s1 := empty.Add(1, 2, 3, "4", 3)
+	s2 := s1.Remove(2, 5)
+	s3 := s2.Add(2)
+
+	if actualValue, expectedValue := fmt.Sprint(empty.Len(), s1.Len(), s2.Len(), s3.Len()), "0 3 2 3"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !s1.Contains(1, 2, 3) || s2.Contains(2) || s1.Contains("4") {
+		t.Errorf("Contains error, got %v", s2)
+	}
+	if !s1.Same(s3) || s1.Same(s2) {
+		t.Errorf("Got %v expected %v", s1.Same(s3), true)
+	}
+
+	elements := []int{}
+	for it := s3.Iterator(); it.Next(); {
+		elements = append(elements, it.Value().(int))
+	}
+	slices.Sort(elements)
+	if actualValue, expectedValue := fmt.Sprint(elements), "[1 2 3]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+}
+
+func BenchmarkHashSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		set := NewHashSet(reflect.TypeOf(0))
+		for n := 0; n < 1000; n++ {
+			set = set.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			set.Contains(n)
+		}
+	}
+}
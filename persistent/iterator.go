@@ -0,0 +1,107 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+type hamtFrame struct {
+	node *hamtNode
+	slot int // next slot to visit
+}
+
+// hamtIterator walks the trie depth first, in the order of the hashes.
+type hamtIterator struct {
+	root    *hamtNode
+	stack   []hamtFrame
+	entries []hamtEntry // of the current slot
+	entry   int
+	started bool
+}
+
+func (it *hamtIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		it.stack = append(it.stack, hamtFrame{node: it.root})
+	} else if it.entry+1 < len(it.entries) {
+		it.entry++
+		return true
+	}
+	for len(it.stack) > 0 {
+		frame := &it.stack[len(it.stack)-1]
+		if frame.slot == len(frame.node.slots) {
+			it.stack = it.stack[:len(it.stack)-1]
+			continue
+		}
+		slot := frame.node.slots[frame.slot]
+		frame.slot++
+		if slot.node != nil {
+			it.stack = append(it.stack, hamtFrame{node: slot.node})
+			continue
+		}
+		it.entries, it.entry = slot.entries, 0
+		return true
+	}
+	it.entries = nil
+	return false
+}
+
+func (it *hamtIterator) Value() interface{} {
+	return it.entries[it.entry].elem
+}
+
+func (it *hamtIterator) Key() interface{} {
+	return it.entries[it.entry].key
+}
+
+func (it *hamtIterator) Reset() {
+	it.stack, it.entries, it.entry, it.started = it.stack[:0], nil, 0, false
+}
+
+// the set keeps the elements as keys, the value of an element is the element itself
+type hashSetIterator struct {
+	hamtIterator
+}
+
+func (it *hashSetIterator) Value() interface{} {
+	return it.Key()
+}
+
+// vectorIterator keeps the leaf of the current index, so that most steps do not walk the trie.
+type vectorIterator struct {
+	vector *Vector
+	index  int
+	leaf   []interface{}
+}
+
+func (it *vectorIterator) Next() bool {
+	if it.index+1 >= it.vector.size {
+		it.index = it.vector.size
+		return false
+	}
+	it.index++
+	if it.index&_MASK == 0 || it.leaf == nil {
+		it.leaf = it.vector.leaf(it.index)
+	}
+	return true
+}
+
+func (it *vectorIterator) Value() interface{} {
+	return it.leaf[it.index&_MASK]
+}
+
+func (it *vectorIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *vectorIterator) Reset() {
+	it.index, it.leaf = -1, nil
+}
@@ -0,0 +1,269 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"strings"
+)
+
+// inner nodes have children, leaves have values; both hold at most _WIDTH of them
+type vectorNode struct {
+	children []*vectorNode
+	values   []interface{}
+}
+
+// Vector is a persistent list on a _WIDTH-way trie indexed by the bits of the index,
+// with the last values kept apart in a tail. Get and Set are O(log32 n), which is at
+// most 7 levels, and Add is O(1) amortized: only every _WIDTH-th one touches the trie.
+type Vector struct {
+	size  int
+	shift uint // of the root level
+	root  *vectorNode
+	tail  []interface{}
+}
+
+var emptyVector = &Vector{shift: _BITS, root: &vectorNode{}}
+
+// Returns an empty vector.
+func NewVector() *Vector {
+	return emptyVector
+}
+
+// Returns the element at index.
+// Second return parameter is true if index is within bounds of the vector, otherwise false.
+func (vector *Vector) Get(index int) (interface{}, bool) {
+	if index < 0 || index >= vector.size {
+		return nil, false
+	}
+	return vector.leaf(index)[index&_MASK], true
+}
+
+// Returns a new vector where the element at index is value, this vector is left unchanged.
+// Second return parameter is false, and the vector is this one, if index is out of bounds.
+func (vector *Vector) Set(index int, value interface{}) (*Vector, bool) {
+	if index < 0 || index >= vector.size {
+		return vector, false
+	}
+	if index >= vector.tailOffset() {
+		tail := append([]interface{}{}, vector.tail...)
+		tail[index&_MASK] = value
+		return &Vector{size: vector.size, shift: vector.shift, root: vector.root, tail: tail}, true
+	}
+	return &Vector{size: vector.size, shift: vector.shift, root: setValue(vector.root, vector.shift, index, value), tail: vector.tail}, true
+}
+
+// Returns a new vector with the values appended, this vector is left unchanged.
+func (vector *Vector) Add(values ...interface{}) *Vector {
+	for _, value := range values {
+		vector = vector.add(value)
+	}
+	return vector
+}
+
+// Returns a new vector without the last value, or this vector if it is empty.
+func (vector *Vector) RemoveLast() *Vector {
+	switch {
+	case vector.size == 0:
+		return vector
+	case vector.size == 1:
+		return emptyVector
+	case vector.size-vector.tailOffset() > 1:
+		// the tails are never written to, add copies them
+		return &Vector{size: vector.size - 1, shift: vector.shift, root: vector.root, tail: vector.tail[:len(vector.tail)-1]}
+	}
+
+	// the tail is emptied, the last leaf of the trie becomes the tail
+	tail := vector.leaf(vector.size - 2)
+	root, shift := vector.popLeaf(vector.root, vector.shift)
+	if root == nil {
+		root = &vectorNode{}
+	}
+	if shift > _BITS && len(root.children) == 1 {
+		root, shift = root.children[0], shift-_BITS
+	}
+	return &Vector{size: vector.size - 1, shift: shift, root: root, tail: tail}
+}
+
+func (vector *Vector) Len() int {
+	return vector.size
+}
+
+func (vector *Vector) Empty() bool {
+	return vector.size == 0
+}
+
+// Check if values (one or more) are present in the vector.
+func (vector *Vector) Contains(values ...interface{}) bool {
+	return contains(vector.Elements(), values)
+}
+
+func (vector *Vector) Elements() []interface{} {
+	values := make([]interface{}, 0, vector.size)
+	for _, value := range vector.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Returns a stateful iterator over the vector, the key being the index.
+func (vector *Vector) Iterator() container.Iterator {
+	return &vectorIterator{vector: vector, index: -1}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs.
+func (vector *Vector) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for base := 0; base < vector.size; base += _WIDTH {
+			for i, value := range vector.leaf(base) {
+				if !yield(base+i, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements.
+func (vector *Vector) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range vector.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (vector *Vector) String() string {
+	str := "Vector{ "
+	values := []string{}
+	for _, value := range vector.All() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+// index of the first value in the tail
+func (vector *Vector) tailOffset() int {
+	if vector.size < _WIDTH {
+		return 0
+	}
+	return (vector.size - 1) &^ _MASK
+}
+
+// the values of the leaf holding index
+func (vector *Vector) leaf(index int) []interface{} {
+	if index >= vector.tailOffset() {
+		return vector.tail
+	}
+	node := vector.root
+	for level := vector.shift; level > 0; level -= _BITS {
+		node = node.children[(index>>level)&_MASK]
+	}
+	return node.values
+}
+
+func (vector *Vector) add(value interface{}) *Vector {
+	if vector.size-vector.tailOffset() < _WIDTH {
+		tail := make([]interface{}, len(vector.tail), len(vector.tail)+1)
+		copy(tail, vector.tail)
+		return &Vector{size: vector.size + 1, shift: vector.shift, root: vector.root, tail: append(tail, value)}
+	}
+
+	// the tail is full, it goes into the trie as a leaf
+	leaf := &vectorNode{values: vector.tail}
+	root, shift := vector.root, vector.shift
+	if vector.size>>_BITS > 1<<shift {
+		// no room left under the root
+		root = &vectorNode{children: []*vectorNode{root, newPath(shift, leaf)}}
+		shift += _BITS
+	} else {
+		root = vector.pushLeaf(root, shift, leaf)
+	}
+	return &Vector{size: vector.size + 1, shift: shift, root: root, tail: []interface{}{value}}
+}
+
+// returns a copy of the node at level with leaf appended below it
+func (vector *Vector) pushLeaf(node *vectorNode, level uint, leaf *vectorNode) *vectorNode {
+	index := ((vector.size - 1) >> level) & _MASK
+	children := make([]*vectorNode, len(node.children), max(len(node.children), index+1))
+	copy(children, node.children)
+	switch {
+	case level == _BITS:
+		children = append(children, leaf)
+	case index < len(children):
+		children[index] = vector.pushLeaf(children[index], level-_BITS, leaf)
+	default:
+		children = append(children, newPath(level-_BITS, leaf))
+	}
+	return &vectorNode{children: children}
+}
+
+// returns a copy of the node at level without its last leaf, nil if nothing is left,
+// and the level of the returned node
+func (vector *Vector) popLeaf(node *vectorNode, level uint) (*vectorNode, uint) {
+	index := ((vector.size - 2) >> level) & _MASK
+	if level > _BITS {
+		child, _ := vector.popLeaf(node.children[index], level-_BITS)
+		if child == nil && index == 0 {
+			return nil, level
+		}
+		children := append([]*vectorNode{}, node.children[:index+1]...)
+		if child == nil {
+			children = children[:index]
+		} else {
+			children[index] = child
+		}
+		return &vectorNode{children: children}, level
+	}
+	if index == 0 {
+		return nil, level
+	}
+	return &vectorNode{children: append([]*vectorNode{}, node.children[:index]...)}, level
+}
+
+// the chain of nodes from level down to the leaf
+func newPath(level uint, leaf *vectorNode) *vectorNode {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode{children: []*vectorNode{newPath(level-_BITS, leaf)}}
+}
+
+// returns a copy of the path to index with value set
+func setValue(node *vectorNode, level uint, index int, value interface{}) *vectorNode {
+	if level == 0 {
+		values := append([]interface{}{}, node.values...)
+		values[index&_MASK] = value
+		return &vectorNode{values: values}
+	}
+	children := append([]*vectorNode{}, node.children...)
+	children[(index>>level)&_MASK] = setValue(children[(index>>level)&_MASK], level-_BITS, index, value)
+	return &vectorNode{children: children}
+}
+
+// check if every value is one of the elements
+func contains(elements []interface{}, values []interface{}) bool {
+	for _, value := range values {
+		if !container.Contains(value, elements) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package persistent
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestVector(t *testing.T) {
+
+	empty := NewVector()
+	v1 := empty.Add("a", "b", "c")
+	v2, ok := v1.Set(1, "B")
+	if !ok {
+		t.Errorf("Got %v expected %v", ok, true)
+	}
+	v3 := v2.RemoveLast()
+
+	if actualValue, expectedValue := fmt.Sprint(empty, v1, v2, v3), "Vector{  } Vector{ a, b, c } Vector{ a, B, c } Vector{ a, B }"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, ok := v1.Set(3, "d"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if value, ok := v1.Get(2); value != "c" || !ok {
+		t.Errorf("Got %v expected %v", value, "c")
+	}
+	if _, ok := v1.Get(-1); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if !v1.Contains("a", "c") || v1.Contains("B") {
+		t.Errorf("Contains error, got %v", v1)
+	}
+	if empty.RemoveLast() != empty || !empty.Empty() {
+		t.Errorf("Got %v expected %v", empty.RemoveLast(), empty)
+	}
+
+}
+
+func TestVectorRandom(t *testing.T) {
+
+	random := rand.New(rand.NewSource(1))
+	vector, reference := NewVector(), []interface{}{}
+	versions, references := []*Vector{}, [][]interface{}{}
+	for i := 0; i < 20000; i++ {
+		switch op := random.Intn(10); {
+		case op < 6 || vector.Len() == 0:
+			vector = vector.Add(i)
+			reference = append(reference[:len(reference):len(reference)], i)
+		case op < 8:
+			index := random.Intn(vector.Len())
+			vector, _ = vector.Set(index, -i)
+			reference = append([]interface{}{}, reference...)
+			reference[index] = -i
+		default:
+			vector = vector.RemoveLast()
+			last := len(reference) - 1
+			reference = reference[:last:last]
+		}
+		if i%1000 == 0 {
+			versions, references = append(versions, vector), append(references, reference)
+		}
+	}
+
+	// the old versions are untouched by the later updates
+	versions, references = append(versions, vector), append(references, reference)
+	for i, version := range versions {
+		if actualValue, expectedValue := fmt.Sprint(version.Elements()), fmt.Sprint(references[i]); actualValue != expectedValue {
+			t.Fatalf("version %v: got %v expected %v", i, actualValue, expectedValue)
+		}
+		it := version.Iterator()
+		for index := 0; it.Next(); index++ {
+			if value, _ := version.Get(index); it.Key() != index || it.Value() != value {
+				t.Fatalf("Got %v expected %v", it.Value(), value)
+			}
+		}
+	}
+
+	for vector.Len() > 0 {
+		vector = vector.RemoveLast()
+	}
+	if vector.shift != _BITS || len(vector.root.children) != 0 {
+		t.Errorf("Got shift %v expected %v", vector.shift, _BITS)
+	}
+
+}
+
+func BenchmarkVector(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		vector := NewVector()
+		for n := 0; n < 1000; n++ {
+			vector = vector.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			vector.Get(n)
+		}
+	}
+}
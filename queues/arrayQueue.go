@@ -0,0 +1,157 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package queues
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"strings"
+)
+
+const (
+	_MIN_CAPACITY  = 16
+	_SHRINK_FACTOR = 4
+)
+
+// ArrayQueue is a FIFO queue backed by a growable circular buffer
+type ArrayQueue struct {
+	elements []interface{}
+	head     int
+	size     int
+}
+
+var _ QueueInterface = &ArrayQueue{}
+
+func NewArrayQueue() *ArrayQueue {
+	return &ArrayQueue{}
+}
+
+// Adds a value at the end of the queue
+func (queue *ArrayQueue) Enqueue(value interface{}) {
+	if queue.size == len(queue.elements) {
+		queue.resize(2 * len(queue.elements))
+	}
+	queue.elements[queue.index(queue.size)] = value
+	queue.size++
+}
+
+// Removes the first element of the queue and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *ArrayQueue) Dequeue() (value interface{}, ok bool) {
+	if queue.size == 0 {
+		return nil, false
+	}
+	value = queue.elements[queue.head]
+	queue.elements[queue.head] = nil
+	queue.head = queue.index(1)
+	queue.size--
+
+	if len(queue.elements) > _MIN_CAPACITY && queue.size <= len(queue.elements)/_SHRINK_FACTOR {
+		queue.resize(len(queue.elements) / 2)
+	}
+	return value, true
+}
+
+// Returns the first element of the queue without removing it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *ArrayQueue) Peek() (value interface{}, ok bool) {
+	if queue.size == 0 {
+		return nil, false
+	}
+	return queue.elements[queue.head], true
+}
+
+// Returns true if queue does not contain any elements.
+func (queue *ArrayQueue) Empty() bool {
+	return queue.size == 0
+}
+
+// Returns number of elements within the queue.
+func (queue *ArrayQueue) Len() int {
+	return queue.size
+}
+
+// Removes all elements from the queue.
+func (queue *ArrayQueue) Clear() {
+	queue.elements = nil
+	queue.head = 0
+	queue.size = 0
+}
+
+// Returns all elements in the queue (FIFO order).
+func (queue *ArrayQueue) Elements() []interface{} {
+	elements := make([]interface{}, queue.size, queue.size)
+	for i := 0; i < queue.size; i++ {
+		elements[i] = queue.elements[queue.index(i)]
+	}
+	return elements
+}
+
+// check if the elements are in the queue
+func (queue *ArrayQueue) Contains(elements ...interface{}) bool {
+	for _, e := range elements {
+		if !queue.contain(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func (queue *ArrayQueue) String() string {
+	str := "ArrayQueue{ "
+	values := []string{}
+	for _, value := range queue.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+// Returns a stateful iterator over the queue (FIFO order), positioned before the first element.
+func (queue *ArrayQueue) Iterator() container.Iterator {
+	return queue.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the queue from the last element.
+func (queue *ArrayQueue) ReverseIterator() container.ReverseIterator {
+	return &arrayQueueIterator{queue: queue, index: -1}
+}
+
+func (queue *ArrayQueue) contain(e interface{}) bool {
+	for i := 0; i < queue.size; i++ {
+		if queue.elements[queue.index(i)] == e {
+			return true
+		}
+	}
+	return false
+}
+
+// position in the buffer of the i-th element from the head
+func (queue *ArrayQueue) index(i int) int {
+	return (queue.head + i) % len(queue.elements)
+}
+
+// moves the elements into a buffer of the given capacity, starting at its beginning
+func (queue *ArrayQueue) resize(capacity int) {
+	if capacity < _MIN_CAPACITY {
+		capacity = _MIN_CAPACITY
+	}
+	elements := make([]interface{}, capacity, capacity)
+	for i := 0; i < queue.size; i++ {
+		elements[i] = queue.elements[queue.index(i)]
+	}
+	queue.elements = elements
+	queue.head = 0
+}
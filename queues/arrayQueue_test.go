@@ -0,0 +1,148 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package queues
+
+import (
+	"testing"
+)
+
+func TestArrayQueue(t *testing.T) {
+
+	queue := NewArrayQueue()
+
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// insertions
+	queue.Enqueue(1)
+	queue.Enqueue(2)
+	queue.Enqueue(3)
+
+	if actualValue := queue.Elements(); actualValue[0].(int) != 1 || actualValue[1].(int) != 2 || actualValue[2].(int) != 3 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,2,3]")
+	}
+
+	if actualValue := queue.Empty(); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	if actualValue := queue.Len(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue := queue.Contains(1, 3); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue, ok := queue.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	queue.Dequeue()
+
+	if actualValue, ok := queue.Peek(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	if actualValue, ok := queue.Dequeue(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	if actualValue, ok := queue.Dequeue(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := queue.Dequeue(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	if actualValue := queue.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	if actualValue := queue.Elements(); len(actualValue) != 0 {
+		t.Errorf("Got %v expected %v", actualValue, "[]")
+	}
+
+	// wrap around and grow
+	for i := 0; i < 100; i++ {
+		queue.Enqueue(i)
+		if i%3 == 0 {
+			queue.Dequeue()
+		}
+	}
+	expected := 34
+	for value := range queue.Values() {
+		if value != expected {
+			t.Errorf("Got %v expected %v", value, expected)
+		}
+		expected++
+	}
+	it := queue.Iterator()
+	for expected = 34; it.Next(); expected++ {
+		if it.Value() != expected || it.Key() != expected-34 {
+			t.Errorf("Got %v expected %v", it.Value(), expected)
+		}
+	}
+	if expected != 100 {
+		t.Errorf("Got %v expected %v", expected, 100)
+	}
+
+}
+
+func BenchmarkArrayQueue(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		queue := NewArrayQueue()
+		for n := 0; n < 1000; n++ {
+			queue.Enqueue(i)
+		}
+		for !queue.Empty() {
+			queue.Dequeue()
+		}
+	}
+}
+
+func TestArrayQueueReverseIterator(t *testing.T) {
+
+	queue := NewArrayQueue()
+	for i := 0; i < 20; i++ {
+		queue.Enqueue(i)
+	}
+	for i := 0; i < 10; i++ {
+		queue.Dequeue()
+		queue.Enqueue(20 + i)
+	}
+
+	it := queue.ReverseIterator()
+	it.End()
+	expected := 29
+	for it.Prev() {
+		if it.Value() != expected {
+			t.Errorf("Got %v expected %v", it.Value(), expected)
+		}
+		expected--
+	}
+	if expected != 9 {
+		t.Errorf("Got %v expected %v", expected, 9)
+	}
+
+	for position, value := range queue.Backward() {
+		if position != 19 || value != 29 {
+			t.Errorf("Got %v expected %v", value, 29)
+		}
+		break
+	}
+
+}
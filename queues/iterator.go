@@ -0,0 +1,100 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package queues
+
+import (
+	"iter"
+)
+
+type arrayQueueIterator struct {
+	queue *ArrayQueue
+	index int
+}
+
+func (it *arrayQueueIterator) Next() bool {
+	if it.index < it.queue.size {
+		it.index++
+	}
+	return it.inRange()
+}
+
+func (it *arrayQueueIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.inRange()
+}
+
+func (it *arrayQueueIterator) Value() interface{} {
+	return it.queue.elements[it.queue.index(it.index)]
+}
+
+func (it *arrayQueueIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *arrayQueueIterator) Reset() {
+	it.index = -1
+}
+
+func (it *arrayQueueIterator) End() {
+	it.index = it.queue.size
+}
+
+func (it *arrayQueueIterator) inRange() bool {
+	return it.index >= 0 && it.index < it.queue.size
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in FIFO order.
+func (queue *ArrayQueue) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i := 0; i < queue.size; i++ {
+			if !yield(i, queue.elements[queue.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (position, element) pairs from the last element to the first.
+func (queue *ArrayQueue) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i := queue.size - 1; i >= 0; i-- {
+			if !yield(i, queue.elements[queue.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements in FIFO order.
+func (queue *ArrayQueue) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for i := 0; i < queue.size; i++ {
+			if !yield(queue.elements[queue.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in FIFO order.
+func (queue *LinkedListQueue) All() iter.Seq2[int, interface{}] {
+	return queue.list.All()
+}
+
+// Returns a range-over-func sequence of the elements in FIFO order.
+func (queue *LinkedListQueue) Values() iter.Seq[interface{}] {
+	return queue.list.Values()
+}
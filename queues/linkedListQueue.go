@@ -0,0 +1,96 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package queues
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/lists"
+	"strings"
+)
+
+// LinkedListQueue is a FIFO queue backed by a singly linked list,
+// elements are appended at the end and removed from the front, both in O(1).
+type LinkedListQueue struct {
+	list *lists.SinglyLinkedList
+}
+
+var _ QueueInterface = &LinkedListQueue{}
+
+// Instantiates a new empty queue
+func NewLinkedListQueue() *LinkedListQueue {
+	return &LinkedListQueue{
+		list: lists.NewSinglyLinkedList(),
+	}
+}
+
+// Adds a value at the end of the queue
+func (queue *LinkedListQueue) Enqueue(value interface{}) {
+	queue.list.Add(value)
+}
+
+// Removes the first element of the queue and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to dequeue.
+func (queue *LinkedListQueue) Dequeue() (value interface{}, ok bool) {
+	value, ok = queue.list.Get(0)
+	queue.list.Remove(0)
+	return
+}
+
+// Returns the first element of the queue without removing it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *LinkedListQueue) Peek() (value interface{}, ok bool) {
+	return queue.list.Get(0)
+}
+
+// Returns true if queue does not contain any elements.
+func (queue *LinkedListQueue) Empty() bool {
+	return queue.list.Empty()
+}
+
+// Returns number of elements within the queue.
+func (queue *LinkedListQueue) Len() int {
+	return queue.list.Len()
+}
+
+// Removes all elements from the queue.
+func (queue *LinkedListQueue) Clear() {
+	queue.list.Clear()
+}
+
+// Returns all elements in the queue (FIFO order).
+func (queue *LinkedListQueue) Elements() []interface{} {
+	return queue.list.Elements()
+}
+
+func (queue *LinkedListQueue) String() string {
+	str := "LinkedListQueue{ "
+	values := []string{}
+	for _, value := range queue.list.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+// check if the elements are in the queue
+func (queue *LinkedListQueue) Contains(elements ...interface{}) bool {
+	return queue.list.Contains(elements...)
+}
+
+// Returns a stateful iterator over the queue (FIFO order), positioned before the first element.
+func (queue *LinkedListQueue) Iterator() container.Iterator {
+	return queue.list.Iterator()
+}
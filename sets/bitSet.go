@@ -0,0 +1,309 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"math/bits"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// BitSet是非负int的集合, 每个元素占一位, 适合取值稠密的整数ID.
+// 内存与最大元素成正比, 稀疏的大整数请使用RoaringBitmap
+type BitSet struct {
+	words []uint64 // 不保留末尾的0字
+	lock  *sync.Mutex
+}
+
+var _ Set = &BitSet{}
+
+// capacity只是预分配的位数, 集合会按需增长
+func NewBitSet(capacity int) *BitSet {
+	return &BitSet{
+		words: make([]uint64, 0, (max(capacity, 0)+63)/64),
+		lock:  &sync.Mutex{},
+	}
+}
+
+func newBitSetOf(words []uint64) *BitSet {
+	return &BitSet{words: trimWords(words), lock: &sync.Mutex{}}
+}
+
+// 去掉末尾的全零字
+func trimWords(words []uint64) []uint64 {
+	for len(words) > 0 && words[len(words)-1] == 0 {
+		words = words[:len(words)-1]
+	}
+	return words
+}
+
+func checkBitIndex(i int) {
+	if i < 0 {
+		panic(fmt.Sprintf("sets: negative bit index %d", i))
+	}
+}
+
+// the elements must be non-negative ints, others are ignored
+func (set *BitSet) Add(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if i, ok := e.(int); ok && i >= 0 {
+			set.set(i)
+		}
+	}
+	set.lock.Unlock()
+}
+
+func (set *BitSet) Remove(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if i, ok := e.(int); ok && i >= 0 {
+			set.unset(i)
+		}
+	}
+	set.lock.Unlock()
+}
+
+func (set *BitSet) Contains(elements ...interface{}) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	for _, e := range elements {
+		if i, ok := e.(int); !ok || !set.test(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// 置位i, i为负数时panic
+func (set *BitSet) Set(i int) {
+	checkBitIndex(i)
+	set.lock.Lock()
+	set.set(i)
+	set.lock.Unlock()
+}
+
+// 清除位i, 整个集合的清空使用Clear
+func (set *BitSet) Unset(i int) {
+	set.lock.Lock()
+	if i >= 0 {
+		set.unset(i)
+	}
+	set.lock.Unlock()
+}
+
+// 位i是否置位
+func (set *BitSet) Test(i int) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.test(i)
+}
+
+// 翻转位i, i为负数时panic
+func (set *BitSet) Flip(i int) {
+	checkBitIndex(i)
+	set.lock.Lock()
+	if set.test(i) {
+		set.unset(i)
+	} else {
+		set.set(i)
+	}
+	set.lock.Unlock()
+}
+
+func (set *BitSet) set(i int) {
+	if w := i / 64; w >= len(set.words) {
+		set.words = slices.Grow(set.words, w+1-len(set.words))[:w+1]
+	}
+	set.words[i/64] |= 1 << (i % 64)
+}
+
+func (set *BitSet) unset(i int) {
+	if w := i / 64; w < len(set.words) {
+		set.words[w] &^= 1 << (i % 64)
+		set.words = trimWords(set.words)
+	}
+}
+
+func (set *BitSet) test(i int) bool {
+	return i >= 0 && i/64 < len(set.words) && set.words[i/64]&(1<<(i%64)) != 0
+}
+
+// 置位的位数, 同Len
+func (set *BitSet) Count() int {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.count()
+}
+
+func (set *BitSet) count() int {
+	count := 0
+	for _, word := range set.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// 返回>= i的最小元素, 没有返回false
+func (set *BitSet) NextSet(i int) (int, bool) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.nextSet(i)
+}
+
+func (set *BitSet) nextSet(i int) (int, bool) {
+	i = max(i, 0)
+	w := i / 64
+	if w >= len(set.words) {
+		return 0, false
+	}
+	if word := set.words[w] >> (i % 64); word != 0 {
+		return i + bits.TrailingZeros64(word), true
+	}
+	for w++; w < len(set.words); w++ {
+		if set.words[w] != 0 {
+			return w*64 + bits.TrailingZeros64(set.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// 返回set与other的交集, 两者均不变
+func (set *BitSet) And(other *BitSet) *BitSet {
+	return set.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// 返回set与other的并集
+func (set *BitSet) Or(other *BitSet) *BitSet {
+	return set.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// 返回set与other的对称差集
+func (set *BitSet) Xor(other *BitSet) *BitSet {
+	return set.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// 返回set与other的差集
+func (set *BitSet) AndNot(other *BitSet) *BitSet {
+	return set.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// 逐字计算, other先在其锁内复制, 以免同时持有两把锁
+func (set *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+	otherWords := other.snapshot()
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	words := make([]uint64, max(len(set.words), len(otherWords)))
+	for i := range words {
+		var x, y uint64
+		if i < len(set.words) {
+			x = set.words[i]
+		}
+		if i < len(otherWords) {
+			y = otherWords[i]
+		}
+		words[i] = op(x, y)
+	}
+	return newBitSetOf(words)
+}
+
+func (set *BitSet) snapshot() []uint64 {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return slices.Clone(set.words)
+}
+
+func (set *BitSet) Clear() {
+	set.lock.Lock()
+	set.words = set.words[:0]
+	set.lock.Unlock()
+}
+
+func (set *BitSet) Len() int {
+	return set.Count()
+}
+
+func (set *BitSet) Empty() bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return len(set.words) == 0
+}
+
+// other也是BitSet时逐字比较
+func (set *BitSet) Same(other Set) bool {
+	if other, ok := other.(*BitSet); ok {
+		return slices.Equal(set.snapshot(), other.snapshot())
+	}
+	if other == nil || set.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(set.Elements()...)
+}
+
+// elements in ascending order
+func (set *BitSet) Elements() []interface{} {
+	set.lock.Lock()
+	snapshot := make([]interface{}, 0, set.count())
+	for i, ok := set.nextSet(0); ok; i, ok = set.nextSet(i + 1) {
+		snapshot = append(snapshot, i)
+	}
+	set.lock.Unlock()
+
+	return snapshot
+}
+
+func (set *BitSet) ElemType() reflect.Type {
+	return reflect.TypeOf(0)
+}
+
+// Returns a stateful iterator over the set in ascending order, positioned before the first element.
+func (set *BitSet) Iterator() container.Iterator {
+	return &bitSetIterator{nextSet: set.NextSet, element: -1}
+}
+
+// Returns a range-over-func sequence of the elements in ascending order.
+// Every step looks for the next set bit from the last element, so the loop body may change the set.
+func (set *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := set.NextSet(0); ok; i, ok = set.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func (set *BitSet) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("BitSet{ ")
+	first := true
+	for _, key := range set.Elements() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", key))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
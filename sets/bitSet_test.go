@@ -0,0 +1,136 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestBitSet(t *testing.T) {
+	set := NewBitSet(10)
+
+	set.Add(5, 3)
+	set.Add()
+	set.Add(2, 4, 1)
+	set.Add(5, 7, 200)
+	set.Add("9", -1, int64(9))
+
+	if set.Len() != 7 || set.Count() != 7 {
+		t.Errorf("Got %v expected %v", set.Len(), 7)
+	}
+	if !set.Contains(4, 200, 7) || !set.Test(200) {
+		t.Errorf("Contains error, expected true")
+	}
+	if set.Contains(9) || set.Contains("9") || set.Test(-1) || set.Test(1000) {
+		t.Errorf("Contains error, expected false")
+	}
+	if actualValue, expectedValue := set.Elements(), []interface{}{1, 2, 3, 4, 5, 7, 200}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := set.String(), "BitSet{ 1 2 3 4 5 7 200 }"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for _, test := range [][]int{{0, 1}, {6, 7}, {8, 200}, {201, -1}} {
+		next, ok := set.NextSet(test[0])
+		if !ok {
+			next = -1
+		}
+		if next != test[1] {
+			t.Errorf("Got %v expected %v", next, test[1])
+		}
+	}
+
+	set.Flip(200)
+	set.Flip(0)
+	set.Unset(7)
+	set.Set(6)
+	set.Remove(1)
+	if actualValue, expectedValue := slices.Collect(set.All()), []int{0, 2, 3, 4, 5, 6}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other := NewHashSet()
+	other.Add(set.Elements()...)
+	if !set.Same(other) || !other.Same(set) {
+		t.Errorf("Same error, expected true")
+	}
+	// trailing zero words do not matter
+	same := NewBitSet(1000)
+	same.Add(0, 2, 3, 4, 5, 6, 999)
+	same.Unset(999)
+	if !set.Same(same) {
+		t.Errorf("Same error, expected true")
+	}
+
+	it := set.Iterator()
+	var iterated []interface{}
+	for it.Next() {
+		iterated = append(iterated, it.Value())
+	}
+	it.Reset()
+	if !it.Next() || it.Value() != 0 || !reflect.DeepEqual(iterated, set.Elements()) {
+		t.Errorf("Got %v expected %v", iterated, set.Elements())
+	}
+
+	set.Clear()
+	if !set.Empty() || set.Len() != 0 {
+		t.Errorf("Empty error, expected %v", true)
+	}
+}
+
+func TestBitSetOperations(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		one, other := NewBitSet(0), NewBitSet(0)
+		hashOne, hashOther := NewHashSet(), NewHashSet()
+		for n := 0; n < 300; n++ {
+			i, j := random.Intn(1000), random.Intn(1000)
+			if round%2 == 0 {
+				j = random.Intn(100)
+			}
+			one.Add(i)
+			hashOne.Add(i)
+			other.Add(j)
+			hashOther.Add(j)
+		}
+
+		operations := []func(Set, Set) Set{Union, Intersect, Difference, SymmetricDifference}
+		for _, operation := range operations {
+			actualValue, expectedValue := operation(one, other), operation(hashOne, hashOther)
+			if _, ok := actualValue.(*BitSet); !ok || !actualValue.Same(expectedValue) {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+			// a bitmap with another set takes the general way
+			if actualValue := operation(one, hashOther); !actualValue.Same(expectedValue) {
+				t.Errorf("Got %v expected %v", actualValue, expectedValue)
+			}
+		}
+	}
+}
+
+func BenchmarkBitSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		set := NewBitSet(0)
+		for n := 0; n < 1000; n++ {
+			set.Set(n)
+		}
+		for n := 0; n < 1000; n++ {
+			set.Unset(n)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// HashSetOf is the type-parameterized version of HashSet
+type HashSetOf[T comparable] struct {
+	m    map[T]struct{}
+	lock *sync.Mutex
+}
+
+func NewHashSetOf[T comparable]() *HashSetOf[T] {
+	return &HashSetOf[T]{
+		m:    make(map[T]struct{}),
+		lock: &sync.Mutex{},
+	}
+}
+
+func (set *HashSetOf[T]) Add(elements ...T) {
+	set.lock.Lock()
+	for _, e := range elements {
+		set.m[e] = struct{}{}
+	}
+	set.lock.Unlock()
+}
+
+func (set *HashSetOf[T]) Remove(elements ...T) {
+	set.lock.Lock()
+	for _, e := range elements {
+		delete(set.m, e)
+	}
+	set.lock.Unlock()
+}
+
+// whether all the elements are in the set
+// return true if all in, or false
+func (set *HashSetOf[T]) Contains(elements ...T) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	for _, e := range elements {
+		if _, ok := set.m[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *HashSetOf[T]) Clear() {
+	set.lock.Lock()
+	set.m = make(map[T]struct{})
+	set.lock.Unlock()
+}
+
+func (set *HashSetOf[T]) Len() int {
+	set.lock.Lock()
+	len := len(set.m)
+	set.lock.Unlock()
+	return len
+}
+
+func (set *HashSetOf[T]) Empty() bool {
+	return set.Len() == 0
+}
+
+func (set *HashSetOf[T]) Same(other *HashSetOf[T]) bool {
+	if other == nil || set.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(set.Elements()...)
+}
+
+func (set *HashSetOf[T]) Elements() []T {
+	set.lock.Lock()
+	snapshot := make([]T, 0, len(set.m))
+	for key := range set.m {
+		snapshot = append(snapshot, key)
+	}
+	set.lock.Unlock()
+
+	return snapshot
+}
+
+func (set *HashSetOf[T]) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("HashSet{ ")
+	first := true
+	for _, key := range set.Elements() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", key))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
+
+// Returns a range-over-func sequence of the elements, the order is unspecified.
+// The lock is released while the loop body runs, see HashSet.All.
+func (set *HashSetOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		set.lock.Lock()
+		for e := range set.m {
+			set.lock.Unlock()
+			if !yield(e) {
+				return
+			}
+			set.lock.Lock()
+		}
+		set.lock.Unlock()
+	}
+}
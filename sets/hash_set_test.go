@@ -54,4 +54,59 @@ func BenchmarkHashSet(b *testing.B) {
 			set.Remove(n)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestHashSetIterator(t *testing.T) {
+	set := NewHashSet()
+	set.Add(1, 2, 3)
+
+	seen := NewHashSet()
+	it := set.Iterator()
+	for it.Next() {
+		if it.Key() != it.Value() {
+			t.Errorf("Iterator error, key %v value %v", it.Key(), it.Value())
+		}
+		seen.Add(it.Value())
+		// the lock must not be held between two steps
+		set.Contains(it.Value())
+	}
+
+	if !set.Same(seen) {
+		t.Errorf("Iterator error, got %v expected %v", seen, set)
+	}
+
+	it.Reset()
+	if !it.Next() {
+		t.Errorf("Iterator error, expected an element after Reset")
+	}
+}
+
+func TestHashSetSeq(t *testing.T) {
+	set := NewHashSet()
+	set.Add(1, 2, 3)
+
+	sum := 0
+	for e := range set.All() {
+		sum += e.(int)
+		// the loop body may use the set
+		set.Contains(e)
+	}
+	if sum != 6 {
+		t.Errorf("All error, got %v expected %v", sum, 6)
+	}
+	sum = 0
+	for e := range set.Values() {
+		sum += e.(int)
+	}
+	if sum != 6 {
+		t.Errorf("Values error, got %v expected %v", sum, 6)
+	}
+
+	// breaking out must not leave the set locked
+	for range set.All() {
+		break
+	}
+	set.Add(4)
+	if set.Len() != 4 {
+		t.Errorf("Len error, expected %v", 4)
+	}
+}
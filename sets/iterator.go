@@ -0,0 +1,117 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+	"math"
+	"reflect"
+)
+
+// hashSetIterator walks the underlying map in place through a reflect.MapIter,
+// so no snapshot of the elements is made. The set's lock is only held while
+// stepping, never between two calls.
+type hashSetIterator struct {
+	set     *HashSet
+	iter    *reflect.MapIter
+	element interface{}
+}
+
+// Returns a stateful iterator over the set, the order is unspecified.
+func (set *HashSet) Iterator() container.Iterator {
+	it := &hashSetIterator{set: set}
+	it.Reset()
+	return it
+}
+
+func (it *hashSetIterator) Next() bool {
+	it.set.lock.Lock()
+	defer it.set.lock.Unlock()
+	if !it.iter.Next() {
+		it.element = nil
+		return false
+	}
+	it.element = it.iter.Key().Interface()
+	return true
+}
+
+func (it *hashSetIterator) Value() interface{} {
+	return it.element
+}
+
+func (it *hashSetIterator) Key() interface{} {
+	return it.element
+}
+
+func (it *hashSetIterator) Reset() {
+	it.set.lock.Lock()
+	it.iter = reflect.ValueOf(it.set.m).MapRange()
+	it.set.lock.Unlock()
+	it.element = nil
+}
+
+// Returns a range-over-func sequence of the elements, the order is unspecified.
+// The lock is released while the loop body runs, so the body may use the set,
+// and breaking out of the loop leaves the set unlocked.
+func (set *HashSet) All() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		set.lock.Lock()
+		for e := range set.m {
+			set.lock.Unlock()
+			if !yield(e) {
+				return
+			}
+			set.lock.Lock()
+		}
+		set.lock.Unlock()
+	}
+}
+
+// Returns a range-over-func sequence of the elements, the same as All.
+// A set's elements are its keys as well, and it has no order, so there is no KeySeq nor Backward.
+func (set *HashSet) Values() iter.Seq[interface{}] {
+	return set.All()
+}
+
+// bitSetIterator walks a BitSet or a RoaringBitmap by looking for the next element
+// on every step, the set's lock is only held then.
+type bitSetIterator struct {
+	nextSet func(i int) (int, bool)
+	next    int // where to look for the next element
+	element int // -1 before the first element and after the last
+}
+
+func (it *bitSetIterator) Next() bool {
+	i, ok := it.nextSet(it.next)
+	if !ok {
+		it.element = -1
+		it.next = math.MaxInt
+		return false
+	}
+	it.element, it.next = i, i+1
+	return true
+}
+
+func (it *bitSetIterator) Value() interface{} {
+	return it.element
+}
+
+func (it *bitSetIterator) Key() interface{} {
+	return it.element
+}
+
+func (it *bitSetIterator) Reset() {
+	it.next, it.element = 0, -1
+}
@@ -0,0 +1,346 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// RoaringBitmap是[0, 2^32)内int的压缩集合: 元素按高16位分块, 稀疏的块存为有序数组,
+// 稠密的块存为位图, 每个元素至多占2字节, 稀疏的大整数也无需按最大元素分配内存
+type RoaringBitmap struct {
+	keys       []uint16 // 各块的高16位, 有序
+	containers []*roaringContainer
+	lock       *sync.Mutex
+}
+
+var _ Set = &RoaringBitmap{}
+
+func NewRoaringBitmap() *RoaringBitmap {
+	return &RoaringBitmap{lock: &sync.Mutex{}}
+}
+
+func roaringIndex(i int) (uint16, uint16, bool) {
+	if i < 0 || uint64(i) > math.MaxUint32 {
+		return 0, 0, false
+	}
+	return uint16(i >> 16), uint16(i), true
+}
+
+func checkRoaringIndex(i int) {
+	if i < 0 || uint64(i) > math.MaxUint32 {
+		panic(fmt.Sprintf("sets: roaring bitmap index %d out of [0, 2^32)", i))
+	}
+}
+
+// the elements must be ints in [0, 2^32), others are ignored
+func (set *RoaringBitmap) Add(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if i, ok := e.(int); ok {
+			set.set(i)
+		}
+	}
+	set.lock.Unlock()
+}
+
+func (set *RoaringBitmap) Remove(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if i, ok := e.(int); ok {
+			set.unset(i)
+		}
+	}
+	set.lock.Unlock()
+}
+
+func (set *RoaringBitmap) Contains(elements ...interface{}) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	for _, e := range elements {
+		if i, ok := e.(int); !ok || !set.test(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// 加入i, i不在[0, 2^32)内时panic
+func (set *RoaringBitmap) Set(i int) {
+	checkRoaringIndex(i)
+	set.lock.Lock()
+	set.set(i)
+	set.lock.Unlock()
+}
+
+// 删除i, 整个集合的清空使用Clear
+func (set *RoaringBitmap) Unset(i int) {
+	set.lock.Lock()
+	set.unset(i)
+	set.lock.Unlock()
+}
+
+// i是否在集合中
+func (set *RoaringBitmap) Test(i int) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.test(i)
+}
+
+// 翻转i, i不在[0, 2^32)内时panic
+func (set *RoaringBitmap) Flip(i int) {
+	checkRoaringIndex(i)
+	set.lock.Lock()
+	if set.test(i) {
+		set.unset(i)
+	} else {
+		set.set(i)
+	}
+	set.lock.Unlock()
+}
+
+// the position of the container of high, found tells if it is present
+func (set *RoaringBitmap) container(high uint16) (int, bool) {
+	return slices.BinarySearch(set.keys, high)
+}
+
+func (set *RoaringBitmap) set(i int) {
+	high, low, ok := roaringIndex(i)
+	if !ok {
+		return
+	}
+	position, found := set.container(high)
+	if !found {
+		set.keys = slices.Insert(set.keys, position, high)
+		set.containers = slices.Insert(set.containers, position, &roaringContainer{})
+	}
+	set.containers[position].add(low)
+}
+
+func (set *RoaringBitmap) unset(i int) {
+	high, low, ok := roaringIndex(i)
+	if !ok {
+		return
+	}
+	position, found := set.container(high)
+	if found && set.containers[position].remove(low) && set.containers[position].cardinality == 0 {
+		set.keys = slices.Delete(set.keys, position, position+1)
+		set.containers = slices.Delete(set.containers, position, position+1)
+	}
+}
+
+func (set *RoaringBitmap) test(i int) bool {
+	high, low, ok := roaringIndex(i)
+	if !ok {
+		return false
+	}
+	position, found := set.container(high)
+	return found && set.containers[position].contains(low)
+}
+
+// 元素个数, 同Len
+func (set *RoaringBitmap) Count() int {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.count()
+}
+
+func (set *RoaringBitmap) count() int {
+	count := 0
+	for _, c := range set.containers {
+		count += c.cardinality
+	}
+	return count
+}
+
+// 返回>= i的最小元素, 没有返回false
+func (set *RoaringBitmap) NextSet(i int) (int, bool) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	return set.nextSet(i)
+}
+
+func (set *RoaringBitmap) nextSet(i int) (int, bool) {
+	if i > 0 && uint64(i) > math.MaxUint32 {
+		return 0, false
+	}
+	high, low, _ := roaringIndex(max(i, 0))
+	position, found := set.container(high)
+	if found {
+		if next, ok := set.containers[position].next(low); ok {
+			return int(high)<<16 | int(next), true
+		}
+		position++
+	}
+	if position == len(set.containers) {
+		return 0, false
+	}
+	// a container is never empty
+	first, _ := set.containers[position].next(0)
+	return int(set.keys[position])<<16 | int(first), true
+}
+
+// 返回set与other的交集, 两者均不变
+func (set *RoaringBitmap) And(other *RoaringBitmap) *RoaringBitmap {
+	return set.combine(other, roaringAnd)
+}
+
+// 返回set与other的并集
+func (set *RoaringBitmap) Or(other *RoaringBitmap) *RoaringBitmap {
+	return set.combine(other, roaringOr)
+}
+
+// 返回set与other的对称差集
+func (set *RoaringBitmap) Xor(other *RoaringBitmap) *RoaringBitmap {
+	return set.combine(other, roaringXor)
+}
+
+// 返回set与other的差集
+func (set *RoaringBitmap) AndNot(other *RoaringBitmap) *RoaringBitmap {
+	return set.combine(other, roaringAndNot)
+}
+
+// 按块合并, other先在其锁内复制, 以免同时持有两把锁
+func (set *RoaringBitmap) combine(other *RoaringBitmap, op roaringOperation) *RoaringBitmap {
+	otherKeys, otherContainers := other.snapshot()
+	set.lock.Lock()
+	defer set.lock.Unlock()
+
+	result := NewRoaringBitmap()
+	keep := func(key uint16, c *roaringContainer) {
+		if c != nil {
+			result.keys = append(result.keys, key)
+			result.containers = append(result.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(set.keys) && j < len(otherKeys) {
+		switch {
+		case set.keys[i] < otherKeys[j]:
+			if op.onlyOne {
+				keep(set.keys[i], set.containers[i].clone())
+			}
+			i++
+		case set.keys[i] > otherKeys[j]:
+			if op.onlyOther {
+				keep(otherKeys[j], otherContainers[j])
+			}
+			j++
+		default:
+			keep(set.keys[i], set.containers[i].combine(otherContainers[j], op))
+			i++
+			j++
+		}
+	}
+	for ; op.onlyOne && i < len(set.keys); i++ {
+		keep(set.keys[i], set.containers[i].clone())
+	}
+	for ; op.onlyOther && j < len(otherKeys); j++ {
+		keep(otherKeys[j], otherContainers[j])
+	}
+	return result
+}
+
+func (set *RoaringBitmap) snapshot() ([]uint16, []*roaringContainer) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	containers := make([]*roaringContainer, len(set.containers))
+	for i, c := range set.containers {
+		containers[i] = c.clone()
+	}
+	return slices.Clone(set.keys), containers
+}
+
+func (set *RoaringBitmap) Clear() {
+	set.lock.Lock()
+	set.keys, set.containers = nil, nil
+	set.lock.Unlock()
+}
+
+func (set *RoaringBitmap) Len() int {
+	return set.Count()
+}
+
+func (set *RoaringBitmap) Empty() bool {
+	return set.Len() == 0
+}
+
+// other也是RoaringBitmap时逐块比较
+func (set *RoaringBitmap) Same(other Set) bool {
+	if other, ok := other.(*RoaringBitmap); ok {
+		return set.Xor(other).Empty()
+	}
+	if other == nil || set.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(set.Elements()...)
+}
+
+// elements in ascending order
+func (set *RoaringBitmap) Elements() []interface{} {
+	set.lock.Lock()
+	snapshot := make([]interface{}, 0, set.count())
+	for i, ok := set.nextSet(0); ok; i, ok = set.nextSet(i + 1) {
+		snapshot = append(snapshot, i)
+	}
+	set.lock.Unlock()
+
+	return snapshot
+}
+
+func (set *RoaringBitmap) ElemType() reflect.Type {
+	return reflect.TypeOf(0)
+}
+
+// Returns a stateful iterator over the set in ascending order, positioned before the first element.
+func (set *RoaringBitmap) Iterator() container.Iterator {
+	return &bitSetIterator{nextSet: set.NextSet, element: -1}
+}
+
+// Returns a range-over-func sequence of the elements in ascending order.
+// Like BitSet.All every step looks the next element up, so the loop body may change the set.
+func (set *RoaringBitmap) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := set.NextSet(0); ok; i, ok = set.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func (set *RoaringBitmap) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("RoaringBitmap{ ")
+	first := true
+	for _, key := range set.Elements() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", key))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
@@ -0,0 +1,136 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestRoaringBitmap(t *testing.T) {
+	set := NewRoaringBitmap()
+
+	set.Add(5, 3)
+	set.Add()
+	set.Add(1<<32-1, 1<<16, 70000)
+	set.Add("9", -1, 1<<32)
+
+	if set.Len() != 5 {
+		t.Errorf("Got %v expected %v", set.Len(), 5)
+	}
+	if !set.Contains(5, 1<<16, 1<<32-1) || set.Contains(4) || set.Contains(1<<32) {
+		t.Errorf("Contains error")
+	}
+	if actualValue, expectedValue := set.Elements(), []interface{}{3, 5, 1 << 16, 70000, 1<<32 - 1}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for _, test := range [][]int{{-5, 3}, {6, 1 << 16}, {70001, 1<<32 - 1}, {1 << 32, -1}} {
+		next, ok := set.NextSet(test[0])
+		if !ok {
+			next = -1
+		}
+		if next != test[1] {
+			t.Errorf("Got %v expected %v", next, test[1])
+		}
+	}
+
+	set.Flip(5)
+	set.Flip(6)
+	set.Unset(1 << 16)
+	set.Remove(70000)
+	if actualValue, expectedValue := slices.Collect(set.All()), []int{3, 6, 1<<32 - 1}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if len(set.keys) != 2 {
+		t.Errorf("Got %v expected %v", len(set.keys), 2)
+	}
+
+	set.Clear()
+	if !set.Empty() {
+		t.Errorf("Empty error, expected %v", true)
+	}
+}
+
+// a dense chunk turns into a bitmap, and back into an array once sparse again
+func TestRoaringBitmapContainers(t *testing.T) {
+	set := NewRoaringBitmap()
+	for n := 0; n < 2*_ARRAY_MAX_SIZE; n += 2 {
+		set.Set(n)
+	}
+	if set.containers[0].isBitmap() {
+		t.Errorf("Got %v expected %v", "bitmap", "array")
+	}
+	set.Set(1)
+	if !set.containers[0].isBitmap() || set.Len() != _ARRAY_MAX_SIZE+1 {
+		t.Errorf("Got %v expected %v", set.Len(), _ARRAY_MAX_SIZE+1)
+	}
+	if next, ok := set.NextSet(3); !ok || next != 4 {
+		t.Errorf("Got %v expected %v", next, 4)
+	}
+	if next, ok := set.NextSet(2*_ARRAY_MAX_SIZE - 1); ok {
+		t.Errorf("Got %v expected %v", next, "none")
+	}
+	set.Unset(0)
+	if set.containers[0].isBitmap() || !set.Contains(1, 2, 2*_ARRAY_MAX_SIZE-2) || set.Contains(0) {
+		t.Errorf("Got %v expected %v", "bitmap", "array")
+	}
+}
+
+func TestRoaringBitmapOperations(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		one, other := NewRoaringBitmap(), NewRoaringBitmap()
+		hashOne, hashOther := NewHashSet(), NewHashSet()
+		// dense chunks as bitmaps and sparse ones as arrays, on both sides
+		for n := 0; n < 20000; n++ {
+			i := random.Intn(3 << 16)
+			if i >= 2<<16 {
+				i = i<<8 | random.Intn(256)
+			}
+			if random.Intn(2) == 0 {
+				one.Add(i)
+				hashOne.Add(i)
+			} else if round%3 != 0 || i < 1<<16 {
+				other.Add(i)
+				hashOther.Add(i)
+			}
+		}
+
+		operations := []func(Set, Set) Set{Union, Intersect, Difference, SymmetricDifference}
+		for _, operation := range operations {
+			actualValue, expectedValue := operation(one, other), operation(hashOne, hashOther)
+			if _, ok := actualValue.(*RoaringBitmap); !ok || actualValue.Len() != expectedValue.Len() || !expectedValue.Contains(actualValue.Elements()...) {
+				t.Errorf("Got %v expected %v", actualValue.Len(), expectedValue.Len())
+			}
+		}
+		if !one.Same(one.Or(NewRoaringBitmap())) || one.Same(other) {
+			t.Errorf("Same error")
+		}
+	}
+}
+
+func BenchmarkRoaringBitmap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		set := NewRoaringBitmap()
+		for n := 0; n < 1000; n++ {
+			set.Set(n * 1000)
+		}
+		for n := 0; n < 1000; n++ {
+			set.Unset(n * 1000)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	// 元素数超过此值时数组容器转为位图容器, 两者此时都占8KB
+	_ARRAY_MAX_SIZE = 4096
+	_BITMAP_WORDS   = 1 << 16 / 64
+)
+
+// roaringContainer保存高16位相同的元素的低16位:
+// 元素不多于_ARRAY_MAX_SIZE时为有序数组, 否则为65536位的位图
+type roaringContainer struct {
+	array       []uint16
+	bitmap      []uint64 // nil when the container is an array
+	cardinality int
+}
+
+func (c *roaringContainer) isBitmap() bool {
+	return c.bitmap != nil
+}
+
+func (c *roaringContainer) contains(low uint16) bool {
+	if c.isBitmap() {
+		return c.bitmap[low/64]&(1<<(low%64)) != 0
+	}
+	_, found := slices.BinarySearch(c.array, low)
+	return found
+}
+
+// returns false if low was already present
+func (c *roaringContainer) add(low uint16) bool {
+	if c.isBitmap() {
+		if c.bitmap[low/64]&(1<<(low%64)) != 0 {
+			return false
+		}
+		c.bitmap[low/64] |= 1 << (low % 64)
+		c.cardinality++
+		return true
+	}
+	i, found := slices.BinarySearch(c.array, low)
+	if found {
+		return false
+	}
+	if len(c.array) == _ARRAY_MAX_SIZE {
+		c.toBitmap()
+		return c.add(low)
+	}
+	c.array = slices.Insert(c.array, i, low)
+	c.cardinality++
+	return true
+}
+
+// returns false if low was absent
+func (c *roaringContainer) remove(low uint16) bool {
+	if c.isBitmap() {
+		if c.bitmap[low/64]&(1<<(low%64)) == 0 {
+			return false
+		}
+		c.bitmap[low/64] &^= 1 << (low % 64)
+		c.cardinality--
+		if c.cardinality == _ARRAY_MAX_SIZE {
+			c.toArray()
+		}
+		return true
+	}
+	i, found := slices.BinarySearch(c.array, low)
+	if !found {
+		return false
+	}
+	c.array = slices.Delete(c.array, i, i+1)
+	c.cardinality--
+	return true
+}
+
+// the smallest element >= low
+func (c *roaringContainer) next(low uint16) (uint16, bool) {
+	if !c.isBitmap() {
+		i, _ := slices.BinarySearch(c.array, low)
+		if i == len(c.array) {
+			return 0, false
+		}
+		return c.array[i], true
+	}
+	w := int(low / 64)
+	if word := c.bitmap[w] >> (low % 64); word != 0 {
+		return low + uint16(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < _BITMAP_WORDS; w++ {
+		if c.bitmap[w] != 0 {
+			return uint16(w*64 + bits.TrailingZeros64(c.bitmap[w])), true
+		}
+	}
+	return 0, false
+}
+
+func (c *roaringContainer) toBitmap() {
+	c.bitmap = c.bitmapWords()
+	c.array = nil
+}
+
+func (c *roaringContainer) toArray() {
+	array := make([]uint16, 0, c.cardinality)
+	for w, word := range c.bitmap {
+		for ; word != 0; word &= word - 1 {
+			array = append(array, uint16(w*64+bits.TrailingZeros64(word)))
+		}
+	}
+	c.array, c.bitmap = array, nil
+}
+
+// the container as a bitmap, which is its own unless it is an array
+func (c *roaringContainer) bitmapWords() []uint64 {
+	if c.isBitmap() {
+		return c.bitmap
+	}
+	bitmap := make([]uint64, _BITMAP_WORDS)
+	for _, low := range c.array {
+		bitmap[low/64] |= 1 << (low % 64)
+	}
+	return bitmap
+}
+
+func (c *roaringContainer) clone() *roaringContainer {
+	return &roaringContainer{array: slices.Clone(c.array), bitmap: slices.Clone(c.bitmap), cardinality: c.cardinality}
+}
+
+// roaringOperation describes a set operation by which elements it keeps:
+// those only in the first container, only in the second, or in both
+type roaringOperation struct {
+	onlyOne, onlyOther, both bool
+	word                     func(x, y uint64) uint64
+}
+
+var (
+	roaringAnd    = roaringOperation{both: true, word: func(x, y uint64) uint64 { return x & y }}
+	roaringOr     = roaringOperation{onlyOne: true, onlyOther: true, both: true, word: func(x, y uint64) uint64 { return x | y }}
+	roaringXor    = roaringOperation{onlyOne: true, onlyOther: true, word: func(x, y uint64) uint64 { return x ^ y }}
+	roaringAndNot = roaringOperation{onlyOne: true, word: func(x, y uint64) uint64 { return x &^ y }}
+)
+
+// returns a new container, nil when it is empty
+func (c *roaringContainer) combine(other *roaringContainer, op roaringOperation) *roaringContainer {
+	var result *roaringContainer
+	switch {
+	case !c.isBitmap() && !other.isBitmap():
+		result = &roaringContainer{array: mergeArrays(c.array, other.array, op)}
+		result.cardinality = len(result.array)
+		if result.cardinality > _ARRAY_MAX_SIZE {
+			result.toBitmap()
+		}
+	case !op.onlyOther && !c.isBitmap():
+		// and, and not: filtering the array is cheaper than going through a bitmap
+		array := make([]uint16, 0, len(c.array))
+		for _, low := range c.array {
+			if other.contains(low) == op.both {
+				array = append(array, low)
+			}
+		}
+		result = &roaringContainer{array: array, cardinality: len(array)}
+	case !op.onlyOne && !op.onlyOther && !other.isBitmap():
+		return other.combine(c, op)
+	default:
+		one, another := c.bitmapWords(), other.bitmapWords()
+		result = &roaringContainer{bitmap: make([]uint64, _BITMAP_WORDS)}
+		for w := range result.bitmap {
+			result.bitmap[w] = op.word(one[w], another[w])
+			result.cardinality += bits.OnesCount64(result.bitmap[w])
+		}
+		if result.cardinality <= _ARRAY_MAX_SIZE {
+			result.toArray()
+		}
+	}
+	if result.cardinality == 0 {
+		return nil
+	}
+	return result
+}
+
+// merges two sorted arrays keeping the elements op keeps
+func mergeArrays(one, other []uint16, op roaringOperation) []uint16 {
+	merged := make([]uint16, 0, len(one)+len(other))
+	i, j := 0, 0
+	for i < len(one) && j < len(other) {
+		switch {
+		case one[i] < other[j]:
+			if op.onlyOne {
+				merged = append(merged, one[i])
+			}
+			i++
+		case one[i] > other[j]:
+			if op.onlyOther {
+				merged = append(merged, other[j])
+			}
+			j++
+		default:
+			if op.both {
+				merged = append(merged, one[i])
+			}
+			i++
+			j++
+		}
+	}
+	if op.onlyOne {
+		merged = append(merged, one[i:]...)
+	}
+	if op.onlyOther {
+		merged = append(merged, other[j:]...)
+	}
+	return merged
+}
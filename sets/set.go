@@ -55,6 +55,10 @@ func Union(one, other Set) Set {
 		return nil
 	}
 
+	if set, ok := bitmapOperation(one, other, (*BitSet).Or, (*RoaringBitmap).Or); ok {
+		return set
+	}
+
 	unionedSet := NewSimpleSet()
 	for _, v := range one.Elements() {
 		unionedSet.Add(v)
@@ -77,6 +81,10 @@ func Intersect(one, other Set) Set {
 		return nil
 	}
 
+	if set, ok := bitmapOperation(one, other, (*BitSet).And, (*RoaringBitmap).And); ok {
+		return set
+	}
+
 	intersectedSet := NewSimpleSet()
 	if other.Len() == 0 {
 		return intersectedSet
@@ -128,6 +136,10 @@ func Difference(one, other Set) Set {
 		return nil
 	}
 
+	if set, ok := bitmapOperation(one, other, (*BitSet).AndNot, (*RoaringBitmap).AndNot); ok {
+		return set
+	}
+
 	differencedSet := NewSimpleSet()
 	for _, v := range one.Elements() {
 		if !other.Contains(v) {
@@ -144,12 +156,32 @@ func SymmetricDifference(one, other Set) Set {
 		return nil
 	}
 
+	if set, ok := bitmapOperation(one, other, (*BitSet).Xor, (*RoaringBitmap).Xor); ok {
+		return set
+	}
+
 	diffA := Difference(one, other)
 	diffB := Difference(other, one)
 
 	return Union(diffA, diffB)
 }
 
+// one, other同为BitSet或同为RoaringBitmap时直接按位计算, 结果也是同类的集合
+func bitmapOperation(one, other Set, bitSetOp func(*BitSet, *BitSet) *BitSet,
+	roaringOp func(*RoaringBitmap, *RoaringBitmap) *RoaringBitmap) (Set, bool) {
+	switch one := one.(type) {
+	case *BitSet:
+		if other, ok := other.(*BitSet); ok {
+			return bitSetOp(one, other), true
+		}
+	case *RoaringBitmap:
+		if other, ok := other.(*RoaringBitmap); ok {
+			return roaringOp(one, other), true
+		}
+	}
+	return nil, false
+}
+
 func NewSimpleSet() Set {
 	return NewHashSet()
 }
@@ -0,0 +1,172 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"reflect"
+	"sync"
+)
+
+// TreeSet是基于红黑树的有序集合, Add/Remove/Contains 均为 O(log n)
+type TreeSet struct {
+	tree     *trees.RBTree
+	elemType reflect.Type
+	lock     *sync.Mutex
+}
+
+var _ Set = &TreeSet{}
+
+func NewTreeSet(compareFunc container.CompareFunction, elemType reflect.Type) *TreeSet {
+	return &TreeSet{
+		tree:     trees.NewRBTree(compareFunc),
+		elemType: elemType,
+		lock:     &sync.Mutex{},
+	}
+}
+
+func (set *TreeSet) isAcceptableElem(elem interface{}) bool {
+	return elem != nil && reflect.TypeOf(elem) == set.elemType
+}
+
+// adds the elements, the ones not of the set's element type are ignored
+func (set *TreeSet) Add(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if set.isAcceptableElem(e) {
+			set.tree.Put(e, nil)
+		}
+	}
+	set.lock.Unlock()
+}
+
+func (set *TreeSet) Remove(elements ...interface{}) {
+	set.lock.Lock()
+	for _, e := range elements {
+		if set.isAcceptableElem(e) {
+			set.tree.Remove(e)
+		}
+	}
+	set.lock.Unlock()
+}
+
+// whether all the elements are in the set, O(log n) for each element
+func (set *TreeSet) Contains(elements ...interface{}) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	for _, e := range elements {
+		if !set.isAcceptableElem(e) {
+			return false
+		}
+		if _, ok := set.tree.Get(e); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (set *TreeSet) Clear() {
+	set.lock.Lock()
+	set.tree.Clear()
+	set.lock.Unlock()
+}
+
+func (set *TreeSet) Len() int {
+	set.lock.Lock()
+	len := set.tree.Len()
+	set.lock.Unlock()
+	return len
+}
+
+func (set *TreeSet) Empty() bool {
+	return set.Len() == 0
+}
+
+func (set *TreeSet) Same(other Set) bool {
+	if other == nil || set.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(set.Elements()...)
+}
+
+// elements in order
+func (set *TreeSet) Elements() []interface{} {
+	set.lock.Lock()
+	snapshot := set.tree.Keys()
+	set.lock.Unlock()
+
+	return snapshot
+}
+
+func (set *TreeSet) ElemType() reflect.Type {
+	return set.elemType
+}
+
+// Returns a stateful iterator over the set in order, positioned before the first element.
+func (set *TreeSet) Iterator() container.Iterator {
+	return set.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the set in reverse order.
+func (set *TreeSet) ReverseIterator() container.ReverseIterator {
+	return &treeSetIterator{container.SyncReverseIterator(set.tree.ReverseIterator(), set.lock)}
+}
+
+// Returns a range-over-func sequence of the elements in order.
+// The loop body may add and remove elements, the walk goes on with the element after the current one.
+func (set *TreeSet) All() iter.Seq[interface{}] {
+	return container.KeySeq(set.Iterator)
+}
+
+// Returns a range-over-func sequence of the elements in reverse order.
+func (set *TreeSet) Backward() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for e := range container.BackwardSeq2(set.ReverseIterator) {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func (set *TreeSet) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("TreeSet{ ")
+	first := true
+	for _, key := range set.Elements() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%v", key))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
+
+// the tree keeps the elements as keys, the value of an element is the element itself
+type treeSetIterator struct {
+	container.ReverseIterator
+}
+
+func (it *treeSetIterator) Value() interface{} {
+	return it.Key()
+}
@@ -0,0 +1,160 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package sets
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestTreeSet(t *testing.T) {
+	set := NewTreeSet(container.IntCompareFunctionASC, reflect.TypeOf(0))
+
+	set.Add(5, 3)
+	set.Add()
+	set.Add(2, 4, 1)
+	set.Add(5, 7, 8)
+	set.Add("9")
+
+	if set.Empty() {
+		t.Errorf("Empty error, expected %v", false)
+	}
+
+	if set.Len() != 7 {
+		t.Errorf("Got %v expected %v", set.Len(), 7)
+	}
+
+	if !set.Contains(4, 8, 7) {
+		t.Errorf("Contains error, expected true")
+	}
+
+	if set.Contains(9) || set.Contains("9") {
+		t.Errorf("Contains error, expected false")
+	}
+
+	if actualValue, expectedValue := set.Elements(), []interface{}{1, 2, 3, 4, 5, 7, 8}; !reflect.DeepEqual(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	other := NewHashSet()
+	other.Add(set.Elements()...)
+	if !set.Same(other) || !other.Same(set) {
+		t.Errorf("Same error, expected true")
+	}
+
+	backward := []interface{}{}
+	for e := range set.Backward() {
+		backward = append(backward, e)
+	}
+	if expectedValue := []interface{}{8, 7, 5, 4, 3, 2, 1}; !reflect.DeepEqual(backward, expectedValue) {
+		t.Errorf("Got %v expected %v", backward, expectedValue)
+	}
+
+	it := set.Iterator()
+	for it.Next() {
+		if it.Key() != it.Value() {
+			t.Errorf("Got %v expected %v", it.Value(), it.Key())
+		}
+	}
+
+	set.Remove(1, 2)
+	if set.Len() != 5 || set.Contains(1) {
+		t.Errorf("Remove error, got %v", set)
+	}
+
+	set.Clear()
+	if !set.Empty() {
+		t.Errorf("Got %v expected %v", set.Len(), 0)
+	}
+}
+
+// the loop bodies add and remove odd elements, the even ones must all be seen once
+func TestSetWriteDuringRange(t *testing.T) {
+	sets := []struct {
+		name    string
+		ordered bool
+		newSet  func() (Set, iter.Seq[interface{}])
+	}{
+		{"TreeSet", true, func() (Set, iter.Seq[interface{}]) {
+			set := NewTreeSet(container.IntCompareFunctionASC, reflect.TypeOf(0))
+			return set, set.All()
+		}},
+		{"HashSet", false, func() (Set, iter.Seq[interface{}]) {
+			set := NewHashSet()
+			return set, set.All()
+		}},
+		{"BitSet", true, func() (Set, iter.Seq[interface{}]) {
+			set := NewBitSet(0)
+			return set, anySeq(set.All())
+		}},
+		{"RoaringBitmap", true, func() (Set, iter.Seq[interface{}]) {
+			set := NewRoaringBitmap()
+			return set, anySeq(set.All())
+		}},
+	}
+	for _, test := range sets {
+		set, all := test.newSet()
+		for n := 0; n < 200; n++ {
+			set.Add(n)
+		}
+
+		random := rand.New(rand.NewSource(1))
+		seen := map[int]int{}
+		previous := -1
+		for e := range all {
+			if test.ordered && e.(int) <= previous {
+				t.Errorf("%v: Got %v after %v", test.name, e, previous)
+			}
+			previous = e.(int)
+			seen[e.(int)]++
+			for i := 0; i < 3; i++ {
+				if odd := 2*random.Intn(100) + 1; random.Intn(2) == 0 {
+					set.Add(odd)
+				} else {
+					set.Remove(odd)
+				}
+			}
+		}
+		for n := 0; n < 200; n += 2 {
+			if seen[n] != 1 {
+				t.Errorf("%v: Got %v seen %v times expected %v", test.name, n, seen[n], 1)
+			}
+		}
+	}
+}
+
+func anySeq[T any](seq iter.Seq[T]) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for e := range seq {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func BenchmarkTreeSet(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		set := NewTreeSet(container.IntCompareFunctionASC, reflect.TypeOf(0))
+		for n := 0; n < 1000; n++ {
+			set.Add(n)
+		}
+		for n := 0; n < 1000; n++ {
+			set.Remove(n)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package skiplists
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type concurrentSkipListNode struct {
+	key      interface{}
+	value    atomic.Pointer[interface{}]
+	next     []atomic.Pointer[concurrentSkipListNode]
+	lock     sync.Mutex
+	marked   atomic.Bool // logically removed, it is being unlinked
+	linked   atomic.Bool // linked at all its levels, so it is logically present
+	topLevel int
+}
+
+// present tells if the node is in the map: inserted and not removed
+func (node *concurrentSkipListNode) present() bool {
+	return node.linked.Load() && !node.marked.Load()
+}
+
+// ConcurrentSkipList is an ordered map safe for concurrent use, implementing the lazy
+// skip list of Herlihy, Lev, Luchangco and Shavit. Lookups never lock, and a writer only
+// locks the nodes around the key it changes, so writers of distant keys do not wait
+// on each other as they would with the single mutex of maps.omap.
+//
+// Len is exact, but the methods walking several keys (Keys, Elements, Range, the iterator)
+// are weakly consistent: they see each key at most once and may miss concurrent changes.
+// Rank and Select are not provided, the widths they need could not be kept without
+// locking the whole list.
+type ConcurrentSkipList struct {
+	head       *concurrentSkipListNode
+	size       atomic.Int64
+	comparator container.CompareFunction
+}
+
+var _ container.ContainerInterface = &ConcurrentSkipList{}
+
+func NewConcurrentSkipList(comparator container.CompareFunction) *ConcurrentSkipList {
+	return &ConcurrentSkipList{
+		head: &concurrentSkipListNode{
+			next:     make([]atomic.Pointer[concurrentSkipListNode], _MAX_LEVEL),
+			topLevel: _MAX_LEVEL,
+		},
+		comparator: comparator,
+	}
+}
+
+// Inserts the key, or replaces the value of an existing key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (list *ConcurrentSkipList) Put(key interface{}, value interface{}) {
+	var preds, succs [_MAX_LEVEL]*concurrentSkipListNode
+	topLevel := randomLevel()
+	for {
+		if found := list.find(key, &preds, &succs); found >= 0 {
+			node := succs[found]
+			if node.marked.Load() {
+				// wait for the removal to unlink it, then insert again
+				runtime.Gosched()
+				continue
+			}
+			for !node.linked.Load() {
+				runtime.Gosched()
+			}
+			node.value.Store(&value)
+			return
+		}
+
+		locked, valid := list.lockPreds(&preds, topLevel, func(level int, pred *concurrentSkipListNode) bool {
+			succ := succs[level]
+			return !pred.marked.Load() && (succ == nil || !succ.marked.Load()) && pred.next[level].Load() == succ
+		})
+		if valid {
+			node := &concurrentSkipListNode{
+				key:      key,
+				next:     make([]atomic.Pointer[concurrentSkipListNode], topLevel),
+				topLevel: topLevel,
+			}
+			node.value.Store(&value)
+			for level := 0; level < topLevel; level++ {
+				node.next[level].Store(succs[level])
+			}
+			for level := 0; level < topLevel; level++ {
+				preds[level].next[level].Store(node)
+			}
+			node.linked.Store(true)
+			list.size.Add(1)
+		}
+		unlock(locked)
+		if valid {
+			return
+		}
+	}
+}
+
+// Searches the key and returns its value or nil if key is not found.
+// Second return parameter is true if key was found, otherwise false.
+func (list *ConcurrentSkipList) Get(key interface{}) (value interface{}, found bool) {
+	var preds, succs [_MAX_LEVEL]*concurrentSkipListNode
+	if level := list.find(key, &preds, &succs); level >= 0 && succs[level].present() {
+		return *succs[level].value.Load(), true
+	}
+	return nil, false
+}
+
+// Removes the key from the list, returns false if it was not there.
+func (list *ConcurrentSkipList) Remove(key interface{}) bool {
+	var preds, succs [_MAX_LEVEL]*concurrentSkipListNode
+	var victim *concurrentSkipListNode
+	for {
+		found := list.find(key, &preds, &succs)
+		if victim == nil {
+			if found < 0 {
+				return false
+			}
+			victim = succs[found]
+			// a node found below its top level is still being linked
+			if !victim.present() || victim.topLevel-1 != found {
+				return false
+			}
+			victim.lock.Lock()
+			if victim.marked.Load() {
+				victim.lock.Unlock()
+				return false
+			}
+			victim.marked.Store(true)
+		}
+
+		locked, valid := list.lockPreds(&preds, victim.topLevel, func(level int, pred *concurrentSkipListNode) bool {
+			return !pred.marked.Load() && pred.next[level].Load() == victim
+		})
+		if valid {
+			for level := victim.topLevel - 1; level >= 0; level-- {
+				preds[level].next[level].Store(victim.next[level].Load())
+			}
+			victim.lock.Unlock()
+			list.size.Add(-1)
+		}
+		unlock(locked)
+		if valid {
+			return true
+		}
+	}
+}
+
+// Returns all keys in-order
+func (list *ConcurrentSkipList) Keys() []interface{} {
+	keys := []interface{}{}
+	for key := range list.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (list *ConcurrentSkipList) Elements() []interface{} {
+	values := []interface{}{}
+	for _, value := range list.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+func (list *ConcurrentSkipList) Min() (key interface{}, value interface{}, found bool) {
+	return list.entry(list.firstFrom(list.head))
+}
+
+func (list *ConcurrentSkipList) Max() (key interface{}, value interface{}, found bool) {
+	for {
+		node := list.head
+		for level := _MAX_LEVEL - 1; level >= 0; level-- {
+			for next := node.next[level].Load(); next != nil; next = node.next[level].Load() {
+				node = next
+			}
+		}
+		if node == list.head {
+			return nil, nil, false
+		}
+		if node.present() {
+			return list.entry(node)
+		}
+		// the last node is being inserted or removed, look again once it is done
+		runtime.Gosched()
+	}
+}
+
+func (list *ConcurrentSkipList) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	var preds, succs [_MAX_LEVEL]*concurrentSkipListNode
+	for {
+		if level := list.find(key, &preds, &succs); level >= 0 && succs[level].present() {
+			return list.entry(succs[level])
+		}
+		if preds[0] == list.head {
+			return nil, nil, false
+		}
+		if preds[0].present() {
+			return list.entry(preds[0])
+		}
+		runtime.Gosched()
+	}
+}
+
+func (list *ConcurrentSkipList) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	return list.entry(list.ceilingNode(key, true))
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order, with keys between from and to.
+// The inclusive flags tell whether from and to themselves belong to the range, a nil bound means unbounded.
+// The list may be modified meanwhile, even by the loop body.
+func (list *ConcurrentSkipList) Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		node := list.firstFrom(list.head)
+		if from != nil {
+			node = list.ceilingNode(from, fromInclusive)
+		}
+		for ; node != nil; node = list.firstFrom(node) {
+			if to != nil {
+				compare := list.comparator(node.key, to)
+				if compare > 0 || compare == 0 && !toInclusive {
+					return
+				}
+			}
+			if !yield(node.key, *node.value.Load()) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order.
+func (list *ConcurrentSkipList) All() iter.Seq2[interface{}, interface{}] {
+	return list.Range(nil, nil, true, true)
+}
+
+// Returns true if list does not contain any keys
+func (list *ConcurrentSkipList) Empty() bool {
+	return list.Len() == 0
+}
+
+// Returns number of keys in the list.
+func (list *ConcurrentSkipList) Len() int {
+	return int(list.size.Load())
+}
+
+// Removes all keys from the list, one by one: the keys put meanwhile may be kept.
+func (list *ConcurrentSkipList) Clear() {
+	for node := list.firstFrom(list.head); node != nil; node = list.firstFrom(node) {
+		list.Remove(node.key)
+	}
+}
+
+// check if the values are in the list
+func (list *ConcurrentSkipList) Contains(values ...interface{}) bool {
+	return contains(list.Elements(), values)
+}
+
+func (list *ConcurrentSkipList) String() string {
+	return entriesString("ConcurrentSkipList", list.All())
+}
+
+// fills the last nodes with a key < key and the nodes after them at every level,
+// returns the highest level where the node after is key, or -1
+func (list *ConcurrentSkipList) find(key interface{}, preds, succs *[_MAX_LEVEL]*concurrentSkipListNode) int {
+	found := -1
+	pred := list.head
+	for level := _MAX_LEVEL - 1; level >= 0; level-- {
+		succ := pred.next[level].Load()
+		for succ != nil && list.comparator(succ.key, key) < 0 {
+			pred, succ = succ, succ.next[level].Load()
+		}
+		if found < 0 && succ != nil && list.comparator(succ.key, key) == 0 {
+			found = level
+		}
+		preds[level], succs[level] = pred, succ
+	}
+	return found
+}
+
+// locks the distinct predecessors of the levels below topLevel, from the bottom,
+// and checks them with valid; returns the locked nodes for unlock
+func (list *ConcurrentSkipList) lockPreds(preds *[_MAX_LEVEL]*concurrentSkipListNode, topLevel int,
+	valid func(level int, pred *concurrentSkipListNode) bool) ([]*concurrentSkipListNode, bool) {
+	locked := make([]*concurrentSkipListNode, 0, topLevel)
+	for level := 0; level < topLevel; level++ {
+		pred := preds[level]
+		if len(locked) == 0 || locked[len(locked)-1] != pred {
+			pred.lock.Lock()
+			locked = append(locked, pred)
+		}
+		if !valid(level, pred) {
+			return locked, false
+		}
+	}
+	return locked, true
+}
+
+func unlock(nodes []*concurrentSkipListNode) {
+	for _, node := range nodes {
+		node.lock.Unlock()
+	}
+}
+
+// the first present node after node at the lowest level, or nil
+func (list *ConcurrentSkipList) firstFrom(node *concurrentSkipListNode) *concurrentSkipListNode {
+	for node = node.next[0].Load(); node != nil && !node.present(); node = node.next[0].Load() {
+	}
+	return node
+}
+
+// the first present node with a key >= key, or > key if not inclusive
+func (list *ConcurrentSkipList) ceilingNode(key interface{}, inclusive bool) *concurrentSkipListNode {
+	var preds, succs [_MAX_LEVEL]*concurrentSkipListNode
+	list.find(key, &preds, &succs)
+	node := list.firstFrom(preds[0])
+	if node != nil && !inclusive && list.comparator(node.key, key) == 0 {
+		node = list.firstFrom(node)
+	}
+	return node
+}
+
+func (list *ConcurrentSkipList) entry(node *concurrentSkipListNode) (key interface{}, value interface{}, found bool) {
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.key, *node.value.Load(), true
+}
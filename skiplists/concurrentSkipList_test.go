@@ -0,0 +1,134 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package skiplists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSkipList(t *testing.T) {
+
+	list := NewConcurrentSkipList(container.IntCompareFunctionASC)
+	for _, key := range []int{50, 20, 80, 10, 30} {
+		list.Put(key, fmt.Sprint(key))
+	}
+	list.Put(10, "ten")
+
+	if actualValue, expectedValue := list.String(), "ConcurrentSkipList{ 10:ten, 20:20, 30:30, 50:50, 80:80 }"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if key, _, _ := list.Floor(40); key != 30 {
+		t.Errorf("Got %v expected %v", key, 30)
+	}
+	if key, _, _ := list.Ceiling(40); key != 50 {
+		t.Errorf("Got %v expected %v", key, 50)
+	}
+	if key, _, _ := list.Max(); key != 80 {
+		t.Errorf("Got %v expected %v", key, 80)
+	}
+	keys := []interface{}{}
+	for key := range list.Range(10, 50, false, false) {
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[20 30]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if !list.Remove(20) || list.Remove(20) || list.Len() != 4 {
+		t.Errorf("Got %v expected %v", list.Len(), 4)
+	}
+	if value, found := list.Get(20); found {
+		t.Errorf("Got %v expected %v", value, nil)
+	}
+	list.Clear()
+	if _, _, found := list.Min(); found || !list.Empty() {
+		t.Errorf("Got %v expected %v", list, "empty list")
+	}
+
+}
+
+func TestConcurrentSkipListParallel(t *testing.T) {
+
+	list := NewConcurrentSkipList(container.IntCompareFunctionASC)
+	var wg sync.WaitGroup
+	// every writer owns the keys equal to its number modulo 8, and removes the odd ones again
+	for writer := 0; writer < 8; writer++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := writer; key < 4000; key += 8 {
+				list.Put(key, key)
+			}
+			for key := writer; key < 4000; key += 8 {
+				if key%2 == 1 && !list.Remove(key) {
+					t.Errorf("Got %v expected %v", false, true)
+				}
+			}
+		}()
+	}
+	for reader := 0; reader < 4; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				previous := -1
+				for key, value := range list.All() {
+					if key.(int) <= previous || key != value {
+						t.Errorf("Got %v after %v", key, previous)
+						return
+					}
+					previous = key.(int)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if list.Len() != 2000 || len(list.Keys()) != 2000 {
+		t.Errorf("Got %v expected %v", list.Len(), 2000)
+	}
+	for key := 0; key < 4000; key++ {
+		if _, found := list.Get(key); found != (key%2 == 0) {
+			t.Errorf("Get(%v): got %v expected %v", key, found, key%2 == 0)
+		}
+	}
+
+}
+
+// the goroutines share the list, each mixing lookups with a few writes
+func BenchmarkConcurrentSkipList(b *testing.B) {
+	list := NewConcurrentSkipList(container.IntCompareFunctionASC)
+	for key := 0; key < 1000; key++ {
+		list.Put(key, key)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		random := rand.New(rand.NewSource(rand.Int63()))
+		for pb.Next() {
+			for n := 0; n < 1000; n++ {
+				switch key := random.Intn(1000); n % 10 {
+				case 0:
+					list.Put(key, key)
+				case 1:
+					list.Remove(key)
+				default:
+					list.Get(key)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package skiplists
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+type iteratorPosition byte
+
+const (
+	begin, between, end iteratorPosition = 0, 1, 2
+)
+
+// skipListIterator walks the lowest level of the list, backwards through the prev links.
+type skipListIterator struct {
+	list     *SkipList
+	node     *skipListNode
+	position iteratorPosition
+}
+
+// Returns a stateful iterator over the list in key order, positioned before the smallest key.
+func (list *SkipList) Iterator() container.Iterator {
+	return list.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the list in reverse key order.
+func (list *SkipList) ReverseIterator() container.ReverseIterator {
+	return &skipListIterator{list: list, position: begin}
+}
+
+func (it *skipListIterator) Next() bool {
+	switch it.position {
+	case end:
+		return false
+	case begin:
+		it.node = it.list.head.levels[0].next
+	case between:
+		it.node = it.node.levels[0].next
+	}
+	return it.settle(end)
+}
+
+func (it *skipListIterator) Prev() bool {
+	switch it.position {
+	case begin:
+		return false
+	case end:
+		it.node = it.list.tail
+	case between:
+		it.node = it.node.prev
+	}
+	return it.settle(begin)
+}
+
+// fixes the position after a move, falling off the list to the given side
+func (it *skipListIterator) settle(side iteratorPosition) bool {
+	if it.node == nil {
+		it.position = side
+		return false
+	}
+	it.position = between
+	return true
+}
+
+func (it *skipListIterator) Value() interface{} {
+	return it.node.value
+}
+
+func (it *skipListIterator) Key() interface{} {
+	return it.node.key
+}
+
+func (it *skipListIterator) Reset() {
+	it.node = nil
+	it.position = begin
+}
+
+func (it *skipListIterator) End() {
+	it.node = nil
+	it.position = end
+}
+
+// concurrentSkipListIterator walks the lowest level of the list, skipping the nodes
+// which are being inserted or removed. It sees some of the changes made meanwhile.
+type concurrentSkipListIterator struct {
+	list    *ConcurrentSkipList
+	node    *concurrentSkipListNode
+	started bool
+}
+
+// Returns a stateful iterator over the list in key order, positioned before the smallest key.
+// It may be used while other goroutines modify the list, see ConcurrentSkipList.Range.
+func (list *ConcurrentSkipList) Iterator() container.Iterator {
+	return &concurrentSkipListIterator{list: list}
+}
+
+func (it *concurrentSkipListIterator) Next() bool {
+	switch {
+	case !it.started:
+		it.started = true
+		it.node = it.list.firstFrom(it.list.head)
+	case it.node != nil:
+		it.node = it.list.firstFrom(it.node)
+	}
+	return it.node != nil
+}
+
+func (it *concurrentSkipListIterator) Value() interface{} {
+	return *it.node.value.Load()
+}
+
+func (it *concurrentSkipListIterator) Key() interface{} {
+	return it.node.key
+}
+
+func (it *concurrentSkipListIterator) Reset() {
+	it.node = nil
+	it.started = false
+}
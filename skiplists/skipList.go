@@ -0,0 +1,354 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package skiplists provides ordered maps built on skip lists: SkipList, which keeps the
+// width of its links so that it also answers Rank and Select, and ConcurrentSkipList,
+// which lets readers and writers work concurrently without a global mutex.
+package skiplists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"math/rand"
+	"strings"
+)
+
+const (
+	_MAX_LEVEL = 32
+	// a node reaches the next level with probability 1/_LEVEL_FACTOR
+	_LEVEL_FACTOR = 4
+)
+
+type skipListLevel struct {
+	next *skipListNode
+	span int // number of keys the link passes over, the next node included
+}
+
+type skipListNode struct {
+	key    interface{}
+	value  interface{}
+	prev   *skipListNode // at the lowest level, nil for the first node
+	levels []skipListLevel
+}
+
+// SkipList is an ordered map on a skip list: every node is linked at a random number
+// of levels, so that a search skips most nodes. Put, Get and Remove are O(log n) on average.
+// It is not safe for concurrent use, see ConcurrentSkipList.
+type SkipList struct {
+	head       *skipListNode
+	tail       *skipListNode
+	level      int
+	size       int
+	comparator container.CompareFunction
+}
+
+var _ trees.SortedMapInterface = &SkipList{}
+
+func NewSkipList(comparator container.CompareFunction) *SkipList {
+	return &SkipList{
+		head:       &skipListNode{levels: make([]skipListLevel, _MAX_LEVEL)},
+		level:      1,
+		comparator: comparator,
+	}
+}
+
+// number of levels of a new node, at least 1
+func randomLevel() int {
+	level := 1
+	for level < _MAX_LEVEL && rand.Intn(_LEVEL_FACTOR) == 0 {
+		level++
+	}
+	return level
+}
+
+// Inserts the key, or replaces the value of an existing key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (list *SkipList) Put(key interface{}, value interface{}) {
+	var update [_MAX_LEVEL]*skipListNode
+	var rank [_MAX_LEVEL]int
+	node := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		if i < list.level-1 {
+			rank[i] = rank[i+1]
+		}
+		for node.levels[i].next != nil && list.comparator(node.levels[i].next.key, key) < 0 {
+			rank[i] += node.levels[i].span
+			node = node.levels[i].next
+		}
+		update[i] = node
+	}
+	if next := node.levels[0].next; next != nil && list.comparator(next.key, key) == 0 {
+		next.value = value
+		return
+	}
+
+	level := randomLevel()
+	for i := list.level; i < level; i++ {
+		update[i] = list.head
+		update[i].levels[i].span = list.size
+	}
+	list.level = max(list.level, level)
+
+	node = &skipListNode{key: key, value: value, levels: make([]skipListLevel, level)}
+	for i := 0; i < level; i++ {
+		node.levels[i].next = update[i].levels[i].next
+		update[i].levels[i].next = node
+		// rank[0]-rank[i] keys lie between update[i] and the new node
+		node.levels[i].span = update[i].levels[i].span - (rank[0] - rank[i])
+		update[i].levels[i].span = rank[0] - rank[i] + 1
+	}
+	for i := level; i < list.level; i++ {
+		update[i].levels[i].span++
+	}
+
+	if update[0] != list.head {
+		node.prev = update[0]
+	}
+	if node.levels[0].next != nil {
+		node.levels[0].next.prev = node
+	} else {
+		list.tail = node
+	}
+	list.size++
+}
+
+// Searches the key and returns its value or nil if key is not found.
+// Second return parameter is true if key was found, otherwise false.
+func (list *SkipList) Get(key interface{}) (value interface{}, found bool) {
+	if node := list.lowerNode(key).levels[0].next; node != nil && list.comparator(node.key, key) == 0 {
+		return node.value, true
+	}
+	return nil, false
+}
+
+// Remove the key from the list.
+func (list *SkipList) Remove(key interface{}) {
+	var update [_MAX_LEVEL]*skipListNode
+	node := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for node.levels[i].next != nil && list.comparator(node.levels[i].next.key, key) < 0 {
+			node = node.levels[i].next
+		}
+		update[i] = node
+	}
+	node = node.levels[0].next
+	if node == nil || list.comparator(node.key, key) != 0 {
+		return
+	}
+
+	for i := 0; i < list.level; i++ {
+		if update[i].levels[i].next == node {
+			update[i].levels[i].span += node.levels[i].span - 1
+			update[i].levels[i].next = node.levels[i].next
+		} else {
+			update[i].levels[i].span--
+		}
+	}
+	if node.levels[0].next != nil {
+		node.levels[0].next.prev = node.prev
+	} else {
+		list.tail = node.prev
+	}
+	for list.level > 1 && list.head.levels[list.level-1].next == nil {
+		list.level--
+	}
+	list.size--
+}
+
+// Returns all keys in-order
+func (list *SkipList) Keys() []interface{} {
+	keys := make([]interface{}, 0, list.size)
+	for node := list.head.levels[0].next; node != nil; node = node.levels[0].next {
+		keys = append(keys, node.key)
+	}
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (list *SkipList) Elements() []interface{} {
+	values := make([]interface{}, 0, list.size)
+	for node := list.head.levels[0].next; node != nil; node = node.levels[0].next {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+func (list *SkipList) Min() (key interface{}, value interface{}, found bool) {
+	return nodeEntry(list.head.levels[0].next)
+}
+
+func (list *SkipList) Max() (key interface{}, value interface{}, found bool) {
+	return nodeEntry(list.tail)
+}
+
+func (list *SkipList) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	node := list.lowerNode(key)
+	if next := node.levels[0].next; next != nil && list.comparator(next.key, key) == 0 {
+		return nodeEntry(next)
+	}
+	if node == list.head {
+		return nil, nil, false
+	}
+	return nodeEntry(node)
+}
+
+func (list *SkipList) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	return nodeEntry(list.lowerNode(key).levels[0].next)
+}
+
+// Returns the number of keys < key, which is the index key has or would have in Keys().
+func (list *SkipList) Rank(key interface{}) int {
+	rank := 0
+	node := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for node.levels[i].next != nil && list.comparator(node.levels[i].next.key, key) < 0 {
+			rank += node.levels[i].span
+			node = node.levels[i].next
+		}
+	}
+	return rank
+}
+
+// Returns the key at the given index in key order and its value.
+// Third return parameter is false if index is out of range.
+func (list *SkipList) Select(index int) (key interface{}, value interface{}, found bool) {
+	if index < 0 || index >= list.size {
+		return nil, nil, false
+	}
+	traversed := 0
+	node := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for node.levels[i].next != nil && traversed+node.levels[i].span <= index+1 {
+			traversed += node.levels[i].span
+			node = node.levels[i].next
+		}
+		if traversed == index+1 {
+			break
+		}
+	}
+	return nodeEntry(node)
+}
+
+// Returns the number of keys in [from, to).
+func (list *SkipList) CountRange(from, to interface{}) int {
+	return max(list.Rank(to)-list.Rank(from), 0)
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order, with keys between from and to.
+// The inclusive flags tell whether from and to themselves belong to the range, a nil bound means unbounded.
+func (list *SkipList) Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		node := list.head.levels[0].next
+		if from != nil {
+			node = list.lowerNode(from).levels[0].next
+			if node != nil && !fromInclusive && list.comparator(node.key, from) == 0 {
+				node = node.levels[0].next
+			}
+		}
+		for ; node != nil; node = node.levels[0].next {
+			if to != nil {
+				compare := list.comparator(node.key, to)
+				if compare > 0 || compare == 0 && !toInclusive {
+					return
+				}
+			}
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order.
+func (list *SkipList) All() iter.Seq2[interface{}, interface{}] {
+	return list.Range(nil, nil, true, true)
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in reverse key order.
+func (list *SkipList) Backward() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		for node := list.tail; node != nil; node = node.prev {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns true if list does not contain any keys
+func (list *SkipList) Empty() bool {
+	return list.size == 0
+}
+
+// Returns number of keys in the list.
+func (list *SkipList) Len() int {
+	return list.size
+}
+
+// Removes all keys from the list.
+func (list *SkipList) Clear() {
+	clear(list.head.levels)
+	list.tail = nil
+	list.level = 1
+	list.size = 0
+}
+
+// check if the values are in the list
+func (list *SkipList) Contains(values ...interface{}) bool {
+	return contains(list.Elements(), values)
+}
+
+func (list *SkipList) String() string {
+	return entriesString("SkipList", list.All())
+}
+
+// the last node whose key is < key, the head if there is none
+func (list *SkipList) lowerNode(key interface{}) *skipListNode {
+	node := list.head
+	for i := list.level - 1; i >= 0; i-- {
+		for node.levels[i].next != nil && list.comparator(node.levels[i].next.key, key) < 0 {
+			node = node.levels[i].next
+		}
+	}
+	return node
+}
+
+func nodeEntry(node *skipListNode) (key interface{}, value interface{}, found bool) {
+	if node == nil {
+		return nil, nil, false
+	}
+	return node.key, node.value, true
+}
+
+// check if every value is one of the elements
+func contains(elements []interface{}, values []interface{}) bool {
+	for _, value := range values {
+		if !container.Contains(value, elements) {
+			return false
+		}
+	}
+	return true
+}
+
+func entriesString(name string, entries iter.Seq2[interface{}, interface{}]) string {
+	str := name + "{ "
+	values := []string{}
+	for key, value := range entries {
+		values = append(values, fmt.Sprintf("%v:%v", key, value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
@@ -0,0 +1,106 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package skiplists
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/trees"
+	"math/rand"
+	"testing"
+)
+
+// the SortedMapInterface methods are tested with the other sorted maps in the trees package
+func TestSkipList(t *testing.T) {
+
+	list := NewSkipList(container.IntCompareFunctionASC)
+	for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+		list.Put(key, fmt.Sprint(key))
+	}
+	list.Put(10, "ten")
+
+	if actualValue, expectedValue := list.String(), "SkipList{ 10:ten, 20:20, 30:30, 50:50, 70:70, 80:80, 90:90 }"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	keys := []interface{}{}
+	for key := range list.Backward() {
+		keys = append(keys, key)
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys), "[90 80 70 50 30 20 10]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	it := list.ReverseIterator()
+	for it.End(); it.Prev() && it.Key() != 50; {
+	}
+	if it.Next(); it.Key() != 70 {
+		t.Errorf("Got %v expected %v", it.Key(), 70)
+	}
+
+	list.Clear()
+	if !list.Empty() || len(list.Keys()) != 0 {
+		t.Errorf("Got %v expected %v", list, "empty list")
+	}
+
+}
+
+func TestSkipListRank(t *testing.T) {
+
+	list := NewSkipList(container.IntCompareFunctionASC)
+	reference := trees.NewRBTree(container.IntCompareFunctionASC)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		if key := random.Intn(1000); random.Intn(3) == 0 {
+			list.Remove(key)
+			reference.Remove(key)
+		} else {
+			list.Put(key, i)
+			reference.Put(key, i)
+		}
+	}
+
+	if actualValue, expectedValue := fmt.Sprint(list.Keys()), fmt.Sprint(reference.Keys()); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for key := -1; key <= 1000; key++ {
+		if actualValue, expectedValue := list.Rank(key), reference.Rank(key); actualValue != expectedValue {
+			t.Errorf("Rank(%v): got %v expected %v", key, actualValue, expectedValue)
+		}
+	}
+	for index := -1; index <= list.Len(); index++ {
+		actualKey, actualValue, actualFound := list.Select(index)
+		expectedKey, expectedValue, expectedFound := reference.Select(index)
+		if actualKey != expectedKey || actualValue != expectedValue || actualFound != expectedFound {
+			t.Errorf("Select(%v): got %v expected %v", index, actualKey, expectedKey)
+		}
+	}
+	if actualValue, expectedValue := list.CountRange(100, 500), reference.CountRange(100, 500); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+}
+
+func BenchmarkSkipList(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(1000)
+	for i := 0; i < b.N; i++ {
+		list := NewSkipList(container.IntCompareFunctionASC)
+		for _, key := range keys {
+			list.Put(key, key)
+		}
+		for _, key := range keys {
+			list.Remove(key)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package stacks
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container/lists"
+	"iter"
+	"strings"
+)
+
+// ArrayStackOf is the type-parameterized version of ArrayStack
+type ArrayStackOf[T any] struct {
+	list *lists.ArrayListOf[T]
+}
+
+func NewArrayStackOf[T any]() *ArrayStackOf[T] {
+	return &ArrayStackOf[T]{
+		list: lists.NewArrayListOf[T](),
+	}
+}
+
+// Pushes a value onto the top of the stack
+func (stack *ArrayStackOf[T]) Push(value T) {
+	stack.list.Add(value)
+}
+
+// Pops (removes) top element on stack and returns it, or zero value if stack is empty.
+// Second return parameter is true, unless the stack was empty and there was nothing to pop.
+func (stack *ArrayStackOf[T]) Pop() (value T, ok bool) {
+	value, ok = stack.list.Get(stack.list.Len() - 1)
+	stack.list.Remove(stack.list.Len() - 1)
+	return
+}
+
+// Returns top element on the stack without removing it, or zero value if stack is empty.
+// Second return parameter is true, unless the stack was empty and there was nothing to peek.
+func (stack *ArrayStackOf[T]) Peek() (value T, ok bool) {
+	return stack.list.Get(stack.list.Len() - 1)
+}
+
+// Returns true if stack does not contain any elements.
+func (stack *ArrayStackOf[T]) Empty() bool {
+	return stack.list.Empty()
+}
+
+// Returns number of elements within the stack.
+func (stack *ArrayStackOf[T]) Len() int {
+	return stack.list.Len()
+}
+
+// Removes all elements from the stack.
+func (stack *ArrayStackOf[T]) Clear() {
+	stack.list.Clear()
+}
+
+// Returns all elements in the stack (LIFO order).
+func (stack *ArrayStackOf[T]) Elements() []T {
+	size := stack.list.Len()
+	elements := make([]T, size, size)
+	for i := 1; i <= size; i++ {
+		elements[size-i], _ = stack.list.Get(i - 1) // in reverse (LIFO)
+	}
+	return elements
+}
+
+func (stack *ArrayStackOf[T]) String() string {
+	str := "ArrayStack{ "
+	values := []string{}
+	for _, value := range stack.list.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (stack *ArrayStackOf[T]) Contains(elements ...T) bool {
+	return stack.list.Contains(elements...)
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in LIFO order, the top first.
+func (stack *ArrayStackOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for idx, value := range stack.list.Backward() {
+			if !yield(stack.list.Len()-1-idx, value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements in LIFO order.
+func (stack *ArrayStackOf[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range stack.list.Backward() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
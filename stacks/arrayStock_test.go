@@ -86,3 +86,56 @@ func BenchmarkArrayStack(b *testing.B) {
 	}
 
 }
+
+func TestArrayStackIterator(t *testing.T) {
+
+	stack := NewArrayStack()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	it := stack.ReverseIterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Value(), 4-count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+	for it.Prev() {
+		if actualValue, expectedValue := it.Value(), 4-count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count--
+	}
+	if count != 0 {
+		t.Errorf("Got %v expected %v", count, 0)
+	}
+
+}
+
+func TestArrayStackSeq(t *testing.T) {
+
+	stack := NewArrayStack()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	for position, value := range stack.All() {
+		if expectedValue := 3 - position; value != expectedValue {
+			t.Errorf("Got %v expected %v", value, expectedValue)
+		}
+	}
+
+	for position, value := range stack.Backward() {
+		if position != 2 || value != 1 {
+			t.Errorf("Got %v expected %v", value, 1)
+		}
+		break
+	}
+
+}
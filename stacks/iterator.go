@@ -0,0 +1,123 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package stacks
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+// walks the stack from the top, like Elements()
+type arrayStackIterator struct {
+	stack *ArrayStack
+	index int
+}
+
+// Returns a stateful iterator over the stack (LIFO order), positioned before the top element.
+func (stack *ArrayStack) Iterator() container.Iterator {
+	return stack.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the stack from the bottom.
+func (stack *ArrayStack) ReverseIterator() container.ReverseIterator {
+	return &arrayStackIterator{stack: stack, index: -1}
+}
+
+func (it *arrayStackIterator) Next() bool {
+	if it.index < it.stack.Len() {
+		it.index++
+	}
+	return it.inRange()
+}
+
+func (it *arrayStackIterator) Prev() bool {
+	if it.index >= 0 {
+		it.index--
+	}
+	return it.inRange()
+}
+
+func (it *arrayStackIterator) Value() interface{} {
+	value, _ := it.stack.list.Get(it.stack.Len() - 1 - it.index)
+	return value
+}
+
+func (it *arrayStackIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *arrayStackIterator) Reset() {
+	it.index = -1
+}
+
+func (it *arrayStackIterator) End() {
+	it.index = it.stack.Len()
+}
+
+func (it *arrayStackIterator) inRange() bool {
+	return it.index >= 0 && it.index < it.stack.Len()
+}
+
+// Returns a stateful iterator over the stack (LIFO order), positioned before the top element.
+func (stack *LinkedListStack) Iterator() container.Iterator {
+	// elements are prepended, so the list is already in LIFO order
+	return stack.list.Iterator()
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in LIFO order, the top first.
+func (stack *ArrayStack) All() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		size := stack.list.Len()
+		for i := 0; i < size; i++ {
+			value, _ := stack.list.Get(size - 1 - i)
+			if !yield(i, value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (position, element) pairs from the bottom to the top.
+func (stack *ArrayStack) Backward() iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		size := stack.list.Len()
+		for i := size - 1; i >= 0; i-- {
+			value, _ := stack.list.Get(size - 1 - i)
+			if !yield(i, value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the elements in LIFO order.
+func (stack *ArrayStack) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range stack.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in LIFO order, the top first.
+func (stack *LinkedListStack) All() iter.Seq2[int, interface{}] {
+	return stack.list.All()
+}
+
+// Returns a range-over-func sequence of the elements in LIFO order.
+func (stack *LinkedListStack) Values() iter.Seq[interface{}] {
+	return stack.list.Values()
+}
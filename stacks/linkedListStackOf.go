@@ -0,0 +1,96 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package stacks
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container/lists"
+	"iter"
+	"strings"
+)
+
+// LinkedListStackOf is the type-parameterized version of LinkedListStack
+type LinkedListStackOf[T any] struct {
+	list *lists.SinglyLinkedListOf[T]
+}
+
+func NewLinkedListStackOf[T any]() *LinkedListStackOf[T] {
+	return &LinkedListStackOf[T]{
+		list: lists.NewSinglyLinkedListOf[T](),
+	}
+}
+
+// Pushes a value onto the top of the stack
+func (stack *LinkedListStackOf[T]) Push(value T) {
+	stack.list.Prepend(value)
+}
+
+// Pops (removes) top element on stack and returns it, or zero value if stack is empty.
+// Second return parameter is true, unless the stack was empty and there was nothing to pop.
+func (stack *LinkedListStackOf[T]) Pop() (value T, ok bool) {
+	value, ok = stack.list.Get(0)
+	stack.list.Remove(0)
+	return
+}
+
+// Returns top element on the stack without removing it, or zero value if stack is empty.
+// Second return parameter is true, unless the stack was empty and there was nothing to peek.
+func (stack *LinkedListStackOf[T]) Peek() (value T, ok bool) {
+	return stack.list.Get(0)
+}
+
+// Returns true if stack does not contain any elements.
+func (stack *LinkedListStackOf[T]) Empty() bool {
+	return stack.list.Empty()
+}
+
+// Returns number of elements within the stack.
+func (stack *LinkedListStackOf[T]) Len() int {
+	return stack.list.Len()
+}
+
+// Removes all elements from the stack.
+func (stack *LinkedListStackOf[T]) Clear() {
+	stack.list.Clear()
+}
+
+// Returns all elements in the stack (LIFO order).
+func (stack *LinkedListStackOf[T]) Elements() []T {
+	return stack.list.Elements()
+}
+
+func (stack *LinkedListStackOf[T]) String() string {
+	str := "LinkedListStack{ "
+	values := []string{}
+	for _, value := range stack.list.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (stack *LinkedListStackOf[T]) Contains(elements ...T) bool {
+	return stack.list.Contains(elements...)
+}
+
+// Returns a range-over-func sequence of (position, element) pairs in LIFO order, the top first.
+func (stack *LinkedListStackOf[T]) All() iter.Seq2[int, T] {
+	return stack.list.All()
+}
+
+// Returns a range-over-func sequence of the elements in LIFO order.
+func (stack *LinkedListStackOf[T]) Values() iter.Seq[T] {
+	return stack.list.Values()
+}
@@ -83,3 +83,24 @@ func BenchmarkLinkedListStack(b *testing.B) {
 		}
 	}
 }
+
+func TestLinkedListStackIterator(t *testing.T) {
+
+	stack := NewLinkedListStack()
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	it := stack.Iterator()
+	count := 0
+	for it.Next() {
+		count++
+		if actualValue, expectedValue := it.Value(), 4-count; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+}
@@ -0,0 +1,78 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package stringsets
+
+type ternarySearchTreeFrame struct {
+	node   *tstNode
+	prefix string
+	stage  byte // 0: left subtree next, 1: the node, 2: middle subtree, 3: right subtree
+}
+
+// ternarySearchTreeIterator walks the tree in order with an explicit stack.
+type ternarySearchTreeIterator struct {
+	tree    *TernarySearchTree
+	stack   []ternarySearchTreeFrame
+	current string
+	started bool
+}
+
+func (it *ternarySearchTreeIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		it.push(it.tree.root, "")
+		if it.tree.hasEmpty {
+			it.current = ""
+			return true
+		}
+	}
+	for len(it.stack) > 0 {
+		frame := &it.stack[len(it.stack)-1]
+		node, prefix := frame.node, frame.prefix
+		switch frame.stage++; frame.stage {
+		case 1:
+			it.push(node.left, prefix)
+		case 2:
+			if node.end {
+				it.current = prefix + string(node.char)
+				return true
+			}
+		case 3:
+			it.push(node.middle, prefix+string(node.char))
+		default:
+			// the right subtree takes the place of the node
+			it.stack = it.stack[:len(it.stack)-1]
+			it.push(node.right, prefix)
+		}
+	}
+	return false
+}
+
+func (it *ternarySearchTreeIterator) push(node *tstNode, prefix string) {
+	if node != nil {
+		it.stack = append(it.stack, ternarySearchTreeFrame{node: node, prefix: prefix})
+	}
+}
+
+// the set has no value, the value of an element is the element itself
+func (it *ternarySearchTreeIterator) Value() interface{} {
+	return it.current
+}
+
+func (it *ternarySearchTreeIterator) Key() interface{} {
+	return it.current
+}
+
+func (it *ternarySearchTreeIterator) Reset() {
+	it.stack, it.current, it.started = it.stack[:0], "", false
+}
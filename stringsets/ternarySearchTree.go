@@ -0,0 +1,443 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package stringsets provides sets of strings which also answer prefix, fuzzy and
+// autocomplete queries.
+package stringsets
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/sets"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"sync"
+)
+
+// every node holds one byte: the strings going on with a smaller or a greater byte at the
+// same position are found through left and right, and the ones going on with this byte
+// through middle; end marks the last byte of a string
+type tstNode struct {
+	char                byte
+	left, middle, right *tstNode
+	end                 bool
+	weight              int
+	maxWeight           int // of the strings ending in the subtree, left and right included
+}
+
+// recomputes maxWeight from the node and its children
+func (node *tstNode) update() {
+	node.maxWeight = minWeight
+	if node.end {
+		node.maxWeight = node.weight
+	}
+	for _, child := range []*tstNode{node.left, node.middle, node.right} {
+		if child != nil {
+			node.maxWeight = max(node.maxWeight, child.maxWeight)
+		}
+	}
+}
+
+const minWeight = -int(^uint(0)>>1) - 1
+
+// TernarySearchTree is a set of strings on a ternary search tree, which is ordered like
+// container.StringCompareFunction. Besides the Set operations, which are O(len(s)) on
+// average, it finds the strings starting with a prefix, the strings close to a given one,
+// and the heaviest completions of a prefix for autocompletion.
+//
+// Removing a string only prunes the nodes left without any string below them.
+type TernarySearchTree struct {
+	root        *tstNode
+	hasEmpty    bool // "" can not be held by a node
+	emptyWeight int
+	size        int
+	lock        *sync.Mutex
+}
+
+var _ sets.Set = &TernarySearchTree{}
+
+func NewTernarySearchTree() *TernarySearchTree {
+	return &TernarySearchTree{lock: &sync.Mutex{}}
+}
+
+// adds the strings with weight 0, the ones already in keep their weight; non-strings are ignored
+func (tree *TernarySearchTree) Add(elements ...interface{}) {
+	tree.lock.Lock()
+	for _, e := range elements {
+		if s, ok := e.(string); ok {
+			tree.put(s, 0, false)
+		}
+	}
+	tree.lock.Unlock()
+}
+
+// adds the string, or changes its weight if it is already in
+func (tree *TernarySearchTree) AddWeighted(element string, weight int) {
+	tree.lock.Lock()
+	tree.put(element, weight, true)
+	tree.lock.Unlock()
+}
+
+// Returns the weight of the string, second return parameter is false if it is not in the set.
+func (tree *TernarySearchTree) Weight(element string) (weight int, ok bool) {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	if element == "" {
+		return tree.emptyWeight, tree.hasEmpty
+	}
+	if node := tree.find(element); node != nil && node.end {
+		return node.weight, true
+	}
+	return 0, false
+}
+
+func (tree *TernarySearchTree) Remove(elements ...interface{}) {
+	tree.lock.Lock()
+	for _, e := range elements {
+		s, ok := e.(string)
+		switch {
+		case !ok:
+		case s == "":
+			if tree.hasEmpty {
+				tree.hasEmpty, tree.emptyWeight = false, 0
+				tree.size--
+			}
+		default:
+			tree.root = tree.remove(tree.root, s, 0)
+		}
+	}
+	tree.lock.Unlock()
+}
+
+// whether all the elements are in the set, O(len(s)) on average for each element
+func (tree *TernarySearchTree) Contains(elements ...interface{}) bool {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	for _, e := range elements {
+		s, ok := e.(string)
+		if !ok {
+			return false
+		}
+		if s == "" && !tree.hasEmpty {
+			return false
+		}
+		if node := tree.find(s); s != "" && (node == nil || !node.end) {
+			return false
+		}
+	}
+	return true
+}
+
+func (tree *TernarySearchTree) Clear() {
+	tree.lock.Lock()
+	tree.root = nil
+	tree.hasEmpty, tree.emptyWeight = false, 0
+	tree.size = 0
+	tree.lock.Unlock()
+}
+
+func (tree *TernarySearchTree) Len() int {
+	tree.lock.Lock()
+	len := tree.size
+	tree.lock.Unlock()
+	return len
+}
+
+func (tree *TernarySearchTree) Empty() bool {
+	return tree.Len() == 0
+}
+
+func (tree *TernarySearchTree) Same(other sets.Set) bool {
+	if other == nil || tree.Len() != other.Len() {
+		return false
+	}
+
+	return other.Contains(tree.Elements()...)
+}
+
+// elements in order
+func (tree *TernarySearchTree) Elements() []interface{} {
+	elements := make([]interface{}, 0, tree.Len())
+	for s := range tree.All() {
+		elements = append(elements, s)
+	}
+	return elements
+}
+
+// Returns the strings starting with prefix in order, at most limit of them unless limit <= 0.
+func (tree *TernarySearchTree) PrefixSearch(prefix string, limit int) []string {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	result := []string{}
+	collect := func(s string) bool {
+		result = append(result, s)
+		return limit <= 0 || len(result) < limit
+	}
+
+	if prefix == "" {
+		if !tree.hasEmpty || collect("") {
+			tree.walk(tree.root, []byte{}, collect)
+		}
+		return result
+	}
+	node := tree.find(prefix)
+	if node == nil {
+		return result
+	}
+	if !node.end || collect(prefix) {
+		tree.walk(node.middle, []byte(prefix), collect)
+	}
+	return result
+}
+
+// Returns the strings within maxEditDistance of s in order, the distance being the
+// Levenshtein distance: the number of bytes to insert, delete or substitute.
+func (tree *TernarySearchTree) NearSearch(s string, maxEditDistance int) []string {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	result := []string{}
+	// row[j] is the distance between the walked prefix and s[:j]
+	row := make([]int, len(s)+1)
+	for j := range row {
+		row[j] = j
+	}
+	if tree.hasEmpty && len(s) <= maxEditDistance {
+		result = append(result, "")
+	}
+	tree.near(tree.root, []byte{}, s, row, maxEditDistance, &result)
+	return result
+}
+
+// Returns the heaviest strings starting with prefix, at most limit of them unless limit <= 0.
+// The strings of the same weight come in order.
+func (tree *TernarySearchTree) Autocomplete(prefix string, limit int) []string {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	result := []string{}
+	// best first search: a candidate is either a string, or a subtree bounded by its maxWeight
+	candidates := trees.NewBinaryHeap(compareCandidates)
+	push := func(node *tstNode, prefix string) {
+		if node != nil {
+			candidates.Push(&tstCandidate{node: node, key: prefix, weight: node.maxWeight})
+		}
+	}
+
+	if prefix == "" {
+		if tree.hasEmpty {
+			candidates.Push(&tstCandidate{weight: tree.emptyWeight})
+		}
+		push(tree.root, "")
+	} else if node := tree.find(prefix); node != nil {
+		if node.end {
+			candidates.Push(&tstCandidate{key: prefix, weight: node.weight})
+		}
+		push(node.middle, prefix)
+	}
+
+	for limit <= 0 || len(result) < limit {
+		value, ok := candidates.Pop()
+		if !ok {
+			break
+		}
+		candidate := value.(*tstCandidate)
+		node := candidate.node
+		if node == nil {
+			result = append(result, candidate.key)
+			continue
+		}
+		push(node.left, candidate.key)
+		push(node.right, candidate.key)
+		push(node.middle, candidate.key+string(node.char))
+		if node.end {
+			candidates.Push(&tstCandidate{key: candidate.key + string(node.char), weight: node.weight})
+		}
+	}
+	return result
+}
+
+// Returns a stateful iterator over the set in order, positioned before the first element.
+func (tree *TernarySearchTree) Iterator() container.Iterator {
+	return container.SyncIterator(&ternarySearchTreeIterator{tree: tree}, tree.lock)
+}
+
+// Returns a range-over-func sequence of the elements in order.
+// The loop body may use the set: the nodes are never moved, a removed string only leaves its
+// node unmarked or pruned, which the walk passes without yielding anything.
+func (tree *TernarySearchTree) All() iter.Seq[interface{}] {
+	return container.KeySeq(tree.Iterator)
+}
+
+func (tree *TernarySearchTree) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("TernarySearchTree{ ")
+	first := true
+	for s := range tree.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+		buf.WriteString(fmt.Sprintf("%q", s))
+	}
+	buf.WriteString(" }")
+
+	return buf.String()
+}
+
+// adds s, setting its weight if it is new or setWeight
+func (tree *TernarySearchTree) put(s string, weight int, setWeight bool) {
+	if s == "" {
+		if !tree.hasEmpty {
+			tree.hasEmpty = true
+			tree.size++
+			tree.emptyWeight = weight
+		} else if setWeight {
+			tree.emptyWeight = weight
+		}
+		return
+	}
+	tree.root = tree.insert(tree.root, s, 0, weight, setWeight)
+}
+
+func (tree *TernarySearchTree) insert(node *tstNode, s string, i int, weight int, setWeight bool) *tstNode {
+	if node == nil {
+		node = &tstNode{char: s[i]}
+	}
+	switch {
+	case s[i] < node.char:
+		node.left = tree.insert(node.left, s, i, weight, setWeight)
+	case s[i] > node.char:
+		node.right = tree.insert(node.right, s, i, weight, setWeight)
+	case i+1 < len(s):
+		node.middle = tree.insert(node.middle, s, i+1, weight, setWeight)
+	case !node.end:
+		node.end, node.weight = true, weight
+		tree.size++
+	case setWeight:
+		node.weight = weight
+	}
+	node.update()
+	return node
+}
+
+// removes s from the subtree, returns the subtree without the nodes left useless
+func (tree *TernarySearchTree) remove(node *tstNode, s string, i int) *tstNode {
+	if node == nil {
+		return nil
+	}
+	switch {
+	case s[i] < node.char:
+		node.left = tree.remove(node.left, s, i)
+	case s[i] > node.char:
+		node.right = tree.remove(node.right, s, i)
+	case i+1 < len(s):
+		node.middle = tree.remove(node.middle, s, i+1)
+	case node.end:
+		node.end, node.weight = false, 0
+		tree.size--
+	}
+	if !node.end && node.left == nil && node.middle == nil && node.right == nil {
+		return nil
+	}
+	node.update()
+	return node
+}
+
+// the node of the last byte of s, which must not be empty, or nil
+func (tree *TernarySearchTree) find(s string) *tstNode {
+	node, i := tree.root, 0
+	for node != nil {
+		switch {
+		case s[i] < node.char:
+			node = node.left
+		case s[i] > node.char:
+			node = node.right
+		case i+1 < len(s):
+			node, i = node.middle, i+1
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// calls fn on the strings of the subtree in order, prefix being the bytes above it,
+// until fn returns false; returns false if it was stopped
+func (tree *TernarySearchTree) walk(node *tstNode, prefix []byte, fn func(string) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !tree.walk(node.left, prefix, fn) {
+		return false
+	}
+	prefix = append(prefix, node.char)
+	if node.end && !fn(string(prefix)) {
+		return false
+	}
+	if !tree.walk(node.middle, prefix, fn) {
+		return false
+	}
+	return tree.walk(node.right, prefix[:len(prefix)-1], fn)
+}
+
+// collects the strings of the subtree close enough to s, row being the distances
+// between prefix and the prefixes of s
+func (tree *TernarySearchTree) near(node *tstNode, prefix []byte, s string, row []int, maxDistance int, result *[]string) {
+	if node == nil {
+		return
+	}
+	tree.near(node.left, prefix, s, row, maxDistance, result)
+
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+	closest := next[0]
+	for j := 1; j < len(row); j++ {
+		substitution := row[j-1]
+		if s[j-1] != node.char {
+			substitution++
+		}
+		next[j] = min(substitution, row[j]+1, next[j-1]+1)
+		closest = min(closest, next[j])
+	}
+	// the distance only grows along the middle, stop when no prefix of s is close enough
+	if closest <= maxDistance {
+		prefix = append(prefix, node.char)
+		if node.end && next[len(s)] <= maxDistance {
+			*result = append(*result, string(prefix))
+		}
+		tree.near(node.middle, prefix, s, next, maxDistance, result)
+		prefix = prefix[:len(prefix)-1]
+	}
+
+	tree.near(node.right, prefix, s, row, maxDistance, result)
+}
+
+type tstCandidate struct {
+	node   *tstNode // nil for a string
+	key    string   // the string, or the bytes above the subtree
+	weight int
+}
+
+// the heaviest first, then in key order: a subtree comes before the strings it holds,
+// which are all greater than its key
+func compareCandidates(a, b interface{}) int8 {
+	c1, c2 := a.(*tstCandidate), b.(*tstCandidate)
+	switch {
+	case c1.weight > c2.weight:
+		return -1
+	case c1.weight < c2.weight:
+		return 1
+	}
+	return container.StringCompareFunction(c1.key, c2.key)
+}
@@ -0,0 +1,222 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package stringsets
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/sets"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTernarySearchTree(t *testing.T) {
+
+	set := NewTernarySearchTree()
+	set.Add("she", "sells", "sea", "shells", "by", "the", "sea", "shore", 1)
+	if actualValue, expectedValue := set.Len(), 7; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.Elements()), "[by sea sells she shells shore the]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !set.Contains("she", "shore") || set.Contains("sh") || set.Contains("") || set.Contains(1) {
+		t.Errorf("Contains error, got %v", set)
+	}
+
+	set.Add("")
+	if actualValue, expectedValue := set.String(), `TernarySearchTree{ "" "by" "sea" "sells" "she" "shells" "shore" "the" }`; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	other := sets.NewHashSet()
+	other.Add("", "by", "sea", "sells", "she", "shells", "shore", "the")
+	if !set.Same(other) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+
+	set.Remove("she", "shells", "", "shell")
+	if actualValue, expectedValue := fmt.Sprint(set.Elements()), "[by sea sells shore the]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	set.Clear()
+	if !set.Empty() || set.root != nil {
+		t.Errorf("Got %v expected %v", set, "empty set")
+	}
+
+}
+
+// the loop body adds and removes the binary strings of odd numbers, which share their nodes
+// with the ones of even numbers, these must all be seen once and in order
+func TestTernarySearchTreeWriteDuringRange(t *testing.T) {
+	set := NewTernarySearchTree()
+	for n := 0; n < 200; n++ {
+		set.Add(fmt.Sprintf("%b", n))
+	}
+
+	random := rand.New(rand.NewSource(1))
+	var evens []string
+	previous := ""
+	for e := range set.All() {
+		if s := e.(string); s <= previous {
+			t.Errorf("Got %v after %v", s, previous)
+		}
+		if previous = e.(string); previous[len(previous)-1] == '0' {
+			evens = append(evens, previous)
+		}
+		for i := 0; i < 3; i++ {
+			if odd := fmt.Sprintf("%b", 2*random.Intn(100)+1); random.Intn(2) == 0 {
+				set.Add(odd)
+			} else {
+				set.Remove(odd)
+			}
+		}
+	}
+	if len(evens) != 100 {
+		t.Errorf("Got %v expected %v", len(evens), 100)
+	}
+}
+
+func TestTernarySearchTreeSearch(t *testing.T) {
+
+	set := NewTernarySearchTree()
+	set.Add("car", "card", "care", "careful", "cart", "cat", "dog", "do", "cars")
+
+	if actualValue, expectedValue := fmt.Sprint(set.PrefixSearch("car", 0)), "[car card care careful cars cart]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.PrefixSearch("car", 3)), "[car card care]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.PrefixSearch("", 2)), "[car card]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.PrefixSearch("cow", 0)), "[]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue, expectedValue := fmt.Sprint(set.NearSearch("cart", 1)), "[car card care cars cart cat]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.NearSearch("dgo", 2)), "[do dog]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.NearSearch("cart", 0)), "[cart]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	set.AddWeighted("careful", 10)
+	set.AddWeighted("cat", 5)
+	set.AddWeighted("card", 5)
+	set.Add("cat")
+	if weight, ok := set.Weight("cat"); weight != 5 || !ok {
+		t.Errorf("Got %v expected %v", weight, 5)
+	}
+	if actualValue, expectedValue := fmt.Sprint(set.Autocomplete("ca", 4)), "[careful card cat car]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	set.Remove("careful")
+	if actualValue, expectedValue := fmt.Sprint(set.Autocomplete("car", 0)), "[card car care cars cart]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, ok := set.Weight("careful"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+}
+
+func TestTernarySearchTreeRandom(t *testing.T) {
+
+	set := NewTernarySearchTree()
+	reference := map[string]int{}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 3000; i++ {
+		b := make([]byte, random.Intn(5))
+		for j := range b {
+			b[j] = "abcd"[random.Intn(4)]
+		}
+		if s := string(b); random.Intn(3) == 0 {
+			set.Remove(s)
+			delete(reference, s)
+		} else {
+			weight := random.Intn(10)
+			set.AddWeighted(s, weight)
+			reference[s] = weight
+		}
+	}
+
+	words := []string{}
+	for s := range reference {
+		words = append(words, s)
+	}
+	slices.SortFunc(words, func(s1, s2 string) int {
+		return int(container.StringCompareFunction(s1, s2))
+	})
+	if actualValue, expectedValue := fmt.Sprint(set.Elements()), fmt.Sprint(words); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the heaviest first, each weight in order
+	slices.SortStableFunc(words, func(s1, s2 string) int {
+		return reference[s2] - reference[s1]
+	})
+	if actualValue, expectedValue := fmt.Sprint(set.Autocomplete("", 20)), fmt.Sprint(words[:20]); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	near := []string{}
+	for s := range reference {
+		if levenshtein(s, "abca") <= 2 {
+			near = append(near, s)
+		}
+	}
+	slices.Sort(near)
+	if actualValue, expectedValue := fmt.Sprint(set.NearSearch("abca", 2)), fmt.Sprint(near); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+}
+
+func levenshtein(s1, s2 string) int {
+	row := make([]int, len(s2)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s1); i++ {
+		previous := row[0]
+		row[0] = i
+		for j := 1; j <= len(s2); j++ {
+			substitution := previous
+			if s1[i-1] != s2[j-1] {
+				substitution++
+			}
+			previous = row[j]
+			row[j] = min(substitution, row[j]+1, row[j-1]+1)
+		}
+	}
+	return row[len(s2)]
+}
+
+func BenchmarkTernarySearchTree(b *testing.B) {
+	words := make([]interface{}, 1000)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	for i := 0; i < b.N; i++ {
+		set := NewTernarySearchTree()
+		set.Add(words...)
+		set.PrefixSearch("word1", 10)
+		set.Autocomplete("word5", 10)
+	}
+}
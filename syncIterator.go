@@ -0,0 +1,83 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package container
+
+import (
+	"sync"
+)
+
+// SyncIterator wraps the iterator of a container guarded by locker, so that every
+// step is taken with the lock held. The lock is never held between two calls.
+func SyncIterator(it Iterator, locker sync.Locker) Iterator {
+	return &syncIterator{it: it, locker: locker}
+}
+
+// SyncReverseIterator is SyncIterator for reverse iterators.
+func SyncReverseIterator(it ReverseIterator, locker sync.Locker) ReverseIterator {
+	return &syncReverseIterator{syncIterator{it: it, locker: locker}, it}
+}
+
+type syncIterator struct {
+	it     Iterator
+	locker sync.Locker
+	key    interface{}
+	value  interface{}
+}
+
+func (it *syncIterator) Next() bool {
+	return it.step(it.it.Next)
+}
+
+// moves with the lock held, and keeps key and value so they are read under the lock too
+func (it *syncIterator) step(move func() bool) bool {
+	it.locker.Lock()
+	defer it.locker.Unlock()
+	if !move() {
+		it.key, it.value = nil, nil
+		return false
+	}
+	it.key, it.value = it.it.Key(), it.it.Value()
+	return true
+}
+
+func (it *syncIterator) Value() interface{} {
+	return it.value
+}
+
+func (it *syncIterator) Key() interface{} {
+	return it.key
+}
+
+func (it *syncIterator) Reset() {
+	it.locker.Lock()
+	it.it.Reset()
+	it.locker.Unlock()
+	it.key, it.value = nil, nil
+}
+
+type syncReverseIterator struct {
+	syncIterator
+	rit ReverseIterator
+}
+
+func (it *syncReverseIterator) Prev() bool {
+	return it.step(it.rit.Prev)
+}
+
+func (it *syncReverseIterator) End() {
+	it.locker.Lock()
+	it.rit.End()
+	it.locker.Unlock()
+	it.key, it.value = nil, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package container
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// sliceIterator checks that it is only moved with the lock held
+type sliceIterator struct {
+	t        *testing.T
+	lock     *sync.Mutex
+	elements []interface{}
+	index    int
+}
+
+func (it *sliceIterator) checkLocked() {
+	if it.lock.TryLock() {
+		it.lock.Unlock()
+		it.t.Errorf("Got %v expected %v", "unlocked", "locked")
+	}
+}
+
+func (it *sliceIterator) Next() bool {
+	it.checkLocked()
+	if it.index >= len(it.elements) {
+		return false
+	}
+	it.index++
+	return it.index <= len(it.elements)
+}
+
+func (it *sliceIterator) Prev() bool {
+	it.checkLocked()
+	if it.index <= 1 {
+		it.index = 0
+		return false
+	}
+	it.index--
+	return true
+}
+
+func (it *sliceIterator) Value() interface{} {
+	it.checkLocked()
+	return it.elements[it.index-1]
+}
+
+func (it *sliceIterator) Key() interface{} {
+	it.checkLocked()
+	return it.index - 1
+}
+
+func (it *sliceIterator) Reset() {
+	it.checkLocked()
+	it.index = 0
+}
+
+func (it *sliceIterator) End() {
+	it.checkLocked()
+	it.index = len(it.elements) + 1
+}
+
+func TestSyncIterator(t *testing.T) {
+	lock := &sync.Mutex{}
+	it := SyncReverseIterator(&sliceIterator{t: t, lock: lock, elements: []interface{}{"a", "b", "c"}}, lock)
+
+	var values []interface{}
+	for it.Next() {
+		// the lock is released between two steps, and the key and value were read under it
+		if !lock.TryLock() {
+			t.Fatalf("Got %v expected %v", "locked", "unlocked")
+		}
+		lock.Unlock()
+		values = append(values, it.Key(), it.Value())
+	}
+	if actualValue, expectedValue := values, []interface{}{0, "a", 1, "b", 2, "c"}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if it.Key() != nil || it.Value() != nil {
+		t.Errorf("Got %v expected %v", it.Value(), nil)
+	}
+
+	it.End()
+	if !it.Prev() || it.Value() != "c" || !it.Prev() || it.Value() != "b" {
+		t.Errorf("Got %v expected %v", it.Value(), "b")
+	}
+	it.Reset()
+	if !it.Next() || it.Value() != "a" {
+		t.Errorf("Got %v expected %v", it.Value(), "a")
+	}
+	if !lock.TryLock() {
+		t.Fatalf("Got %v expected %v", "locked", "unlocked")
+	}
+	lock.Unlock()
+
+	// breaking out of a loop over a locked sequence leaves the lock free
+	newIterator := func() Iterator {
+		return SyncIterator(&sliceIterator{t: t, lock: lock, elements: []interface{}{1, 2}}, lock)
+	}
+	for range Seq2(newIterator) {
+		break
+	}
+	if !lock.TryLock() {
+		t.Errorf("Got %v expected %v", "locked", "unlocked")
+	}
+}
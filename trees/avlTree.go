@@ -0,0 +1,161 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+type avlNode struct {
+	key    interface{}
+	value  interface{}
+	left   *avlNode
+	right  *avlNode
+	height int // of the subtree, 1 for a leaf
+}
+
+func (node *avlNode) entry() (interface{}, interface{}) {
+	return node.key, node.value
+}
+
+func (node *avlNode) children() (*avlNode, *avlNode) {
+	return node.left, node.right
+}
+
+func avlHeight(node *avlNode) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+// AVLTree is a search tree whose subtrees differ in height by at most one.
+// It is more strictly balanced than RBTree, so lookups are a bit faster and updates a bit slower.
+type AVLTree struct {
+	searchTree[*avlNode]
+}
+
+func NewAVLTree(comparator container.CompareFunction) *AVLTree {
+	return &AVLTree{searchTree[*avlNode]{comparator: comparator}}
+}
+
+// Inserts node into the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *AVLTree) Put(key interface{}, value interface{}) {
+	tree.root = tree.put(tree.root, key, value)
+}
+
+// Remove the node from the tree by key.
+func (tree *AVLTree) Remove(key interface{}) {
+	tree.root = tree.remove(tree.root, key)
+}
+
+func (tree *AVLTree) String() string {
+	return treeString("AVLTree", tree)
+}
+
+func (tree *AVLTree) put(node *avlNode, key interface{}, value interface{}) *avlNode {
+	if node == nil {
+		tree.size++
+		return &avlNode{key: key, value: value, height: 1}
+	}
+	compare := tree.comparator(key, node.key)
+	switch {
+	case compare == 0:
+		node.value = value
+		return node
+	case compare < 0:
+		node.left = tree.put(node.left, key, value)
+	case compare > 0:
+		node.right = tree.put(node.right, key, value)
+	}
+	return avlBalance(node)
+}
+
+func (tree *AVLTree) remove(node *avlNode, key interface{}) *avlNode {
+	if node == nil {
+		return nil
+	}
+	compare := tree.comparator(key, node.key)
+	switch {
+	case compare < 0:
+		node.left = tree.remove(node.left, key)
+	case compare > 0:
+		node.right = tree.remove(node.right, key)
+	default:
+		tree.size--
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		// the successor takes the place of the node
+		var successor *avlNode
+		node.right, successor = avlRemoveMin(node.right)
+		successor.left, successor.right = node.left, node.right
+		node = successor
+	}
+	return avlBalance(node)
+}
+
+// removes the smallest node of the subtree, returns the new subtree and the removed node
+func avlRemoveMin(node *avlNode) (*avlNode, *avlNode) {
+	if node.left == nil {
+		return node.right, node
+	}
+	var min *avlNode
+	node.left, min = avlRemoveMin(node.left)
+	return avlBalance(node), min
+}
+
+// restores the height and the balance of the node, whose subtrees are balanced, returns the new subtree root
+func avlBalance(node *avlNode) *avlNode {
+	avlUpdateHeight(node)
+	switch balance := avlHeight(node.left) - avlHeight(node.right); {
+	case balance > 1:
+		if avlHeight(node.left.left) < avlHeight(node.left.right) {
+			node.left = avlRotateLeft(node.left)
+		}
+		return avlRotateRight(node)
+	case balance < -1:
+		if avlHeight(node.right.right) < avlHeight(node.right.left) {
+			node.right = avlRotateRight(node.right)
+		}
+		return avlRotateLeft(node)
+	}
+	return node
+}
+
+func avlUpdateHeight(node *avlNode) {
+	node.height = max(avlHeight(node.left), avlHeight(node.right)) + 1
+}
+
+func avlRotateLeft(node *avlNode) *avlNode {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	avlUpdateHeight(node)
+	avlUpdateHeight(right)
+	return right
+}
+
+func avlRotateRight(node *avlNode) *avlNode {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	avlUpdateHeight(node)
+	avlUpdateHeight(left)
+	return left
+}
@@ -0,0 +1,437 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+// inner nodes only hold separator keys and children, the entries are all in the leaves,
+// which are linked in key order
+type bPlusTreeNode struct {
+	keys     []interface{}
+	values   []interface{}    // leaves only
+	children []*bPlusTreeNode // inner nodes only, one more than keys
+	next     *bPlusTreeNode   // leaves only
+}
+
+func (node *bPlusTreeNode) leaf() bool {
+	return len(node.children) == 0
+}
+
+// BPlusTree keeps between degree-1 and 2*degree-1 keys per node (the root may have less).
+// Child i of an inner node holds the keys in [keys[i-1], keys[i]), and since the leaves
+// are linked a range scan walks them without going back up the tree.
+type BPlusTree struct {
+	root       *bPlusTreeNode
+	size       int
+	degree     int
+	comparator container.CompareFunction
+}
+
+// Instantiates a B+tree of the given minimum degree, at least 2.
+func NewBPlusTree(degree int, comparator container.CompareFunction) *BPlusTree {
+	if degree < 2 {
+		degree = 2
+	}
+	return &BPlusTree{
+		degree:     degree,
+		comparator: comparator,
+	}
+}
+
+// Inserts the key, or replaces the value of an existing key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *BPlusTree) Put(key interface{}, value interface{}) {
+	if tree.root == nil {
+		tree.root = &bPlusTreeNode{}
+	}
+	if separator, right := tree.insert(tree.root, key, value); right != nil {
+		tree.root = &bPlusTreeNode{
+			keys:     []interface{}{separator},
+			children: []*bPlusTreeNode{tree.root, right},
+		}
+	}
+}
+
+// Searches the key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+func (tree *BPlusTree) Get(key interface{}) (value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, false
+	}
+	leaf := tree.leafOf(key)
+	if index, found := tree.search(leaf, key); found {
+		return leaf.values[index], true
+	}
+	return nil, false
+}
+
+// Remove the key from the tree.
+func (tree *BPlusTree) Remove(key interface{}) {
+	if tree.root == nil || !tree.remove(tree.root, key) {
+		return
+	}
+	if tree.size == 0 {
+		tree.root = nil
+	} else if len(tree.root.keys) == 0 {
+		tree.root = tree.root.children[0]
+	}
+}
+
+// Returns all keys in-order
+func (tree *BPlusTree) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.size)
+	for leaf := tree.firstLeaf(); leaf != nil; leaf = leaf.next {
+		keys = append(keys, leaf.keys...)
+	}
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (tree *BPlusTree) Elements() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	for leaf := tree.firstLeaf(); leaf != nil; leaf = leaf.next {
+		values = append(values, leaf.values...)
+	}
+	return values
+}
+
+func (tree *BPlusTree) Min() (key interface{}, value interface{}, found bool) {
+	leaf := tree.firstLeaf()
+	if leaf == nil {
+		return nil, nil, false
+	}
+	return leaf.keys[0], leaf.values[0], true
+}
+
+func (tree *BPlusTree) Max() (key interface{}, value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, nil, false
+	}
+	leaf := tree.lastLeaf(tree.root)
+	return leaf.keys[len(leaf.keys)-1], leaf.values[len(leaf.values)-1], true
+}
+
+func (tree *BPlusTree) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, nil, false
+	}
+	return tree.floor(tree.root, key)
+}
+
+func (tree *BPlusTree) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	if leaf, index := tree.seek(key, true); leaf != nil {
+		return leaf.keys[index], leaf.values[index], true
+	}
+	return nil, nil, false
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order, with keys between from and to.
+// The inclusive flags tell whether from and to themselves belong to the range, a nil bound means unbounded.
+// After finding the first key the scan only follows the leaf links.
+func (tree *BPlusTree) Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		leaf, index := tree.firstLeaf(), 0
+		if from != nil {
+			leaf, index = tree.seek(from, fromInclusive)
+		}
+		for ; leaf != nil; leaf, index = leaf.next, 0 {
+			for ; index < len(leaf.keys); index++ {
+				if to != nil {
+					compare := tree.comparator(leaf.keys[index], to)
+					if compare > 0 || compare == 0 && !toInclusive {
+						return
+					}
+				}
+				if !yield(leaf.keys[index], leaf.values[index]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns true if tree does not contain any keys
+func (tree *BPlusTree) Empty() bool {
+	return tree.size == 0
+}
+
+// Returns number of keys in the tree.
+func (tree *BPlusTree) Len() int {
+	return tree.size
+}
+
+// Removes all keys from the tree.
+func (tree *BPlusTree) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+// check if the values are in the tree
+func (tree *BPlusTree) Contains(values ...interface{}) bool {
+	return treeContains(tree, values)
+}
+
+// Returns a stateful iterator over the tree in key order, positioned before the smallest key.
+func (tree *BPlusTree) Iterator() container.Iterator {
+	return &bPlusTreeIterator{tree: tree, index: -1}
+}
+
+func (tree *BPlusTree) String() string {
+	return treeString("BPlusTree", tree)
+}
+
+// binary search for the first key which is >= key, or > key if not inclusive
+func (tree *BPlusTree) searchFrom(node *bPlusTreeNode, key interface{}, inclusive bool) int {
+	low, high := 0, len(node.keys)
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		compare := tree.comparator(node.keys[middle], key)
+		if compare < 0 || compare == 0 && !inclusive {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low
+}
+
+func (tree *BPlusTree) search(node *bPlusTreeNode, key interface{}) (index int, found bool) {
+	index = tree.searchFrom(node, key, true)
+	return index, index < len(node.keys) && tree.comparator(node.keys[index], key) == 0
+}
+
+// index of the child of an inner node whose keys range covers key
+func (tree *BPlusTree) childIndex(node *bPlusTreeNode, key interface{}) int {
+	return tree.searchFrom(node, key, false)
+}
+
+// the leaf where key is, or would be inserted
+func (tree *BPlusTree) leafOf(key interface{}) *bPlusTreeNode {
+	node := tree.root
+	for !node.leaf() {
+		node = node.children[tree.childIndex(node, key)]
+	}
+	return node
+}
+
+func (tree *BPlusTree) firstLeaf() *bPlusTreeNode {
+	node := tree.root
+	for node != nil && !node.leaf() {
+		node = node.children[0]
+	}
+	return node
+}
+
+func (tree *BPlusTree) lastLeaf(node *bPlusTreeNode) *bPlusTreeNode {
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+	return node
+}
+
+// position of the first key >= key, or > key if not inclusive; nil leaf if there is none
+func (tree *BPlusTree) seek(key interface{}, inclusive bool) (*bPlusTreeNode, int) {
+	if tree.root == nil {
+		return nil, 0
+	}
+	leaf := tree.leafOf(key)
+	index := tree.searchFrom(leaf, key, inclusive)
+	if index == len(leaf.keys) {
+		// the following keys start in the next leaf
+		leaf, index = leaf.next, 0
+	}
+	return leaf, index
+}
+
+// there are no links back to the previous leaf, so when the covering leaf has no key <= key
+// the floor is the largest key of the subtree left of it
+func (tree *BPlusTree) floor(node *bPlusTreeNode, key interface{}) (interface{}, interface{}, bool) {
+	if node.leaf() {
+		index := tree.searchFrom(node, key, false)
+		if index == 0 {
+			return nil, nil, false
+		}
+		return node.keys[index-1], node.values[index-1], true
+	}
+	index := tree.childIndex(node, key)
+	if floorKey, value, found := tree.floor(node.children[index], key); found || index == 0 {
+		return floorKey, value, found
+	}
+	leaf := tree.lastLeaf(node.children[index-1])
+	return leaf.keys[len(leaf.keys)-1], leaf.values[len(leaf.values)-1], true
+}
+
+// inserts into the subtree of node, if the node overflows it is split and
+// the new right node is returned with the separator key to add to the parent
+func (tree *BPlusTree) insert(node *bPlusTreeNode, key interface{}, value interface{}) (interface{}, *bPlusTreeNode) {
+	if node.leaf() {
+		index, found := tree.search(node, key)
+		if found {
+			node.values[index] = value
+			return nil, nil
+		}
+		node.keys = insertAt(node.keys, index, key)
+		node.values = insertAt(node.values, index, value)
+		tree.size++
+		if len(node.keys) < 2*tree.degree {
+			return nil, nil
+		}
+		middle := len(node.keys) / 2
+		right := &bPlusTreeNode{
+			keys:   append([]interface{}{}, node.keys[middle:]...),
+			values: append([]interface{}{}, node.values[middle:]...),
+			next:   node.next,
+		}
+		clear(node.keys[middle:])
+		clear(node.values[middle:])
+		node.keys, node.values, node.next = node.keys[:middle], node.values[:middle], right
+		return right.keys[0], right
+	}
+
+	index := tree.childIndex(node, key)
+	separator, child := tree.insert(node.children[index], key, value)
+	if child == nil {
+		return nil, nil
+	}
+	node.keys = insertAt(node.keys, index, separator)
+	node.children = insertAt(node.children, index+1, child)
+	if len(node.keys) < 2*tree.degree {
+		return nil, nil
+	}
+	// the middle key moves up instead of being copied like a leaf's
+	middle := len(node.keys) / 2
+	separator = node.keys[middle]
+	right := &bPlusTreeNode{
+		keys:     append([]interface{}{}, node.keys[middle+1:]...),
+		children: append([]*bPlusTreeNode{}, node.children[middle+1:]...),
+	}
+	clear(node.keys[middle:])
+	clear(node.children[middle+1:])
+	node.keys, node.children = node.keys[:middle], node.children[:middle+1]
+	return separator, right
+}
+
+// removes key from the subtree of node, rebalancing the children left with too few keys.
+// The separators are left as they are, they still bound the keys of their children.
+func (tree *BPlusTree) remove(node *bPlusTreeNode, key interface{}) bool {
+	if node.leaf() {
+		index, found := tree.search(node, key)
+		if !found {
+			return false
+		}
+		node.keys = removeAt(node.keys, index)
+		node.values = removeAt(node.values, index)
+		tree.size--
+		return true
+	}
+
+	index := tree.childIndex(node, key)
+	if !tree.remove(node.children[index], key) {
+		return false
+	}
+	if len(node.children[index].keys) < tree.degree-1 {
+		tree.rebalance(node, index)
+	}
+	return true
+}
+
+// refills the child at index by borrowing from a sibling or merging with one
+func (tree *BPlusTree) rebalance(node *bPlusTreeNode, index int) {
+	child := node.children[index]
+	switch {
+	case index > 0 && len(node.children[index-1].keys) >= tree.degree:
+		left := node.children[index-1]
+		last := len(left.keys) - 1
+		if child.leaf() {
+			child.keys = insertAt(child.keys, 0, left.keys[last])
+			child.values = insertAt(child.values, 0, left.values[last])
+			left.values = removeAt(left.values, last)
+			node.keys[index-1] = child.keys[0]
+		} else {
+			child.keys = insertAt(child.keys, 0, node.keys[index-1])
+			child.children = insertAt(child.children, 0, left.children[last+1])
+			left.children = removeAt(left.children, last+1)
+			node.keys[index-1] = left.keys[last]
+		}
+		left.keys = removeAt(left.keys, last)
+	case index < len(node.keys) && len(node.children[index+1].keys) >= tree.degree:
+		right := node.children[index+1]
+		if child.leaf() {
+			child.keys = append(child.keys, right.keys[0])
+			child.values = append(child.values, right.values[0])
+			right.values = removeAt(right.values, 0)
+			right.keys = removeAt(right.keys, 0)
+			node.keys[index] = right.keys[0]
+		} else {
+			child.keys = append(child.keys, node.keys[index])
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+			node.keys[index] = right.keys[0]
+			right.keys = removeAt(right.keys, 0)
+		}
+	case index < len(node.keys):
+		tree.merge(node, index)
+	default:
+		tree.merge(node, index-1)
+	}
+}
+
+// merges the child at index+1 into the child at index
+func (tree *BPlusTree) merge(node *bPlusTreeNode, index int) {
+	left, right := node.children[index], node.children[index+1]
+	if left.leaf() {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(append(left.keys, node.keys[index]), right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	node.keys = removeAt(node.keys, index)
+	node.children = removeAt(node.children, index+1)
+}
+
+// bPlusTreeIterator follows the leaf links.
+type bPlusTreeIterator struct {
+	tree  *BPlusTree
+	leaf  *bPlusTreeNode
+	index int // -1 before the first Next
+}
+
+func (it *bPlusTreeIterator) Next() bool {
+	if it.index < 0 {
+		it.leaf, it.index = it.tree.firstLeaf(), 0
+	} else if it.leaf != nil {
+		it.index++
+	}
+	if it.leaf != nil && it.index >= len(it.leaf.keys) {
+		it.leaf, it.index = it.leaf.next, 0
+	}
+	return it.leaf != nil
+}
+
+func (it *bPlusTreeIterator) Value() interface{} {
+	return it.leaf.values[it.index]
+}
+
+func (it *bPlusTreeIterator) Key() interface{} {
+	return it.leaf.keys[it.index]
+}
+
+func (it *bPlusTreeIterator) Reset() {
+	it.leaf, it.index = nil, -1
+}
@@ -0,0 +1,122 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"testing"
+)
+
+func TestBPlusTreeStructure(t *testing.T) {
+
+	for _, degree := range []int{2, 3, 5} {
+		tree := NewBPlusTree(degree, container.IntCompareFunctionASC)
+		random := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 5000; i++ {
+			if key := random.Intn(1000); random.Intn(3) == 0 {
+				tree.Remove(key)
+			} else {
+				tree.Put(key, key)
+			}
+			if i%500 == 0 {
+				checkBPlusTree(t, tree)
+			}
+		}
+		checkBPlusTree(t, tree)
+
+		for _, key := range tree.Keys() {
+			tree.Remove(key)
+		}
+		if tree.root != nil || !tree.Empty() {
+			t.Errorf("Got %v expected %v", tree, "empty tree")
+		}
+	}
+
+}
+
+// checks the separators, the fill of the nodes, the leaf depth and the leaf links
+func checkBPlusTree(t *testing.T, tree *BPlusTree) {
+	if tree.root == nil {
+		if tree.Len() != 0 {
+			t.Fatalf("Got %v expected %v", tree.Len(), 0)
+		}
+		return
+	}
+	leafDepth := -1
+	leaves := []*bPlusTreeNode{}
+	var check func(node *bPlusTreeNode, depth int, low, high interface{})
+	check = func(node *bPlusTreeNode, depth int, low, high interface{}) {
+		if node != tree.root && (len(node.keys) < tree.degree-1 || len(node.keys) > 2*tree.degree-1) {
+			t.Fatalf("Got %v keys in a node of degree %v", len(node.keys), tree.degree)
+		}
+		for i, key := range node.keys {
+			if low != nil && key.(int) < low.(int) || high != nil && key.(int) >= high.(int) {
+				t.Fatalf("Got key %v out of [%v, %v)", key, low, high)
+			}
+			if i > 0 && key.(int) <= node.keys[i-1].(int) {
+				t.Fatalf("Got key %v after %v", key, node.keys[i-1])
+			}
+		}
+		if node.leaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				t.Fatalf("Got leaf depth %v expected %v", depth, leafDepth)
+			}
+			if len(node.values) != len(node.keys) {
+				t.Fatalf("Got %v values for %v keys", len(node.values), len(node.keys))
+			}
+			leaves = append(leaves, node)
+			return
+		}
+		if len(node.children) != len(node.keys)+1 {
+			t.Fatalf("Got %v children for %v keys", len(node.children), len(node.keys))
+		}
+		for i, child := range node.children {
+			childLow, childHigh := low, high
+			if i > 0 {
+				childLow = node.keys[i-1]
+			}
+			if i < len(node.keys) {
+				childHigh = node.keys[i]
+			}
+			check(child, depth+1, childLow, childHigh)
+		}
+	}
+	check(tree.root, 0, nil, nil)
+
+	size := 0
+	for i, leaf := range leaves {
+		size += len(leaf.keys)
+		if i+1 < len(leaves) && leaf.next != leaves[i+1] || i+1 == len(leaves) && leaf.next != nil {
+			t.Fatalf("Got broken leaf link after %v", leaf.keys)
+		}
+	}
+	if size != tree.Len() {
+		t.Fatalf("Got %v expected %v", size, tree.Len())
+	}
+}
+
+func BenchmarkBPlusTree(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := NewBPlusTree(16, container.IntCompareFunctionASC)
+		for n := 0; n < 1000; n++ {
+			tree.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			tree.Remove(n)
+		}
+	}
+}
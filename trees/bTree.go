@@ -0,0 +1,483 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+type bTreeEntry struct {
+	key   interface{}
+	value interface{}
+}
+
+// a node is a leaf when it has no children, an inner node has one child more than entries
+type bTreeNode struct {
+	entries  []bTreeEntry
+	children []*bTreeNode
+}
+
+func (node *bTreeNode) leaf() bool {
+	return len(node.children) == 0
+}
+
+// BTree keeps between degree-1 and 2*degree-1 entries per node (the root may have less),
+// so a node holds many keys in one slice instead of one allocation per key like RBTree,
+// and the tree is shallow. Put, Get and Remove are O(log n).
+type BTree struct {
+	root       *bTreeNode
+	size       int
+	degree     int
+	comparator container.CompareFunction
+}
+
+// Instantiates a B-tree of the given minimum degree, at least 2.
+func NewBTree(degree int, comparator container.CompareFunction) *BTree {
+	if degree < 2 {
+		degree = 2
+	}
+	return &BTree{
+		degree:     degree,
+		comparator: comparator,
+	}
+}
+
+// Inserts the key, or replaces the value of an existing key.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *BTree) Put(key interface{}, value interface{}) {
+	if tree.root == nil {
+		tree.root = &bTreeNode{}
+	}
+	if len(tree.root.entries) == 2*tree.degree-1 {
+		tree.root = &bTreeNode{children: []*bTreeNode{tree.root}}
+		tree.splitChild(tree.root, 0)
+	}
+	tree.insertNonFull(tree.root, bTreeEntry{key, value})
+}
+
+// Searches the key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+func (tree *BTree) Get(key interface{}) (value interface{}, found bool) {
+	for node := tree.root; node != nil; {
+		index, found := tree.search(node, key)
+		if found {
+			return node.entries[index].value, true
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[index]
+	}
+	return nil, false
+}
+
+// Remove the key from the tree.
+func (tree *BTree) Remove(key interface{}) {
+	if tree.root == nil {
+		return
+	}
+	tree.remove(tree.root, key)
+	if len(tree.root.entries) == 0 {
+		if tree.root.leaf() {
+			tree.root = nil
+		} else {
+			tree.root = tree.root.children[0]
+		}
+	}
+}
+
+// Returns all keys in-order
+func (tree *BTree) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.size)
+	for it := tree.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (tree *BTree) Elements() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	for it := tree.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (tree *BTree) Min() (key interface{}, value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, nil, false
+	}
+	node := tree.root
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	return node.entries[0].key, node.entries[0].value, true
+}
+
+func (tree *BTree) Max() (key interface{}, value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, nil, false
+	}
+	entry := tree.maxEntry(tree.root)
+	return entry.key, entry.value, true
+}
+
+func (tree *BTree) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	var floor *bTreeEntry
+	for node := tree.root; node != nil; {
+		index, found := tree.search(node, key)
+		if found {
+			return node.entries[index].key, node.entries[index].value, true
+		}
+		if index > 0 {
+			floor = &node.entries[index-1]
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[index]
+	}
+	if floor == nil {
+		return nil, nil, false
+	}
+	return floor.key, floor.value, true
+}
+
+func (tree *BTree) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	var ceiling *bTreeEntry
+	for node := tree.root; node != nil; {
+		index, found := tree.search(node, key)
+		if found {
+			return node.entries[index].key, node.entries[index].value, true
+		}
+		if index < len(node.entries) {
+			ceiling = &node.entries[index]
+		}
+		if node.leaf() {
+			break
+		}
+		node = node.children[index]
+	}
+	if ceiling == nil {
+		return nil, nil, false
+	}
+	return ceiling.key, ceiling.value, true
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order, with keys between from and to.
+// The inclusive flags tell whether from and to themselves belong to the range, a nil bound means unbounded.
+func (tree *BTree) Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		it := &bTreeIterator{tree: tree, started: true}
+		if from == nil {
+			it.descend(tree.root)
+		} else {
+			it.seek(from, fromInclusive)
+		}
+		for it.settle() {
+			if to != nil {
+				compare := tree.comparator(it.Key(), to)
+				if compare > 0 || compare == 0 && !toInclusive {
+					return
+				}
+			}
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+			it.advance()
+		}
+	}
+}
+
+// Returns true if tree does not contain any keys
+func (tree *BTree) Empty() bool {
+	return tree.size == 0
+}
+
+// Returns number of keys in the tree.
+func (tree *BTree) Len() int {
+	return tree.size
+}
+
+// Removes all keys from the tree.
+func (tree *BTree) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+// check if the values are in the tree
+func (tree *BTree) Contains(values ...interface{}) bool {
+	return treeContains(tree, values)
+}
+
+// Returns a stateful iterator over the tree in key order, positioned before the smallest key.
+func (tree *BTree) Iterator() container.Iterator {
+	return &bTreeIterator{tree: tree}
+}
+
+func (tree *BTree) String() string {
+	return treeString("BTree", tree)
+}
+
+// binary search for the first entry whose key is >= key, found tells if it is equal
+func (tree *BTree) search(node *bTreeNode, key interface{}) (index int, found bool) {
+	low, high := 0, len(node.entries)
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if tree.comparator(node.entries[middle].key, key) < 0 {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	return low, low < len(node.entries) && tree.comparator(node.entries[low].key, key) == 0
+}
+
+// splits the full child at index in two around its median entry, which moves up to the parent
+func (tree *BTree) splitChild(parent *bTreeNode, index int) {
+	child := parent.children[index]
+	middle := tree.degree - 1
+	right := &bTreeNode{entries: append([]bTreeEntry{}, child.entries[middle+1:]...)}
+	if !child.leaf() {
+		right.children = append([]*bTreeNode{}, child.children[middle+1:]...)
+		clear(child.children[middle+1:])
+		child.children = child.children[:middle+1]
+	}
+	median := child.entries[middle]
+	clear(child.entries[middle:])
+	child.entries = child.entries[:middle]
+
+	parent.entries = insertAt(parent.entries, index, median)
+	parent.children = insertAt(parent.children, index+1, right)
+}
+
+// inserts into a node which is not full, splitting the full children on the way down
+func (tree *BTree) insertNonFull(node *bTreeNode, entry bTreeEntry) {
+	for {
+		index, found := tree.search(node, entry.key)
+		if found {
+			node.entries[index].value = entry.value
+			return
+		}
+		if node.leaf() {
+			node.entries = insertAt(node.entries, index, entry)
+			tree.size++
+			return
+		}
+		if len(node.children[index].entries) == 2*tree.degree-1 {
+			tree.splitChild(node, index)
+			// the median moved up to index, the key may be that one or go right of it
+			switch compare := tree.comparator(entry.key, node.entries[index].key); {
+			case compare == 0:
+				node.entries[index].value = entry.value
+				return
+			case compare > 0:
+				index++
+			}
+		}
+		node = node.children[index]
+	}
+}
+
+// removes key from the subtree of node, which has at least degree entries unless it is the root,
+// making sure every child it descends to has at least degree entries as well
+func (tree *BTree) remove(node *bTreeNode, key interface{}) {
+	for {
+		index, found := tree.search(node, key)
+		switch {
+		case found && node.leaf():
+			node.entries = removeAt(node.entries, index)
+			tree.size--
+			return
+		case found && len(node.children[index].entries) >= tree.degree:
+			// the predecessor takes the place of the key
+			predecessor := tree.maxEntry(node.children[index])
+			node.entries[index] = predecessor
+			node, key = node.children[index], predecessor.key
+		case found && len(node.children[index+1].entries) >= tree.degree:
+			successor := tree.minEntry(node.children[index+1])
+			node.entries[index] = successor
+			node, key = node.children[index+1], successor.key
+		case found:
+			tree.merge(node, index)
+			node = node.children[index]
+		case node.leaf():
+			return
+		default:
+			node = node.children[tree.fill(node, index)]
+		}
+	}
+}
+
+func (tree *BTree) minEntry(node *bTreeNode) bTreeEntry {
+	for !node.leaf() {
+		node = node.children[0]
+	}
+	return node.entries[0]
+}
+
+func (tree *BTree) maxEntry(node *bTreeNode) bTreeEntry {
+	for !node.leaf() {
+		node = node.children[len(node.children)-1]
+	}
+	return node.entries[len(node.entries)-1]
+}
+
+// makes sure the child at index has at least degree entries, by borrowing from a sibling
+// or merging with one; returns the index of the child which now covers the keys
+func (tree *BTree) fill(node *bTreeNode, index int) int {
+	child := node.children[index]
+	if len(child.entries) >= tree.degree {
+		return index
+	}
+	switch {
+	case index > 0 && len(node.children[index-1].entries) >= tree.degree:
+		left := node.children[index-1]
+		child.entries = insertAt(child.entries, 0, node.entries[index-1])
+		node.entries[index-1] = left.entries[len(left.entries)-1]
+		left.entries = removeAt(left.entries, len(left.entries)-1)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = removeAt(left.children, len(left.children)-1)
+		}
+	case index < len(node.entries) && len(node.children[index+1].entries) >= tree.degree:
+		right := node.children[index+1]
+		child.entries = append(child.entries, node.entries[index])
+		node.entries[index] = right.entries[0]
+		right.entries = removeAt(right.entries, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	case index < len(node.entries):
+		tree.merge(node, index)
+	default:
+		tree.merge(node, index-1)
+		index--
+	}
+	return index
+}
+
+// merges the child at index+1 and the entry at index into the child at index
+func (tree *BTree) merge(node *bTreeNode, index int) {
+	child, right := node.children[index], node.children[index+1]
+	child.entries = append(append(child.entries, node.entries[index]), right.entries...)
+	child.children = append(child.children, right.children...)
+	node.entries = removeAt(node.entries, index)
+	node.children = removeAt(node.children, index+1)
+}
+
+// inserts value at index, shifting the following ones
+func insertAt[T any](slice []T, index int, value T) []T {
+	var zero T
+	slice = append(slice, zero)
+	copy(slice[index+1:], slice[index:])
+	slice[index] = value
+	return slice
+}
+
+// removes the value at index, shifting the following ones
+func removeAt[T any](slice []T, index int) []T {
+	copy(slice[index:], slice[index+1:])
+	var zero T
+	slice[len(slice)-1] = zero
+	return slice[:len(slice)-1]
+}
+
+type bTreeFrame struct {
+	node  *bTreeNode
+	index int // of the entry to visit next in the node, once the child before it is done
+}
+
+// bTreeIterator walks the tree in order, keeping the path from the root on a stack.
+type bTreeIterator struct {
+	tree    *BTree
+	stack   []bTreeFrame
+	started bool
+}
+
+func (it *bTreeIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		it.descend(it.tree.root)
+	} else if len(it.stack) > 0 {
+		it.advance()
+	}
+	return it.settle()
+}
+
+// pushes the leftmost path of the subtree
+func (it *bTreeIterator) descend(node *bTreeNode) {
+	for node != nil {
+		it.stack = append(it.stack, bTreeFrame{node, 0})
+		if node.leaf() {
+			return
+		}
+		node = node.children[0]
+	}
+}
+
+// pushes the path to the first key >= key, or > key if not inclusive
+func (it *bTreeIterator) seek(key interface{}, inclusive bool) {
+	for node := it.tree.root; node != nil; {
+		index, found := it.tree.search(node, key)
+		if found && !inclusive {
+			index++
+		}
+		it.stack = append(it.stack, bTreeFrame{node, index})
+		if found || node.leaf() {
+			if found && !inclusive && !node.leaf() {
+				it.descend(node.children[index])
+			}
+			return
+		}
+		node = node.children[index]
+	}
+}
+
+// moves past the current entry, into the subtree right of it
+func (it *bTreeIterator) advance() {
+	top := &it.stack[len(it.stack)-1]
+	top.index++
+	if !top.node.leaf() {
+		it.descend(top.node.children[top.index])
+	}
+}
+
+// pops the nodes whose entries are all visited, returns false when none is left
+func (it *bTreeIterator) settle() bool {
+	for len(it.stack) > 0 && it.stack[len(it.stack)-1].index >= len(it.stack[len(it.stack)-1].node.entries) {
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return len(it.stack) > 0
+}
+
+func (it *bTreeIterator) current() *bTreeEntry {
+	top := it.stack[len(it.stack)-1]
+	return &top.node.entries[top.index]
+}
+
+func (it *bTreeIterator) Value() interface{} {
+	return it.current().value
+}
+
+func (it *bTreeIterator) Key() interface{} {
+	return it.current().key
+}
+
+func (it *bTreeIterator) Reset() {
+	it.stack, it.started = it.stack[:0], false
+}
@@ -0,0 +1,110 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"testing"
+)
+
+func TestBTreeStructure(t *testing.T) {
+
+	for _, degree := range []int{2, 3, 5} {
+		tree := NewBTree(degree, container.IntCompareFunctionASC)
+		random := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 5000; i++ {
+			if key := random.Intn(1000); random.Intn(3) == 0 {
+				tree.Remove(key)
+			} else {
+				tree.Put(key, key)
+			}
+			if i%500 == 0 {
+				checkBTree(t, tree)
+			}
+		}
+		checkBTree(t, tree)
+
+		for _, key := range tree.Keys() {
+			tree.Remove(key)
+		}
+		if tree.root != nil || !tree.Empty() {
+			t.Errorf("Got %v expected %v", tree, "empty tree")
+		}
+	}
+
+}
+
+// checks the key order, the fill of the nodes and that all leaves are at the same depth
+func checkBTree(t *testing.T, tree *BTree) {
+	if tree.root == nil {
+		if tree.Len() != 0 {
+			t.Fatalf("Got %v expected %v", tree.Len(), 0)
+		}
+		return
+	}
+	leafDepth := -1
+	var check func(node *bTreeNode, depth int, low, high interface{}) int
+	check = func(node *bTreeNode, depth int, low, high interface{}) int {
+		if node != tree.root && (len(node.entries) < tree.degree-1 || len(node.entries) > 2*tree.degree-1) {
+			t.Fatalf("Got %v entries in a node of degree %v", len(node.entries), tree.degree)
+		}
+		for i, entry := range node.entries {
+			if low != nil && entry.key.(int) <= low.(int) || high != nil && entry.key.(int) >= high.(int) {
+				t.Fatalf("Got key %v out of (%v, %v)", entry.key, low, high)
+			}
+			if i > 0 && entry.key.(int) <= node.entries[i-1].key.(int) {
+				t.Fatalf("Got key %v after %v", entry.key, node.entries[i-1].key)
+			}
+		}
+		if node.leaf() {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if depth != leafDepth {
+				t.Fatalf("Got leaf depth %v expected %v", depth, leafDepth)
+			}
+			return len(node.entries)
+		}
+		if len(node.children) != len(node.entries)+1 {
+			t.Fatalf("Got %v children for %v entries", len(node.children), len(node.entries))
+		}
+		size := len(node.entries)
+		for i, child := range node.children {
+			childLow, childHigh := low, high
+			if i > 0 {
+				childLow = node.entries[i-1].key
+			}
+			if i < len(node.entries) {
+				childHigh = node.entries[i].key
+			}
+			size += check(child, depth+1, childLow, childHigh)
+		}
+		return size
+	}
+	if size := check(tree.root, 0, nil, nil); size != tree.Len() {
+		t.Fatalf("Got %v expected %v", size, tree.Len())
+	}
+}
+
+func BenchmarkBTree(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := NewBTree(16, container.IntCompareFunctionASC)
+		for n := 0; n < 1000; n++ {
+			tree.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			tree.Remove(n)
+		}
+	}
+}
@@ -68,9 +68,95 @@ func NewBinaryHeap(comparator container.CompareFunction) *BinaryHeap {
 	}
 }
 
+// Instantiates a heap holding the given elements, arranged in O(n) rather than by n pushes.
+func NewBinaryHeapFrom(elements []interface{}, comparator container.CompareFunction) *BinaryHeap {
+	heap := NewBinaryHeap(comparator)
+	heap.list.Add(elements...)
+	heap.heapify()
+	return heap
+}
+
 func (heap *BinaryHeap) Push(val interface{}) {
 	heap.list.Add(val)
-	heap.bubbleUp()
+	heap.bubbleUp(heap.list.Len() - 1)
+}
+
+// Pushes values (one or more), re-arranging the whole heap in O(n) when that is cheaper
+// than pushing them one by one.
+func (heap *BinaryHeap) PushAll(values ...interface{}) {
+	size := heap.list.Len()
+	heap.list.Add(values...)
+	if len(values) > size {
+		heap.heapify()
+		return
+	}
+	for index := size; index < heap.list.Len(); index++ {
+		heap.bubbleUp(index)
+	}
+}
+
+// Pushes val and then pops the top element, faster than Push followed by Pop.
+// Returns val itself if it does not go below the top.
+func (heap *BinaryHeap) PushPop(val interface{}) interface{} {
+	top, ok := heap.list.Get(0)
+	if !ok || heap.comparator(val, top) <= 0 {
+		return val
+	}
+	heap.replaceTop(val)
+	return top
+}
+
+// Pops the top element and then pushes val, faster than Pop followed by Push.
+// Second return parameter is false if the heap was empty, val is pushed anyway.
+func (heap *BinaryHeap) Replace(val interface{}) (top interface{}, ok bool) {
+	top, ok = heap.list.Get(0)
+	if !ok {
+		heap.Push(val)
+		return
+	}
+	heap.replaceTop(val)
+	return
+}
+
+// Returns the k top elements in order without changing the heap, in O(k log k).
+// Returns all the elements if there are less than k.
+func (heap *BinaryHeap) TopK(k int) []interface{} {
+	if k > heap.list.Len() {
+		k = heap.list.Len()
+	}
+	if k <= 0 {
+		return []interface{}{}
+	}
+
+	// the candidates are the children of the elements taken so far, kept by index in a heap of their own
+	top := make([]interface{}, 0, k)
+	candidates := NewBinaryHeap(func(i1, i2 interface{}) int8 {
+		e1, _ := heap.list.Get(i1.(int))
+		e2, _ := heap.list.Get(i2.(int))
+		return heap.comparator(e1, e2)
+	})
+	candidates.Push(0)
+	for len(top) < k {
+		index, _ := candidates.Pop()
+		element, _ := heap.list.Get(index.(int))
+		top = append(top, element)
+		for _, child := range []int{index.(int)<<1 + 1, index.(int)<<1 + 2} {
+			if child < heap.list.Len() {
+				candidates.Push(child)
+			}
+		}
+	}
+	return top
+}
+
+// Pops all the elements and returns them in order, leaving the heap empty.
+func (heap *BinaryHeap) DrainSorted() []interface{} {
+	sorted := make([]interface{}, 0, heap.list.Len())
+	for !heap.Empty() {
+		val, _ := heap.Pop()
+		sorted = append(sorted, val)
+	}
+	return sorted
 }
 
 func (heap *BinaryHeap) Pop() (val interface{}, ok bool) {
@@ -82,7 +168,7 @@ func (heap *BinaryHeap) Pop() (val interface{}, ok bool) {
 	heap.list.Swap(0, lastIndex)
 	heap.list.Remove(lastIndex)
 
-	heap.bubbleDown()
+	heap.bubbleDown(0)
 	return
 }
 
@@ -92,10 +178,30 @@ func (heap *BinaryHeap) Peek() (val interface{}, ok bool) {
 	return heap.list.Get(0)
 }
 
+// Moves all the elements of other into the heap, leaving other empty, in O(n + m) at most.
+func (heap *BinaryHeap) Merge(other HeapInterface) {
+	heap.PushAll(drainHeap(heap, other)...)
+}
+
+// puts val on top in place of the top element and moves it down to its place
+func (heap *BinaryHeap) replaceTop(val interface{}) {
+	heap.list.Add(val)
+	lastIndex := heap.list.Len() - 1
+	heap.list.Swap(0, lastIndex)
+	heap.list.Remove(lastIndex)
+	heap.bubbleDown(0)
+}
+
+// arranges the whole list into a heap by bubbling down every parent, from the last one up, in O(n)
+func (heap *BinaryHeap) heapify() {
+	for index := heap.list.Len()>>1 - 1; index >= 0; index-- {
+		heap.bubbleDown(index)
+	}
+}
+
 // Performs the "bubble down" operation. This is to place the element that is at the
-// root of the heap in its correct place so that the heap maintains the min/max-heap order property.
-func (heap *BinaryHeap) bubbleDown() {
-	index := 0
+// index (the root after a pop) in its correct place so that the heap maintains the min/max-heap order property.
+func (heap *BinaryHeap) bubbleDown(index int) {
 	size := heap.list.Len()
 	for leftIndex := index<<1 + 1; leftIndex < size; leftIndex = index<<1 + 1 {
 		rightIndex := index<<1 + 2
@@ -117,10 +223,9 @@ func (heap *BinaryHeap) bubbleDown() {
 }
 
 // Performs the "bubble up" operation. This is to place a newly inserted
-// element (i.e. last element in the list) in its correct place so that
+// element (i.e. the element at index, the last in the list) in its correct place so that
 // the heap maintains the min/max-heap order property.
-func (heap *BinaryHeap) bubbleUp() {
-	index := heap.list.Len() - 1
+func (heap *BinaryHeap) bubbleUp(index int) {
 	for parentIndex := (index - 1) >> 1; index > 0; parentIndex = (index - 1) >> 1 {
 		indexValue, _ := heap.list.Get(index)
 		parentValue, _ := heap.list.Get(parentIndex)
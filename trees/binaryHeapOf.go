@@ -0,0 +1,144 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/lists"
+	"iter"
+	"strings"
+)
+
+// BinaryHeapOf is the type-parameterized version of BinaryHeap
+type BinaryHeapOf[T any] struct {
+	list       *lists.ArrayListOf[T]
+	comparator container.Comparator[T]
+}
+
+func NewBinaryHeapOf[T any](comparator container.Comparator[T]) *BinaryHeapOf[T] {
+	return &BinaryHeapOf[T]{
+		list:       lists.NewArrayListOf[T](),
+		comparator: comparator,
+	}
+}
+
+// Returns true if heap does not contain any elements.
+func (heap *BinaryHeapOf[T]) Empty() bool {
+	return heap.list.Empty()
+}
+
+// Returns number of elements within the heap.
+func (heap *BinaryHeapOf[T]) Len() int {
+	return heap.list.Len()
+}
+
+// Removes all elements from the heap.
+func (heap *BinaryHeapOf[T]) Clear() {
+	heap.list.Clear()
+}
+
+// check if the elements are in the heap
+func (heap *BinaryHeapOf[T]) Contains(elements ...T) bool {
+	return heap.list.Contains(elements...)
+}
+
+// Returns all elements in the heap.
+func (heap *BinaryHeapOf[T]) Elements() []T {
+	return heap.list.Elements()
+}
+
+func (heap *BinaryHeapOf[T]) String() string {
+	str := "BinaryHeap{ "
+	values := []string{}
+	for _, value := range heap.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (heap *BinaryHeapOf[T]) Push(val T) {
+	heap.list.Add(val)
+	heap.bubbleUp()
+}
+
+// Pops (removes) top element on heap and returns it, or zero value if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *BinaryHeapOf[T]) Pop() (val T, ok bool) {
+	val, ok = heap.list.Get(0)
+	if !ok {
+		return
+	}
+	lastIndex := heap.list.Len() - 1
+	heap.list.Swap(0, lastIndex)
+	heap.list.Remove(lastIndex)
+
+	heap.bubbleDown()
+	return
+}
+
+// Returns top element on the heap without removing it, or zero value if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *BinaryHeapOf[T]) Peek() (val T, ok bool) {
+	return heap.list.Get(0)
+}
+
+// Performs the "bubble down" operation, see BinaryHeap.bubbleDown.
+func (heap *BinaryHeapOf[T]) bubbleDown() {
+	index := 0
+	size := heap.list.Len()
+	for leftIndex := index<<1 + 1; leftIndex < size; leftIndex = index<<1 + 1 {
+		rightIndex := index<<1 + 2
+		smallerIndex := leftIndex
+		leftValue, _ := heap.list.Get(leftIndex)
+		rightValue, _ := heap.list.Get(rightIndex)
+		if rightIndex < size && heap.comparator(leftValue, rightValue) > 0 {
+			smallerIndex = rightIndex
+		}
+		indexValue, _ := heap.list.Get(index)
+		smallerValue, _ := heap.list.Get(smallerIndex)
+		if heap.comparator(indexValue, smallerValue) > 0 {
+			heap.list.Swap(index, smallerIndex)
+		} else {
+			break
+		}
+		index = smallerIndex
+	}
+}
+
+// Performs the "bubble up" operation, see BinaryHeap.bubbleUp.
+func (heap *BinaryHeapOf[T]) bubbleUp() {
+	index := heap.list.Len() - 1
+	for parentIndex := (index - 1) >> 1; index > 0; parentIndex = (index - 1) >> 1 {
+		indexValue, _ := heap.list.Get(index)
+		parentValue, _ := heap.list.Get(parentIndex)
+		if heap.comparator(parentValue, indexValue) <= 0 {
+			break
+		}
+		heap.list.Swap(index, parentIndex)
+		index = parentIndex
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs in the heap's internal array order.
+func (heap *BinaryHeapOf[T]) All() iter.Seq2[int, T] {
+	return heap.list.All()
+}
+
+// Returns a range-over-func sequence of the elements in the heap's internal array order.
+func (heap *BinaryHeapOf[T]) Values() iter.Seq[T] {
+	return heap.list.Values()
+}
@@ -0,0 +1,84 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"testing"
+)
+
+func TestBinaryHeapOf(t *testing.T) {
+
+	heap := NewBinaryHeapOf[int](container.OrderedCompareFunctionASC[int])
+
+	if actualValue := heap.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue := heap.Elements(); actualValue[0] != 1 || actualValue[1] != 3 || actualValue[2] != 2 {
+		t.Errorf("Got %v expected %v", actualValue, "[1,3,2]")
+	}
+
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	if actualValue, ok := heap.Pop(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+
+	if actualValue, ok := heap.Pop(); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+
+	if actualValue, ok := heap.Pop(); actualValue != 3 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+
+	if actualValue, ok := heap.Pop(); actualValue != 0 || ok {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+	rand.Seed(3)
+	for i := 0; i < 10000; i++ {
+		heap.Push(int(rand.Int31n(30)))
+	}
+
+	prev, _ := heap.Pop()
+	for !heap.Empty() {
+		curr, _ := heap.Pop()
+		if prev > curr {
+			t.Errorf("Heap property invalidated. prev: %v current: %v", prev, curr)
+		}
+		prev = curr
+	}
+
+}
+
+func BenchmarkBinaryHeapOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		heap := NewBinaryHeapOf[int](container.OrderedCompareFunctionASC[int])
+		for n := 0; n < 1000; n++ {
+			heap.Push(i)
+		}
+		for !heap.Empty() {
+			heap.Pop()
+		}
+	}
+}
@@ -16,6 +16,7 @@ package trees
 import (
 	"github.com/aiwuTech/container"
 	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -106,3 +107,181 @@ func BenchmarkBinaryHeap(b *testing.B) {
 	}
 
 }
+
+func TestBinaryHeapIterator(t *testing.T) {
+
+	heap := NewBinaryHeap(container.IntCompareFunctionASC)
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	// internal array order: [1,3,2]
+	it := heap.Iterator()
+	count := 0
+	for it.Next() {
+		if actualValue, expectedValue := it.Value(), []int{1, 3, 2}[count]; actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Got %v expected %v", count, 3)
+	}
+
+}
+
+func TestBinaryHeapFrom(t *testing.T) {
+
+	random := rand.New(rand.NewSource(1))
+	elements := []interface{}{}
+	for i := 0; i < 1000; i++ {
+		elements = append(elements, random.Intn(100))
+	}
+
+	heap := NewBinaryHeapFrom(elements, container.IntCompareFunctionASC)
+	if actualValue := heap.Len(); actualValue != 1000 {
+		t.Errorf("Got %v expected %v", actualValue, 1000)
+	}
+
+	top := heap.TopK(10)
+	if actualValue := heap.Len(); actualValue != 1000 {
+		t.Errorf("Got %v expected %v", actualValue, 1000)
+	}
+
+	sorted := heap.DrainSorted()
+	if !heap.Empty() || len(sorted) != 1000 {
+		t.Errorf("Got %v expected %v", len(sorted), 1000)
+	}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i-1].(int) > sorted[i].(int) {
+			t.Errorf("Heap property invalidated. prev: %v current: %v", sorted[i-1], sorted[i])
+		}
+	}
+	for i, value := range top {
+		if value != sorted[i] {
+			t.Errorf("Got %v expected %v", value, sorted[i])
+		}
+	}
+
+	if actualValue := len(heap.TopK(3)); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+
+}
+
+func TestBinaryHeapPushAll(t *testing.T) {
+
+	heap := NewBinaryHeap(container.IntCompareFunctionASC)
+
+	// few values are bubbled up, many re-arrange the heap
+	heap.PushAll(5, 3, 8)
+	heap.PushAll(1)
+	heap.PushAll(9, 2, 7, 4, 6)
+	if actualValue, expectedValue := heap.TopK(20), []interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// 0 is not pushed at all, 10 replaces the top
+	if actualValue := heap.PushPop(0); actualValue != 0 {
+		t.Errorf("Got %v expected %v", actualValue, 0)
+	}
+	if actualValue := heap.PushPop(10); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.Replace(0); actualValue != 2 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 2)
+	}
+	if actualValue, expectedValue := heap.DrainSorted(), []interface{}{0, 3, 4, 5, 6, 7, 8, 9, 10}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	if actualValue := heap.PushPop(1); actualValue != 1 || !heap.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.Replace(1); actualValue != nil || ok || heap.Len() != 1 {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+}
+
+func benchmarkElements() []interface{} {
+	random := rand.New(rand.NewSource(1))
+	elements := make([]interface{}, 1000)
+	for i := range elements {
+		elements[i] = random.Int()
+	}
+	return elements
+}
+
+func BenchmarkBinaryHeapPushEach(b *testing.B) {
+	elements := benchmarkElements()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap := NewBinaryHeap(container.IntCompareFunctionASC)
+		for _, element := range elements {
+			heap.Push(element)
+		}
+	}
+}
+
+func BenchmarkNewBinaryHeapFrom(b *testing.B) {
+	elements := benchmarkElements()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewBinaryHeapFrom(elements, container.IntCompareFunctionASC)
+	}
+}
+
+func BenchmarkBinaryHeapTopK(b *testing.B) {
+	heap := NewBinaryHeapFrom(benchmarkElements(), container.IntCompareFunctionASC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		heap.TopK(10)
+	}
+}
+
+func BenchmarkBinaryHeapDrainSorted(b *testing.B) {
+	elements := benchmarkElements()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewBinaryHeapFrom(elements, container.IntCompareFunctionASC).DrainSorted()
+	}
+}
+
+func TestBinaryHeapMerge(t *testing.T) {
+
+	heap := NewBinaryHeapFrom([]interface{}{5, 1, 3}, container.IntCompareFunctionASC)
+	other := NewPairingHeap(container.IntCompareFunctionASC)
+	other.Push(4)
+	other.Push(2)
+	heap.Merge(other)
+	heap.Merge(heap)
+
+	if !other.Empty() {
+		t.Errorf("Got %v expected %v", other.Len(), 0)
+	}
+	if actualValue, expectedValue := heap.DrainSorted(), []interface{}{1, 2, 3, 4, 5}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+}
+
+// pops everything and checks the order, the heap must hold ints
+func checkHeapOrder(t *testing.T, heap HeapInterface) {
+	length := heap.Len()
+	if actualValue := len(heap.Elements()); actualValue != length {
+		t.Errorf("Got %v expected %v", actualValue, length)
+	}
+	count := 0
+	prev, ok := heap.Pop()
+	for ; ok; count++ {
+		curr, more := heap.Pop()
+		if more && prev.(int) > curr.(int) {
+			t.Errorf("Heap property invalidated. prev: %v current: %v", prev, curr)
+		}
+		prev, ok = curr, more
+	}
+	if count != length {
+		t.Errorf("Got %v expected %v", count, length)
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+// DaryHeap is an array heap whose nodes have arity children instead of two.
+// A wider heap is shallower, so pushes are cheaper while pops compare more children,
+// which suits workloads with many more pushes than pops.
+type DaryHeap struct {
+	elements   []interface{}
+	arity      int
+	comparator container.CompareFunction
+}
+
+// Instantiates a heap whose nodes have arity children, at least 2.
+func NewDaryHeap(arity int, comparator container.CompareFunction) *DaryHeap {
+	if arity < 2 {
+		arity = 2
+	}
+	return &DaryHeap{
+		arity:      arity,
+		comparator: comparator,
+	}
+}
+
+// Returns the number of children of each node.
+func (heap *DaryHeap) Arity() int {
+	return heap.arity
+}
+
+func (heap *DaryHeap) Push(val interface{}) {
+	heap.elements = append(heap.elements, val)
+	heap.bubbleUp(len(heap.elements) - 1)
+}
+
+func (heap *DaryHeap) Pop() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	val = heap.elements[0]
+	lastIndex := len(heap.elements) - 1
+	heap.elements[0] = heap.elements[lastIndex]
+	heap.elements[lastIndex] = nil
+	heap.elements = heap.elements[:lastIndex]
+	heap.bubbleDown(0)
+	return val, true
+}
+
+func (heap *DaryHeap) Peek() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	return heap.elements[0], true
+}
+
+// Appends the elements of other and re-arranges the heap in O(n + m).
+func (heap *DaryHeap) Merge(other HeapInterface) {
+	elements := drainHeap(heap, other)
+	if len(elements) == 0 {
+		return
+	}
+	heap.elements = append(heap.elements, elements...)
+	for index := (len(heap.elements) - 2) / heap.arity; index >= 0; index-- {
+		heap.bubbleDown(index)
+	}
+}
+
+// Returns true if heap does not contain any elements.
+func (heap *DaryHeap) Empty() bool {
+	return len(heap.elements) == 0
+}
+
+// Returns number of elements within the heap.
+func (heap *DaryHeap) Len() int {
+	return len(heap.elements)
+}
+
+// Removes all elements from the heap.
+func (heap *DaryHeap) Clear() {
+	heap.elements = nil
+}
+
+// check if the elements are in the heap
+func (heap *DaryHeap) Contains(elements ...interface{}) bool {
+	return heapContains(heap, elements)
+}
+
+// Returns all elements in the heap's internal array order.
+func (heap *DaryHeap) Elements() []interface{} {
+	return append([]interface{}{}, heap.elements...)
+}
+
+// Returns a stateful iterator over the heap in its internal array order, which is not sorted.
+func (heap *DaryHeap) Iterator() container.Iterator {
+	return &daryHeapIterator{heap: heap, index: -1}
+}
+
+func (heap *DaryHeap) String() string {
+	return heapString("DaryHeap", heap)
+}
+
+func (heap *DaryHeap) bubbleUp(index int) {
+	for index > 0 {
+		parentIndex := (index - 1) / heap.arity
+		if heap.comparator(heap.elements[parentIndex], heap.elements[index]) <= 0 {
+			break
+		}
+		heap.elements[index], heap.elements[parentIndex] = heap.elements[parentIndex], heap.elements[index]
+		index = parentIndex
+	}
+}
+
+func (heap *DaryHeap) bubbleDown(index int) {
+	size := len(heap.elements)
+	for {
+		smallerIndex := index
+		firstChild := index*heap.arity + 1
+		for child := firstChild; child < firstChild+heap.arity && child < size; child++ {
+			if heap.comparator(heap.elements[child], heap.elements[smallerIndex]) < 0 {
+				smallerIndex = child
+			}
+		}
+		if smallerIndex == index {
+			return
+		}
+		heap.elements[index], heap.elements[smallerIndex] = heap.elements[smallerIndex], heap.elements[index]
+		index = smallerIndex
+	}
+}
+
+type daryHeapIterator struct {
+	heap  *DaryHeap
+	index int
+}
+
+func (it *daryHeapIterator) Next() bool {
+	if it.index < len(it.heap.elements) {
+		it.index++
+	}
+	return it.index < len(it.heap.elements)
+}
+
+func (it *daryHeapIterator) Value() interface{} {
+	return it.heap.elements[it.index]
+}
+
+func (it *daryHeapIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *daryHeapIterator) Reset() {
+	it.index = -1
+}
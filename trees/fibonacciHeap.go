@@ -0,0 +1,241 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+type fibonacciNode struct {
+	value  interface{}
+	child  *fibonacciNode
+	left   *fibonacciNode // siblings form a circular list
+	right  *fibonacciNode
+	degree int
+}
+
+// FibonacciHeap is a list of heap-ordered trees which are only consolidated on Pop.
+// Push, Peek and Merge are O(1), Pop is O(log n) amortized.
+type FibonacciHeap struct {
+	min        *fibonacciNode // in the root list
+	size       int
+	comparator container.CompareFunction
+}
+
+func NewFibonacciHeap(comparator container.CompareFunction) *FibonacciHeap {
+	return &FibonacciHeap{
+		comparator: comparator,
+	}
+}
+
+func (heap *FibonacciHeap) Push(val interface{}) {
+	node := &fibonacciNode{value: val}
+	node.left, node.right = node, node
+	heap.addRoots(node, node)
+	heap.size++
+}
+
+func (heap *FibonacciHeap) Pop() (val interface{}, ok bool) {
+	min := heap.min
+	if min == nil {
+		return nil, false
+	}
+	if min.child != nil {
+		spliceFibonacciLists(min, min.child)
+		min.child = nil
+	}
+	if min.right == min {
+		heap.min = nil
+	} else {
+		min.left.right = min.right
+		min.right.left = min.left
+		heap.min = min.right
+		heap.consolidate()
+	}
+	heap.size--
+	return min.value, true
+}
+
+func (heap *FibonacciHeap) Peek() (val interface{}, ok bool) {
+	if heap.min == nil {
+		return nil, false
+	}
+	return heap.min.value, true
+}
+
+// Joins the root lists in O(1) when other is a FibonacciHeap, pushes its elements otherwise.
+func (heap *FibonacciHeap) Merge(other HeapInterface) {
+	if fibonacci, ok := other.(*FibonacciHeap); ok && fibonacci != heap {
+		if fibonacci.min != nil {
+			heap.addRoots(fibonacci.min, fibonacci.min)
+		}
+		heap.size += fibonacci.size
+		fibonacci.Clear()
+		return
+	}
+	for _, element := range drainHeap(heap, other) {
+		heap.Push(element)
+	}
+}
+
+// Returns true if heap does not contain any elements.
+func (heap *FibonacciHeap) Empty() bool {
+	return heap.size == 0
+}
+
+// Returns number of elements within the heap.
+func (heap *FibonacciHeap) Len() int {
+	return heap.size
+}
+
+// Removes all elements from the heap.
+func (heap *FibonacciHeap) Clear() {
+	heap.min = nil
+	heap.size = 0
+}
+
+// check if the elements are in the heap
+func (heap *FibonacciHeap) Contains(elements ...interface{}) bool {
+	return heapContains(heap, elements)
+}
+
+// Returns all elements in the order of the trees, parents before their children.
+func (heap *FibonacciHeap) Elements() []interface{} {
+	return heapElements(heap)
+}
+
+// Returns a stateful iterator over the heap, parents before their children, which is not sorted.
+// Key is the position of the element in the walk.
+func (heap *FibonacciHeap) Iterator() container.Iterator {
+	return &fibonacciHeapIterator{heap: heap, index: -1}
+}
+
+func (heap *FibonacciHeap) String() string {
+	return heapString("FibonacciHeap", heap)
+}
+
+// adds a circular list of trees whose smallest root is min to the root list
+func (heap *FibonacciHeap) addRoots(roots, min *fibonacciNode) {
+	if heap.min == nil {
+		heap.min = min
+		return
+	}
+	spliceFibonacciLists(heap.min, roots)
+	if heap.comparator(min.value, heap.min.value) < 0 {
+		heap.min = min
+	}
+}
+
+// links the roots of equal degree until all degrees differ, and finds the new min
+func (heap *FibonacciHeap) consolidate() {
+	roots := []*fibonacciNode{}
+	for node := heap.min; ; {
+		roots = append(roots, node)
+		if node = node.right; node == heap.min {
+			break
+		}
+	}
+
+	byDegree := []*fibonacciNode{}
+	for _, node := range roots {
+		degree := node.degree
+		for degree < len(byDegree) && byDegree[degree] != nil {
+			other := byDegree[degree]
+			if heap.comparator(other.value, node.value) < 0 {
+				node, other = other, node
+			}
+			// other goes below node
+			other.left, other.right = other, other
+			if node.child == nil {
+				node.child = other
+			} else {
+				spliceFibonacciLists(node.child, other)
+			}
+			node.degree++
+			byDegree[degree] = nil
+			degree++
+		}
+		for degree >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+		byDegree[degree] = node
+	}
+
+	heap.min = nil
+	for _, node := range byDegree {
+		if node != nil {
+			node.left, node.right = node, node
+			heap.addRoots(node, node)
+		}
+	}
+}
+
+// joins the circular lists of a and b into one
+func spliceFibonacciLists(a, b *fibonacciNode) {
+	aRight, bLeft := a.right, b.left
+	a.right, b.left = b, a
+	bLeft.right, aRight.left = aRight, bLeft
+}
+
+// fibonacciHeapIterator walks the trees depth first. Entering a circular list of siblings
+// puts all of them on the stack, so it does not need to remember where the list started.
+type fibonacciHeapIterator struct {
+	heap  *FibonacciHeap
+	stack []*fibonacciNode
+	node  *fibonacciNode
+	index int
+}
+
+func (it *fibonacciHeapIterator) Next() bool {
+	switch {
+	case it.index == -1:
+		it.stack = it.pushSiblings(it.stack[:0], it.heap.min)
+	case it.node != nil:
+		it.stack = it.pushSiblings(it.stack, it.node.child)
+	default:
+		return false
+	}
+	it.index++
+	it.node = nil
+	if len(it.stack) > 0 {
+		it.node = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return it.node != nil
+}
+
+// pushes the circular list starting at first, in reverse so that first is popped first
+func (it *fibonacciHeapIterator) pushSiblings(stack []*fibonacciNode, first *fibonacciNode) []*fibonacciNode {
+	if first == nil {
+		return stack
+	}
+	for node := first.left; ; node = node.left {
+		stack = append(stack, node)
+		if node == first {
+			return stack
+		}
+	}
+}
+
+func (it *fibonacciHeapIterator) Value() interface{} {
+	return it.node.value
+}
+
+func (it *fibonacciHeapIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *fibonacciHeapIterator) Reset() {
+	it.stack, it.node, it.index = it.stack[:0], nil, -1
+}
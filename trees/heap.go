@@ -0,0 +1,88 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"strings"
+)
+
+// HeapInterface is implemented by the heaps of this package.
+// The element on top is the smallest one by the heap's comparator.
+type HeapInterface interface {
+	Push(val interface{})
+	// Pops (removes) top element on heap and returns it, or nil if heap is empty.
+	// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+	Pop() (val interface{}, ok bool)
+	// Returns top element on the heap without removing it, or nil if heap is empty.
+	Peek() (val interface{}, ok bool)
+	// Moves all the elements of other into the heap, leaving other empty.
+	// Both heaps must order their elements the same way. It is O(1) when other is
+	// of the same kind and the structure allows it, other heaps are pushed element by element.
+	Merge(other HeapInterface)
+	container.ContainerInterface
+}
+
+var (
+	_ HeapInterface = &BinaryHeap{}
+	_ HeapInterface = &DaryHeap{}
+	_ HeapInterface = &PairingHeap{}
+	_ HeapInterface = &FibonacciHeap{}
+	_ HeapInterface = &MinMaxHeap{}
+)
+
+// takes the elements out of other for a merge, nil when other is the heap itself
+func drainHeap(heap, other HeapInterface) []interface{} {
+	if other == nil || other == heap {
+		return nil
+	}
+	elements := other.Elements()
+	other.Clear()
+	return elements
+}
+
+// collects the elements through the heap's iterator
+func heapElements(heap HeapInterface) []interface{} {
+	elements := make([]interface{}, 0, heap.Len())
+	for it := heap.Iterator(); it.Next(); {
+		elements = append(elements, it.Value())
+	}
+	return elements
+}
+
+// check if the elements are in the heap by walking it
+func heapContains(heap HeapInterface, elements []interface{}) bool {
+	for _, element := range elements {
+		found := false
+		for it := heap.Iterator(); it.Next() && !found; {
+			found = it.Value() == element
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func heapString(name string, heap HeapInterface) string {
+	str := name + "{ "
+	values := []string{}
+	for _, value := range heap.Elements() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
@@ -0,0 +1,146 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// the heaps sharing the HeapInterface tests, BinaryHeap has its own in binaryHeap_test.go
+var heaps = []struct {
+	name    string
+	newHeap func() HeapInterface
+}{
+	{"DaryHeap2", func() HeapInterface { return NewDaryHeap(2, container.IntCompareFunctionASC) }},
+	{"DaryHeap3", func() HeapInterface { return NewDaryHeap(3, container.IntCompareFunctionASC) }},
+	{"DaryHeap4", func() HeapInterface { return NewDaryHeap(4, container.IntCompareFunctionASC) }},
+	{"DaryHeap1", func() HeapInterface { return NewDaryHeap(1, container.IntCompareFunctionASC) }},
+	{"PairingHeap", func() HeapInterface { return NewPairingHeap(container.IntCompareFunctionASC) }},
+	{"FibonacciHeap", func() HeapInterface { return NewFibonacciHeap(container.IntCompareFunctionASC) }},
+}
+
+func TestHeaps(t *testing.T) {
+	for _, heap := range heaps {
+		t.Run(heap.name, func(t *testing.T) {
+			testHeap(t, heap.newHeap())
+		})
+	}
+}
+
+func testHeap(t *testing.T, heap HeapInterface) {
+	if actualValue, ok := heap.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	heap.Push(3)
+	heap.Push(2)
+	heap.Push(1)
+
+	if actualValue := heap.Len(); actualValue != 3 || heap.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, ok := heap.Peek(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if !heap.Contains(1, 2, 3) || heap.Contains(4) {
+		t.Errorf("Contains error, got %v", heap)
+	}
+
+	elements := heap.Elements()
+	slices.SortFunc(elements, func(e1, e2 interface{}) int { return e1.(int) - e2.(int) })
+	if actualValue, expectedValue := elements, []interface{}{1, 2, 3}; !slices.Equal(actualValue, expectedValue) {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	for _, expectedValue := range []int{1, 2, 3} {
+		if actualValue, ok := heap.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if !heap.Empty() {
+		t.Errorf("Got %v expected %v", heap.Len(), 0)
+	}
+
+	random := rand.New(rand.NewSource(3))
+	for i := 0; i < 10000; i++ {
+		heap.Push(random.Intn(30))
+		if i%3 == 0 {
+			heap.Pop()
+		}
+	}
+	checkHeapOrder(t, heap)
+}
+
+func TestHeapsMerge(t *testing.T) {
+	for _, heap := range heaps {
+		t.Run(heap.name, func(t *testing.T) {
+			testHeapMerge(t, heap.newHeap, heap.newHeap())
+		})
+	}
+}
+
+func testHeapMerge(t *testing.T, newHeap func() HeapInterface, heap HeapInterface) {
+	heap.Push(5)
+	heap.Push(1)
+
+	// same kind, then another kind, then itself
+	other := newHeap()
+	other.Push(4)
+	other.Push(2)
+	heap.Merge(other)
+	binary := NewBinaryHeap(container.IntCompareFunctionASC)
+	binary.Push(3)
+	heap.Merge(binary)
+	heap.Merge(heap)
+
+	if !other.Empty() || !binary.Empty() {
+		t.Errorf("Got %v expected %v", other.Len()+binary.Len(), 0)
+	}
+	if actualValue := heap.Len(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	for _, expectedValue := range []int{1, 2, 3, 4, 5} {
+		if actualValue, _ := heap.Pop(); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+// an arity below 2 is raised to 2
+func TestDaryHeapArity(t *testing.T) {
+	for _, test := range [][]int{{-1, 2}, {0, 2}, {1, 2}, {2, 2}, {3, 3}, {4, 4}} {
+		if actualValue := NewDaryHeap(test[0], container.IntCompareFunctionASC).Arity(); actualValue != test[1] {
+			t.Errorf("Got %v expected %v", actualValue, test[1])
+		}
+	}
+}
+
+func BenchmarkHeaps(b *testing.B) {
+	for _, heap := range heaps {
+		b.Run(heap.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				heap := heap.newHeap()
+				for n := 0; n < 1000; n++ {
+					heap.Push(n)
+				}
+				for !heap.Empty() {
+					heap.Pop()
+				}
+			}
+		})
+	}
+}
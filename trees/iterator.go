@@ -0,0 +1,312 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+type iteratorPosition byte
+
+const (
+	begin, between, end iteratorPosition = 0, 1, 2
+)
+
+// redBlackTreeIterator walks the tree in key order keeping the path from the root to its node,
+// since the nodes have no parent pointers, they may be shared with snapshots of the tree.
+type redBlackTreeIterator struct {
+	tree     *RBTree
+	path     redBlackPath
+	mods     int // of the tree when the path was taken
+	key      interface{}
+	value    interface{}
+	position iteratorPosition
+}
+
+// Returns a stateful iterator over the tree in key order, positioned before the smallest key.
+func (tree *RBTree) Iterator() container.Iterator {
+	return tree.ReverseIterator()
+}
+
+// Returns a stateful iterator which may also walk the tree in reverse key order.
+// The tree may be written between two steps, the iterator then goes on from the key it is at.
+func (tree *RBTree) ReverseIterator() container.ReverseIterator {
+	return &redBlackTreeIterator{tree: tree, position: begin}
+}
+
+func (it *redBlackTreeIterator) Next() bool {
+	switch it.position {
+	case end:
+		return false
+	case begin:
+		it.mods = it.tree.mods
+		it.path.first(it.tree.root)
+	case between:
+		it.path.nextAfter(it.tree, it.key, &it.mods)
+	}
+	return it.settle(end)
+}
+
+func (it *redBlackTreeIterator) Prev() bool {
+	switch it.position {
+	case begin:
+		return false
+	case end:
+		it.mods = it.tree.mods
+		it.path.last(it.tree.root)
+	case between:
+		it.path.prevBefore(it.tree, it.key, &it.mods)
+	}
+	return it.settle(begin)
+}
+
+// fixes the position after a move, falling off the tree to the given side
+func (it *redBlackTreeIterator) settle(side iteratorPosition) bool {
+	node := it.path.node()
+	if node == nil {
+		it.position = side
+		it.key, it.value = nil, nil
+		return false
+	}
+	it.position = between
+	it.key, it.value = node.key, node.value
+	return true
+}
+
+func (it *redBlackTreeIterator) Value() interface{} {
+	return it.value
+}
+
+func (it *redBlackTreeIterator) Key() interface{} {
+	return it.key
+}
+
+func (it *redBlackTreeIterator) Reset() {
+	it.path = it.path[:0]
+	it.key, it.value = nil, nil
+	it.position = begin
+}
+
+func (it *redBlackTreeIterator) End() {
+	it.path = it.path[:0]
+	it.key, it.value = nil, nil
+	it.position = end
+}
+
+func minimumNode(node *redBlackNode) *redBlackNode {
+	if node == nil {
+		return nil
+	}
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// redBlackPath holds the nodes from the root down to the current one, which is the last,
+// it is empty once the walk falls off the tree.
+type redBlackPath []*redBlackNode
+
+// the current node, or nil
+func (path redBlackPath) node() *redBlackNode {
+	if len(path) == 0 {
+		return nil
+	}
+	return path[len(path)-1]
+}
+
+// moves to the smallest node of the tree
+func (path *redBlackPath) first(root *redBlackNode) {
+	*path = (*path)[:0]
+	path.pushLeft(root)
+}
+
+// moves to the largest node of the tree
+func (path *redBlackPath) last(root *redBlackNode) {
+	*path = (*path)[:0]
+	path.pushRight(root)
+}
+
+// descends along the left children of node
+func (path *redBlackPath) pushLeft(node *redBlackNode) {
+	for ; node != nil; node = node.left {
+		*path = append(*path, node)
+	}
+}
+
+// descends along the right children of node
+func (path *redBlackPath) pushRight(node *redBlackNode) {
+	for ; node != nil; node = node.right {
+		*path = append(*path, node)
+	}
+}
+
+// moves to the next node in key order
+func (path *redBlackPath) next() {
+	node := path.node()
+	if node.right != nil {
+		path.pushLeft(node.right)
+		return
+	}
+	for *path = (*path)[:len(*path)-1]; len(*path) > 0; *path = (*path)[:len(*path)-1] {
+		parent := path.node()
+		if parent.left == node {
+			return
+		}
+		node = parent
+	}
+}
+
+// moves to the previous node in key order
+func (path *redBlackPath) prev() {
+	node := path.node()
+	if node.left != nil {
+		path.pushRight(node.left)
+		return
+	}
+	for *path = (*path)[:len(*path)-1]; len(*path) > 0; *path = (*path)[:len(*path)-1] {
+		parent := path.node()
+		if parent.right == node {
+			return
+		}
+		node = parent
+	}
+}
+
+// moves to the smallest node with a key greater than key, or equal to it when inclusive
+func (path *redBlackPath) seek(tree *RBTree, key interface{}, inclusive bool) {
+	*path = (*path)[:0]
+	found := 0
+	for node := tree.root; node != nil; {
+		*path = append(*path, node)
+		compare := tree.comparator(key, node.key)
+		if compare < 0 || compare == 0 && inclusive {
+			found = len(*path)
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+	*path = (*path)[:found]
+}
+
+// moves to the largest node with a key smaller than key, or equal to it when inclusive
+func (path *redBlackPath) seekBack(tree *RBTree, key interface{}, inclusive bool) {
+	*path = (*path)[:0]
+	found := 0
+	for node := tree.root; node != nil; {
+		*path = append(*path, node)
+		compare := tree.comparator(key, node.key)
+		if compare > 0 || compare == 0 && inclusive {
+			found = len(*path)
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	*path = (*path)[:found]
+}
+
+// moves to the next node after key, the key of the current node. If the tree was written
+// since mods was taken, the nodes on the path may have been rotated, copied or removed,
+// so the next key is sought from the root instead.
+func (path *redBlackPath) nextAfter(tree *RBTree, key interface{}, mods *int) {
+	if *mods == tree.mods {
+		path.next()
+		return
+	}
+	*mods = tree.mods
+	path.seek(tree, key, false)
+}
+
+// moves to the previous node before key, the key of the current node, see nextAfter
+func (path *redBlackPath) prevBefore(tree *RBTree, key interface{}, mods *int) {
+	if *mods == tree.mods {
+		path.prev()
+		return
+	}
+	*mods = tree.mods
+	path.seekBack(tree, key, false)
+}
+
+// Returns a stateful iterator over the heap in its internal array order, which is not sorted.
+func (heap *BinaryHeap) Iterator() container.Iterator {
+	return heap.list.Iterator()
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order.
+// The loop body may write the tree, the walk goes on after the key it is at.
+func (tree *RBTree) All() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		var path redBlackPath
+		mods := tree.mods
+		for path.first(tree.root); len(path) > 0; {
+			key, value := path.node().key, path.node().value
+			if !yield(key, value) {
+				return
+			}
+			path.nextAfter(tree, key, &mods)
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in reverse key order.
+// The loop body may write the tree, the walk goes on before the key it is at.
+func (tree *RBTree) Backward() iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		var path redBlackPath
+		mods := tree.mods
+		for path.last(tree.root); len(path) > 0; {
+			key, value := path.node().key, path.node().value
+			if !yield(key, value) {
+				return
+			}
+			path.prevBefore(tree, key, &mods)
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the keys in order.
+// It is not named Keys, which already returns a slice.
+func (tree *RBTree) KeySeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for key := range tree.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the values in key order.
+func (tree *RBTree) Values() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, value := range tree.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (index, element) pairs in the heap's internal array order.
+func (heap *BinaryHeap) All() iter.Seq2[int, interface{}] {
+	return heap.list.All()
+}
+
+// Returns a range-over-func sequence of the elements in the heap's internal array order.
+func (heap *BinaryHeap) Values() iter.Seq[interface{}] {
+	return heap.list.Values()
+}
@@ -0,0 +1,259 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/bits"
+)
+
+// MinMaxHeap is an array heap giving both the smallest and the largest element.
+// The nodes on even levels (the root is on level 0) are smaller than all their descendants,
+// the ones on odd levels larger, so the largest element is one of the root's children.
+// Push, PopMin and PopMax are O(log n), PeekMin and PeekMax O(1).
+// As a HeapInterface, Pop and Peek work on the smallest element.
+type MinMaxHeap struct {
+	elements   []interface{}
+	comparator container.CompareFunction
+}
+
+func NewMinMaxHeap(comparator container.CompareFunction) *MinMaxHeap {
+	return &MinMaxHeap{
+		comparator: comparator,
+	}
+}
+
+func (heap *MinMaxHeap) Push(val interface{}) {
+	heap.elements = append(heap.elements, val)
+	heap.bubbleUp(len(heap.elements) - 1)
+}
+
+// Same as PopMin.
+func (heap *MinMaxHeap) Pop() (val interface{}, ok bool) {
+	return heap.PopMin()
+}
+
+// Same as PeekMin.
+func (heap *MinMaxHeap) Peek() (val interface{}, ok bool) {
+	return heap.PeekMin()
+}
+
+// Returns the smallest element without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *MinMaxHeap) PeekMin() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	return heap.elements[0], true
+}
+
+// Returns the largest element without removing it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to peek.
+func (heap *MinMaxHeap) PeekMax() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	return heap.elements[heap.maxIndex()], true
+}
+
+// Pops (removes) the smallest element and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *MinMaxHeap) PopMin() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	return heap.removeAt(0), true
+}
+
+// Pops (removes) the largest element and returns it, or nil if heap is empty.
+// Second return parameter is true, unless the heap was empty and there was nothing to pop.
+func (heap *MinMaxHeap) PopMax() (val interface{}, ok bool) {
+	if len(heap.elements) == 0 {
+		return nil, false
+	}
+	return heap.removeAt(heap.maxIndex()), true
+}
+
+// Appends the elements of other and re-arranges the heap in O(n + m).
+func (heap *MinMaxHeap) Merge(other HeapInterface) {
+	elements := drainHeap(heap, other)
+	if len(elements) == 0 {
+		return
+	}
+	heap.elements = append(heap.elements, elements...)
+	for index := len(heap.elements)>>1 - 1; index >= 0; index-- {
+		heap.trickleDown(index)
+	}
+}
+
+// Returns true if heap does not contain any elements.
+func (heap *MinMaxHeap) Empty() bool {
+	return len(heap.elements) == 0
+}
+
+// Returns number of elements within the heap.
+func (heap *MinMaxHeap) Len() int {
+	return len(heap.elements)
+}
+
+// Removes all elements from the heap.
+func (heap *MinMaxHeap) Clear() {
+	heap.elements = nil
+}
+
+// check if the elements are in the heap
+func (heap *MinMaxHeap) Contains(elements ...interface{}) bool {
+	return heapContains(heap, elements)
+}
+
+// Returns all elements in the heap's internal array order.
+func (heap *MinMaxHeap) Elements() []interface{} {
+	return append([]interface{}{}, heap.elements...)
+}
+
+// Returns a stateful iterator over the heap in its internal array order, which is not sorted.
+func (heap *MinMaxHeap) Iterator() container.Iterator {
+	return &minMaxHeapIterator{heap: heap, index: -1}
+}
+
+func (heap *MinMaxHeap) String() string {
+	return heapString("MinMaxHeap", heap)
+}
+
+// index of the largest element, the heap must not be empty
+func (heap *MinMaxHeap) maxIndex() int {
+	switch len(heap.elements) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if heap.less(1, 2) {
+		return 2
+	}
+	return 1
+}
+
+// removes the element at index, moving the last one into its place
+func (heap *MinMaxHeap) removeAt(index int) interface{} {
+	val := heap.elements[index]
+	lastIndex := len(heap.elements) - 1
+	heap.elements[index] = heap.elements[lastIndex]
+	heap.elements[lastIndex] = nil
+	heap.elements = heap.elements[:lastIndex]
+	if index < lastIndex {
+		heap.trickleDown(index)
+	}
+	return val
+}
+
+func (heap *MinMaxHeap) less(i, j int) bool {
+	return heap.comparator(heap.elements[i], heap.elements[j]) < 0
+}
+
+func (heap *MinMaxHeap) swap(i, j int) {
+	heap.elements[i], heap.elements[j] = heap.elements[j], heap.elements[i]
+}
+
+// whether the index is on a min level
+func isMinLevel(index int) bool {
+	return bits.Len(uint(index+1))%2 == 1
+}
+
+// places the element at index, the last one, by comparing it with its parent first
+// and then bubbling it up through the grandparents on its own kind of levels
+func (heap *MinMaxHeap) bubbleUp(index int) {
+	if index == 0 {
+		return
+	}
+	parent := (index - 1) >> 1
+	min := isMinLevel(index)
+	if heap.less(parent, index) == min {
+		// index belongs to the other kind of levels, e.g. a new element larger than its min-level parent
+		heap.swap(index, parent)
+		index, min = parent, !min
+	}
+	for index > 2 {
+		grandparent := ((index-1)>>1 - 1) >> 1
+		if heap.less(index, grandparent) != min {
+			return
+		}
+		heap.swap(index, grandparent)
+		index = grandparent
+	}
+}
+
+// places the element at index by moving it down to the smallest (largest on a max level)
+// of its children and grandchildren
+func (heap *MinMaxHeap) trickleDown(index int) {
+	min := isMinLevel(index)
+	// prefers the smaller element on min levels, the larger one on max levels
+	better := func(i, j int) bool {
+		if min {
+			return heap.less(i, j)
+		}
+		return heap.less(j, i)
+	}
+
+	size := len(heap.elements)
+	for {
+		firstChild := index<<1 + 1
+		if firstChild >= size {
+			return
+		}
+		best := firstChild
+		candidates := []int{firstChild + 1, firstChild<<1 + 1, firstChild<<1 + 2, firstChild<<1 + 3, firstChild<<1 + 4}
+		for _, candidate := range candidates {
+			if candidate < size && better(candidate, best) {
+				best = candidate
+			}
+		}
+		if !better(best, index) {
+			return
+		}
+		heap.swap(best, index)
+		if best <= firstChild+1 {
+			// a child has no descendants on the same kind of level to go on with
+			return
+		}
+		if parent := (best - 1) >> 1; better(parent, best) {
+			heap.swap(best, parent)
+		}
+		index = best
+	}
+}
+
+type minMaxHeapIterator struct {
+	heap  *MinMaxHeap
+	index int
+}
+
+func (it *minMaxHeapIterator) Next() bool {
+	if it.index < len(it.heap.elements) {
+		it.index++
+	}
+	return it.index < len(it.heap.elements)
+}
+
+func (it *minMaxHeapIterator) Value() interface{} {
+	return it.heap.elements[it.index]
+}
+
+func (it *minMaxHeapIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *minMaxHeapIterator) Reset() {
+	it.index = -1
+}
@@ -0,0 +1,118 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+
+	heap := NewMinMaxHeap(container.IntCompareFunctionASC)
+
+	if actualValue, ok := heap.PopMax(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	for _, value := range []int{5, 9, 1, 7, 3} {
+		heap.Push(value)
+	}
+
+	if actualValue, ok := heap.PeekMin(); actualValue != 1 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, ok := heap.PeekMax(); actualValue != 9 || !ok {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if !heap.Contains(1, 3, 5, 7, 9) || heap.Contains(2) || heap.Len() != 5 {
+		t.Errorf("Contains error, got %v", heap)
+	}
+
+	if actualValue, _ := heap.PopMax(); actualValue != 9 {
+		t.Errorf("Got %v expected %v", actualValue, 9)
+	}
+	if actualValue, _ := heap.PopMin(); actualValue != 1 {
+		t.Errorf("Got %v expected %v", actualValue, 1)
+	}
+	if actualValue, _ := heap.PopMax(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+	if actualValue, _ := heap.Pop(); actualValue != 3 {
+		t.Errorf("Got %v expected %v", actualValue, 3)
+	}
+	if actualValue, _ := heap.PeekMax(); actualValue != 5 {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+	if actualValue, _ := heap.PopMax(); actualValue != 5 || !heap.Empty() {
+		t.Errorf("Got %v expected %v", actualValue, 5)
+	}
+
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+
+	heap := NewMinMaxHeap(container.IntCompareFunctionASC)
+	reference := []int{}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		switch random.Intn(4) {
+		case 0:
+			if actualValue, ok := heap.PopMin(); ok {
+				if actualValue != reference[0] {
+					t.Fatalf("Got %v expected %v", actualValue, reference[0])
+				}
+				reference = reference[1:]
+			}
+		case 1:
+			if actualValue, ok := heap.PopMax(); ok {
+				if actualValue != reference[len(reference)-1] {
+					t.Fatalf("Got %v expected %v", actualValue, reference[len(reference)-1])
+				}
+				reference = reference[:len(reference)-1]
+			}
+		default:
+			value := random.Intn(100)
+			heap.Push(value)
+			index, _ := slices.BinarySearch(reference, value)
+			reference = slices.Insert(reference, index, value)
+		}
+		if heap.Len() != len(reference) {
+			t.Fatalf("Got %v expected %v", heap.Len(), len(reference))
+		}
+	}
+
+	other := NewMinMaxHeap(container.IntCompareFunctionASC)
+	other.Merge(heap)
+	checkHeapOrder(t, other)
+
+}
+
+// keeps the 10 largest of a stream, the use the heap is made for
+func BenchmarkMinMaxHeap(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		heap := NewMinMaxHeap(container.IntCompareFunctionASC)
+		for n := 0; n < 1000; n++ {
+			heap.Push(n * 7919 % 1000)
+			if heap.Len() > 10 {
+				heap.PopMin()
+			}
+		}
+		for !heap.Empty() {
+			heap.PopMax()
+		}
+	}
+}
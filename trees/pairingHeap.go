@@ -0,0 +1,184 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+type pairingNode struct {
+	value   interface{}
+	child   *pairingNode // first child
+	sibling *pairingNode // next sibling
+}
+
+// PairingHeap is a heap-ordered multiway tree. Push and Merge are O(1),
+// Pop is O(log n) amortized by pairing up the children of the removed root.
+type PairingHeap struct {
+	root       *pairingNode
+	size       int
+	comparator container.CompareFunction
+}
+
+func NewPairingHeap(comparator container.CompareFunction) *PairingHeap {
+	return &PairingHeap{
+		comparator: comparator,
+	}
+}
+
+func (heap *PairingHeap) Push(val interface{}) {
+	heap.root = heap.meld(heap.root, &pairingNode{value: val})
+	heap.size++
+}
+
+func (heap *PairingHeap) Pop() (val interface{}, ok bool) {
+	if heap.root == nil {
+		return nil, false
+	}
+	val = heap.root.value
+	heap.root = heap.mergePairs(heap.root.child)
+	heap.size--
+	return val, true
+}
+
+func (heap *PairingHeap) Peek() (val interface{}, ok bool) {
+	if heap.root == nil {
+		return nil, false
+	}
+	return heap.root.value, true
+}
+
+// Melds the roots in O(1) when other is a PairingHeap, pushes its elements otherwise.
+func (heap *PairingHeap) Merge(other HeapInterface) {
+	if pairing, ok := other.(*PairingHeap); ok && pairing != heap {
+		heap.root = heap.meld(heap.root, pairing.root)
+		heap.size += pairing.size
+		pairing.Clear()
+		return
+	}
+	for _, element := range drainHeap(heap, other) {
+		heap.Push(element)
+	}
+}
+
+// Returns true if heap does not contain any elements.
+func (heap *PairingHeap) Empty() bool {
+	return heap.size == 0
+}
+
+// Returns number of elements within the heap.
+func (heap *PairingHeap) Len() int {
+	return heap.size
+}
+
+// Removes all elements from the heap.
+func (heap *PairingHeap) Clear() {
+	heap.root = nil
+	heap.size = 0
+}
+
+// check if the elements are in the heap
+func (heap *PairingHeap) Contains(elements ...interface{}) bool {
+	return heapContains(heap, elements)
+}
+
+// Returns all elements in the order of the tree, parents before their children.
+func (heap *PairingHeap) Elements() []interface{} {
+	return heapElements(heap)
+}
+
+// Returns a stateful iterator over the heap, parents before their children, which is not sorted.
+// Key is the position of the element in the walk.
+func (heap *PairingHeap) Iterator() container.Iterator {
+	return &pairingHeapIterator{heap: heap, index: -1}
+}
+
+func (heap *PairingHeap) String() string {
+	return heapString("PairingHeap", heap)
+}
+
+// links two trees, the one with the larger root becomes the first child of the other
+func (heap *PairingHeap) meld(a, b *pairingNode) *pairingNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if heap.comparator(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// melds the siblings pairwise from left to right, then the pairs from right to left
+func (heap *PairingHeap) mergePairs(first *pairingNode) *pairingNode {
+	pairs := []*pairingNode{}
+	for node := first; node != nil; {
+		a, b := node, node.sibling
+		node = nil
+		if b != nil {
+			node = b.sibling
+			b.sibling = nil
+		}
+		a.sibling = nil
+		pairs = append(pairs, heap.meld(a, b))
+	}
+
+	var root *pairingNode
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = heap.meld(pairs[i], root)
+	}
+	return root
+}
+
+// pairingHeapIterator walks the tree depth first, keeping the nodes to visit on a stack.
+type pairingHeapIterator struct {
+	heap  *PairingHeap
+	stack []*pairingNode
+	node  *pairingNode
+	index int
+}
+
+func (it *pairingHeapIterator) Next() bool {
+	switch {
+	case it.index == -1:
+		it.stack = append(it.stack[:0], it.heap.root)
+	case it.node != nil:
+		it.stack = append(it.stack, it.node.sibling, it.node.child)
+	default:
+		return false
+	}
+	it.index++
+	it.node = nil
+	for it.node == nil && len(it.stack) > 0 {
+		it.node = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return it.node != nil
+}
+
+func (it *pairingHeapIterator) Value() interface{} {
+	return it.node.value
+}
+
+func (it *pairingHeapIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *pairingHeapIterator) Reset() {
+	it.stack, it.node, it.index = it.stack[:0], nil, -1
+}
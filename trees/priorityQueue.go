@@ -0,0 +1,239 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"strings"
+)
+
+// PriorityQueueHandle refers to a value pushed on a PriorityQueue,
+// it is used to change the value's priority or to remove it later.
+type PriorityQueueHandle struct {
+	value    interface{}
+	priority interface{}
+	index    int // position in the heap, -1 once popped or removed
+	queue    *PriorityQueue
+}
+
+// Returns the value the handle refers to.
+func (handle *PriorityQueueHandle) Value() interface{} {
+	return handle.value
+}
+
+// Returns the current priority of the value.
+func (handle *PriorityQueueHandle) Priority() interface{} {
+	return handle.priority
+}
+
+// PriorityQueue is a binary heap of values ordered by their priorities, e.g. for Dijkstra's algorithm.
+// The comparator compares priorities like BinaryHeap's compares elements, so the smallest one is on top.
+// Every value keeps its heap index in its handle, so Update and Remove are O(log n).
+type PriorityQueue struct {
+	handles    []*PriorityQueueHandle
+	comparator container.CompareFunction
+}
+
+func NewPriorityQueue(comparator container.CompareFunction) *PriorityQueue {
+	return &PriorityQueue{
+		comparator: comparator,
+	}
+}
+
+// Pushes a value with its priority, and returns the handle of the value.
+func (queue *PriorityQueue) Push(value interface{}, priority interface{}) *PriorityQueueHandle {
+	handle := &PriorityQueueHandle{value: value, priority: priority, index: len(queue.handles), queue: queue}
+	queue.handles = append(queue.handles, handle)
+	queue.bubbleUp(handle.index)
+	return handle
+}
+
+// Pops (removes) the value with the top priority and returns it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to pop.
+func (queue *PriorityQueue) Pop() (value interface{}, ok bool) {
+	if len(queue.handles) == 0 {
+		return nil, false
+	}
+	handle := queue.handles[0]
+	queue.remove(0)
+	return handle.value, true
+}
+
+// Returns the value with the top priority without removing it, or nil if queue is empty.
+// Second return parameter is true, unless the queue was empty and there was nothing to peek.
+func (queue *PriorityQueue) Peek() (value interface{}, ok bool) {
+	if len(queue.handles) == 0 {
+		return nil, false
+	}
+	return queue.handles[0].value, true
+}
+
+// Changes the priority of the handle's value, returns false if the value is no longer in the queue.
+// Raising and lowering the priority both work, so it serves as decrease-key as well.
+func (queue *PriorityQueue) Update(handle *PriorityQueueHandle, priority interface{}) bool {
+	if !queue.contain(handle) {
+		return false
+	}
+	handle.priority = priority
+	if !queue.bubbleUp(handle.index) {
+		queue.bubbleDown(handle.index)
+	}
+	return true
+}
+
+// Removes the handle's value, returns false if it was no longer in the queue.
+func (queue *PriorityQueue) Remove(handle *PriorityQueueHandle) bool {
+	if !queue.contain(handle) {
+		return false
+	}
+	queue.remove(handle.index)
+	return true
+}
+
+// Check if the handles (one or more) still refer to values in the queue, O(1) for each handle.
+// The elements of a PriorityQueue are looked up by handle, since values need not be unique.
+func (queue *PriorityQueue) Contains(handles ...interface{}) bool {
+	for _, handle := range handles {
+		handle, ok := handle.(*PriorityQueueHandle)
+		if !ok || !queue.contain(handle) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns true if queue does not contain any values.
+func (queue *PriorityQueue) Empty() bool {
+	return len(queue.handles) == 0
+}
+
+// Returns number of values within the queue.
+func (queue *PriorityQueue) Len() int {
+	return len(queue.handles)
+}
+
+// Removes all values from the queue, their handles are no longer contained.
+func (queue *PriorityQueue) Clear() {
+	for _, handle := range queue.handles {
+		handle.index = -1
+	}
+	queue.handles = nil
+}
+
+// Returns all values in the heap's internal array order.
+func (queue *PriorityQueue) Elements() []interface{} {
+	values := make([]interface{}, len(queue.handles))
+	for i, handle := range queue.handles {
+		values[i] = handle.value
+	}
+	return values
+}
+
+// Returns a stateful iterator over the values in the heap's internal array order, which is not sorted.
+// Key is the index, Value the value.
+func (queue *PriorityQueue) Iterator() container.Iterator {
+	return &priorityQueueIterator{queue: queue, index: -1}
+}
+
+func (queue *PriorityQueue) String() string {
+	str := "PriorityQueue{ "
+	values := []string{}
+	for _, handle := range queue.handles {
+		values = append(values, fmt.Sprintf("%v:%v", handle.value, handle.priority))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
+
+func (queue *PriorityQueue) contain(handle *PriorityQueueHandle) bool {
+	return handle != nil && handle.queue == queue && handle.index >= 0
+}
+
+// removes the handle at index, moving the last one into its place
+func (queue *PriorityQueue) remove(index int) {
+	lastIndex := len(queue.handles) - 1
+	queue.swap(index, lastIndex)
+	queue.handles[lastIndex].index = -1
+	queue.handles[lastIndex] = nil
+	queue.handles = queue.handles[:lastIndex]
+	if index < lastIndex && !queue.bubbleUp(index) {
+		queue.bubbleDown(index)
+	}
+}
+
+func (queue *PriorityQueue) less(i, j int) bool {
+	return queue.comparator(queue.handles[i].priority, queue.handles[j].priority) < 0
+}
+
+func (queue *PriorityQueue) swap(i, j int) {
+	queue.handles[i], queue.handles[j] = queue.handles[j], queue.handles[i]
+	queue.handles[i].index = i
+	queue.handles[j].index = j
+}
+
+// Moves the handle at index up to its place, returns true if it moved.
+func (queue *PriorityQueue) bubbleUp(index int) bool {
+	start := index
+	for index > 0 {
+		parentIndex := (index - 1) >> 1
+		if !queue.less(index, parentIndex) {
+			break
+		}
+		queue.swap(index, parentIndex)
+		index = parentIndex
+	}
+	return index != start
+}
+
+// Moves the handle at index down to its place.
+func (queue *PriorityQueue) bubbleDown(index int) {
+	size := len(queue.handles)
+	for leftIndex := index<<1 + 1; leftIndex < size; leftIndex = index<<1 + 1 {
+		smallerIndex := leftIndex
+		if rightIndex := leftIndex + 1; rightIndex < size && queue.less(rightIndex, leftIndex) {
+			smallerIndex = rightIndex
+		}
+		if !queue.less(smallerIndex, index) {
+			break
+		}
+		queue.swap(index, smallerIndex)
+		index = smallerIndex
+	}
+}
+
+type priorityQueueIterator struct {
+	queue *PriorityQueue
+	index int
+}
+
+func (it *priorityQueueIterator) Next() bool {
+	if it.index < len(it.queue.handles) {
+		it.index++
+	}
+	return it.index < len(it.queue.handles)
+}
+
+func (it *priorityQueueIterator) Value() interface{} {
+	return it.queue.handles[it.index].value
+}
+
+func (it *priorityQueueIterator) Key() interface{} {
+	return it.index
+}
+
+func (it *priorityQueueIterator) Reset() {
+	it.index = -1
+}
@@ -0,0 +1,128 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+
+	queue := NewPriorityQueue(container.IntCompareFunctionASC)
+
+	if actualValue, ok := queue.Pop(); actualValue != nil || ok {
+		t.Errorf("Got %v expected %v", actualValue, nil)
+	}
+
+	a := queue.Push("a", 5)
+	b := queue.Push("b", 3)
+	c := queue.Push("c", 8)
+	d := queue.Push("d", 1)
+
+	if actualValue, ok := queue.Peek(); actualValue != "d" || !ok {
+		t.Errorf("Got %v expected %v", actualValue, "d")
+	}
+
+	// decrease c to the top, then raise it back
+	if !queue.Update(c, 0) {
+		t.Errorf("Got %v expected %v", false, true)
+	}
+	if actualValue, _ := queue.Peek(); actualValue != "c" {
+		t.Errorf("Got %v expected %v", actualValue, "c")
+	}
+	queue.Update(c, 9)
+	if c.Priority() != 9 || c.Value() != "c" {
+		t.Errorf("Got %v expected %v", c.Priority(), 9)
+	}
+
+	if !queue.Remove(b) || queue.Remove(b) || queue.Contains(b) {
+		t.Errorf("Remove error, got %v", queue)
+	}
+	if !queue.Contains(a, c, d) || queue.Contains("a") || queue.Len() != 3 {
+		t.Errorf("Contains error, got %v", queue)
+	}
+
+	for _, expectedValue := range []string{"d", "a", "c"} {
+		if actualValue, ok := queue.Pop(); actualValue != expectedValue || !ok {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	if queue.Contains(a) || queue.Update(a, 1) || !queue.Empty() {
+		t.Errorf("Got %v expected %v", queue, "an empty queue")
+	}
+
+	other := NewPriorityQueue(container.IntCompareFunctionASC)
+	e := other.Push("e", 1)
+	if queue.Contains(e) || queue.Remove(e) {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+	other.Clear()
+	if other.Contains(e) {
+		t.Errorf("Got %v expected %v", true, false)
+	}
+}
+
+func TestPriorityQueueRandom(t *testing.T) {
+
+	queue := NewPriorityQueue(container.IntCompareFunctionASC)
+	random := rand.New(rand.NewSource(1))
+	handles := []*PriorityQueueHandle{}
+	for i := 0; i < 1000; i++ {
+		handles = append(handles, queue.Push(i, random.Intn(1000)))
+	}
+	for i := 0; i < 2000; i++ {
+		handle := handles[random.Intn(len(handles))]
+		if random.Intn(4) == 0 {
+			queue.Remove(handle)
+		} else {
+			queue.Update(handle, random.Intn(1000))
+		}
+	}
+
+	expected := []int{}
+	for _, handle := range handles {
+		if queue.Contains(handle) {
+			expected = append(expected, handle.Priority().(int))
+		}
+	}
+	slices.Sort(expected)
+	for _, expectedPriority := range expected {
+		value, _ := queue.Pop()
+		if actualValue := handles[value.(int)].Priority(); actualValue != expectedPriority {
+			t.Errorf("Got %v expected %v", actualValue, expectedPriority)
+		}
+	}
+	if !queue.Empty() {
+		t.Errorf("Got %v expected %v", queue.Len(), 0)
+	}
+}
+
+func BenchmarkPriorityQueue(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		queue := NewPriorityQueue(container.IntCompareFunctionASC)
+		handles := make([]*PriorityQueueHandle, 0, 1000)
+		for n := 0; n < 1000; n++ {
+			handles = append(handles, queue.Push(n, n))
+		}
+		for n, handle := range handles {
+			queue.Update(handle, -n)
+		}
+		for n := 0; n < 1000; n++ {
+			queue.Pop()
+		}
+	}
+}
@@ -21,13 +21,20 @@ const (
     black, red color = true, false
 )
 
+// the tree which may modify a node in place, the nodes owned by another tree are
+// shared with it and copied before any change, see RBTree.Snapshot
+type redBlackOwner struct {
+    _ byte // not zero-sized, so that every owner has its own address
+}
+
 type redBlackNode struct {
     key    interface{}
     value  interface{}
     color  color
     left   *redBlackNode
     right  *redBlackNode
-    parent *redBlackNode
+    size   int // number of nodes in the subtree rooted here
+    owner  *redBlackOwner
 }
 
 func (node *redBlackNode) maximumNode() *redBlackNode {
@@ -38,29 +45,12 @@ func (node *redBlackNode) maximumNode() *redBlackNode {
 }
 
 
-func (node *redBlackNode) grandparent() *redBlackNode {
-    if node != nil && node.parent != nil {
-        return node.parent.parent
-    }
-    return nil
-}
-
-func (node *redBlackNode) uncle() *redBlackNode {
-    if node == nil || node.parent == nil || node.parent.parent == nil {
-        return nil
-    }
-    return node.parent.sibling()
-}
-
-func (node *redBlackNode) sibling() *redBlackNode {
-    if node == nil || node.parent == nil {
-        return nil
-    }
-    if node == node.parent.left {
-        return node.parent.right
-    } else {
-        return node.parent.left
+// size of the subtree, 0 for nil
+func nodeSize(node *redBlackNode) int {
+    if node == nil {
+        return 0
     }
+    return node.size
 }
 
 func (node *redBlackNode) String() string {
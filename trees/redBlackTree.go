@@ -18,12 +18,17 @@ package trees
 import (
 	"github.com/aiwuTech/container"
 	"github.com/aiwuTech/container/stacks"
+	"iter"
 )
 
+// RBTree has no parent pointers, the writes walk down from the root keeping the path,
+// so that its nodes can be shared with the snapshots taken from it.
 type RBTree struct {
 	root       *redBlackNode
 	size       int
 	comparator container.CompareFunction
+	owner      *redBlackOwner // of the nodes it may modify in place, nil until the first snapshot
+	mods       int            // counts the writes, so that the iterators know when their path went stale
 }
 
 func NewRBTree(comparator container.CompareFunction) *RBTree {
@@ -35,38 +40,39 @@ func NewRBTree(comparator container.CompareFunction) *RBTree {
 // Inserts node into the tree.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *RBTree) Put(key interface{}, value interface{}) {
-	insertedNode := &redBlackNode{key: key, value: value, color: red}
+	tree.mods++
+	insertedNode := &redBlackNode{key: key, value: value, color: red, size: 1, owner: tree.owner}
 	if tree.root == nil {
+		insertedNode.color = black
 		tree.root = insertedNode
-	} else {
-		node := tree.root
-		loop := true
-		for loop {
-			compare := tree.comparator(key, node.key)
-			switch {
-			case compare == 0:
-				node.value = value
-				return
-			case compare < 0:
-				if node.left == nil {
-					node.left = insertedNode
-					loop = false
-				} else {
-					node = node.left
-				}
-			case compare > 0:
-				if node.right == nil {
-					node.right = insertedNode
-					loop = false
-				} else {
-					node = node.right
-				}
-			}
+		tree.size = 1
+		return
+	}
+
+	var buffer [64]*redBlackNode
+	path := append(buffer[:0], tree.own(&tree.root))
+	for {
+		node := path[len(path)-1]
+		compare := tree.comparator(key, node.key)
+		if compare == 0 {
+			node.value = value
+			return
 		}
-		insertedNode.parent = node
+		link := &node.right
+		if compare < 0 {
+			link = &node.left
+		}
+		if *link == nil {
+			*link = insertedNode
+			break
+		}
+		path = append(path, tree.own(link))
+	}
+	for _, node := range path {
+		node.size++
 	}
-	tree.insertCase1(insertedNode)
 	tree.size += 1
+	tree.insertFixup(append(path, insertedNode))
 }
 
 // Searches the node in the tree by key and returns its value or nil if key is not found in tree.
@@ -83,33 +89,71 @@ func (tree *RBTree) Get(key interface{}) (value interface{}, found bool) {
 // Remove the node from the tree by key.
 // Key should adhere to the comparator's type assertion, otherwise method panics.
 func (tree *RBTree) Remove(key interface{}) {
-	var child *redBlackNode
-	node := tree.lookup(key)
-	if node == nil {
+	if tree.lookup(key) == nil {
 		return
 	}
+	tree.mods++
+
+	var buffer [64]*redBlackNode
+	path := append(buffer[:0], tree.own(&tree.root))
+	for {
+		node := path[len(path)-1]
+		compare := tree.comparator(key, node.key)
+		if compare == 0 {
+			break
+		}
+		if compare < 0 {
+			path = append(path, tree.own(&node.left))
+		} else {
+			path = append(path, tree.own(&node.right))
+		}
+	}
+	node := path[len(path)-1]
 	if node.left != nil && node.right != nil {
-		pred := node.left.maximumNode()
+		// the predecessor takes the place of the key, and is removed instead
+		path = append(path, tree.own(&node.left))
+		for path[len(path)-1].right != nil {
+			path = append(path, tree.own(&path[len(path)-1].right))
+		}
+		pred := path[len(path)-1]
 		node.key = pred.key
 		node.value = pred.value
 		node = pred
 	}
-	if node.left == nil || node.right == nil {
-		if node.right == nil {
-			child = node.left
+
+	// node has one child at most, which takes its place
+	child := node.left
+	if child == nil {
+		child = node.right
+	}
+	link := tree.link(path, len(path)-1)
+	*link = child
+	path = path[:len(path)-1]
+	for _, parent := range path {
+		parent.size--
+	}
+	tree.size -= 1
+	if node.color == black {
+		if nodeColor(child) == red {
+			tree.own(link).color = black
 		} else {
-			child = node.right
-		}
-		if node.color == black {
-			node.color = nodeColor(child)
-			tree.deleteCase1(node)
-		}
-		tree.replaceNode(node, child)
-		if node.parent == nil && child != nil {
-			child.color = black
+			tree.deleteFixup(path, link)
 		}
 	}
-	tree.size -= 1
+}
+
+// Returns a snapshot of the tree in O(1): the two trees share all their nodes, and
+// copy the ones they modify afterwards, so that neither sees the later writes of the
+// other. The snapshot is an RBTree as well, it may be read or written while the tree is,
+// without any synchronization as long as each of them is only used by one goroutine.
+func (tree *RBTree) Snapshot() *RBTree {
+	tree.owner = &redBlackOwner{}
+	return &RBTree{
+		root:       tree.root,
+		size:       tree.size,
+		comparator: tree.comparator,
+		owner:      &redBlackOwner{},
+	}
 }
 
 // Returns true if tree does not contain any nodes
@@ -144,6 +188,139 @@ func (tree *RBTree) Elements() []interface{} {
 func (tree *RBTree) Clear() {
 	tree.root = nil
 	tree.size = 0
+	tree.mods++
+}
+
+// Returns the smallest key and its value, third return parameter is false if the tree is empty.
+func (tree *RBTree) Min() (key interface{}, value interface{}, found bool) {
+	return nodeEntry(minimumNode(tree.root))
+}
+
+// Returns the largest key and its value, third return parameter is false if the tree is empty.
+func (tree *RBTree) Max() (key interface{}, value interface{}, found bool) {
+	if tree.root == nil {
+		return nil, nil, false
+	}
+	return nodeEntry(tree.root.maximumNode())
+}
+
+// Returns the largest key <= the given key and its value.
+// Third return parameter is false if there is no such key.
+func (tree *RBTree) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	return nodeEntry(tree.floorNode(key))
+}
+
+// Returns the smallest key >= the given key and its value.
+// Third return parameter is false if there is no such key.
+func (tree *RBTree) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	return nodeEntry(tree.ceilingNode(key))
+}
+
+// Returns the largest key < the given key and its value.
+// Third return parameter is false if there is no such key.
+func (tree *RBTree) Lower(key interface{}) (lowerKey interface{}, value interface{}, found bool) {
+	return nodeEntry(tree.lowerNode(key))
+}
+
+// Returns the smallest key > the given key and its value.
+// Third return parameter is false if there is no such key.
+func (tree *RBTree) Higher(key interface{}) (higherKey interface{}, value interface{}, found bool) {
+	return nodeEntry(tree.higherNode(key))
+}
+
+// Removes the smallest key and returns it with its value, third return parameter is false if the tree is empty.
+func (tree *RBTree) PollMin() (key interface{}, value interface{}, found bool) {
+	key, value, found = tree.Min()
+	if found {
+		tree.Remove(key)
+	}
+	return
+}
+
+// Removes the largest key and returns it with its value, third return parameter is false if the tree is empty.
+func (tree *RBTree) PollMax() (key interface{}, value interface{}, found bool) {
+	key, value, found = tree.Max()
+	if found {
+		tree.Remove(key)
+	}
+	return
+}
+
+// Returns the number of keys smaller than the given key, i.e. the index the key has,
+// or would have if it was put, in the tree's key order.
+func (tree *RBTree) Rank(key interface{}) int {
+	rank := 0
+	node := tree.root
+	for node != nil {
+		compare := tree.comparator(key, node.key)
+		switch {
+		case compare == 0:
+			return rank + nodeSize(node.left)
+		case compare < 0:
+			node = node.left
+		case compare > 0:
+			rank += nodeSize(node.left) + 1
+			node = node.right
+		}
+	}
+	return rank
+}
+
+// Returns the i-th smallest key (counting from 0) and its value.
+// Third return parameter is false if i is out of range.
+func (tree *RBTree) Select(i int) (key interface{}, value interface{}, found bool) {
+	if i < 0 || i >= tree.size {
+		return nil, nil, false
+	}
+	node := tree.root
+	for {
+		leftSize := nodeSize(node.left)
+		switch {
+		case i < leftSize:
+			node = node.left
+		case i > leftSize:
+			i -= leftSize + 1
+			node = node.right
+		default:
+			return node.key, node.value, true
+		}
+	}
+}
+
+// Returns the number of keys in [from, to).
+func (tree *RBTree) CountRange(from, to interface{}) int {
+	if count := tree.Rank(to) - tree.Rank(from); count > 0 {
+		return count
+	}
+	return 0
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order, with keys between from and to.
+// The inclusive flags tell whether from and to themselves belong to the range, a nil bound means unbounded.
+// Like All, the loop body may write the tree.
+func (tree *RBTree) Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		var path redBlackPath
+		mods := tree.mods
+		if from == nil {
+			path.first(tree.root)
+		} else {
+			path.seek(tree, from, fromInclusive)
+		}
+		for len(path) > 0 {
+			key, value := path.node().key, path.node().value
+			if to != nil {
+				compare := tree.comparator(key, to)
+				if compare > 0 || compare == 0 && !toInclusive {
+					return
+				}
+			}
+			if !yield(key, value) {
+				return
+			}
+			path.nextAfter(tree, key, &mods)
+		}
+	}
 }
 
 func (tree *RBTree) String() string {
@@ -201,87 +378,6 @@ func (tree *RBTree) inOrder() []*redBlackNode {
 	return nodes
 }
 
-func (tree *RBTree) deleteCase1(node *redBlackNode) {
-	if node.parent == nil {
-		return
-	} else {
-		tree.deleteCase2(node)
-	}
-}
-
-func (tree *RBTree) deleteCase2(node *redBlackNode) {
-	sibling := node.sibling()
-	if nodeColor(sibling) == red {
-		node.parent.color = red
-		sibling.color = black
-		if node == node.parent.left {
-			tree.rotateLeft(node.parent)
-		} else {
-			tree.rotateRight(node.parent)
-		}
-	}
-	tree.deleteCase3(node)
-}
-
-func (tree *RBTree) deleteCase3(node *redBlackNode) {
-	sibling := node.sibling()
-	if nodeColor(node.parent) == black &&
-		nodeColor(sibling) == black &&
-		nodeColor(sibling.left) == black &&
-		nodeColor(sibling.right) == black {
-		sibling.color = red
-		tree.deleteCase1(node.parent)
-	} else {
-		tree.deleteCase4(node)
-	}
-}
-
-func (tree *RBTree) deleteCase4(node *redBlackNode) {
-	sibling := node.sibling()
-	if nodeColor(node.parent) == red &&
-		nodeColor(sibling) == black &&
-		nodeColor(sibling.left) == black &&
-		nodeColor(sibling.right) == black {
-		sibling.color = red
-		node.parent.color = black
-	} else {
-		tree.deleteCase5(node)
-	}
-}
-
-func (tree *RBTree) deleteCase5(node *redBlackNode) {
-	sibling := node.sibling()
-	if node == node.parent.left &&
-		nodeColor(sibling) == black &&
-		nodeColor(sibling.left) == red &&
-		nodeColor(sibling.right) == black {
-		sibling.color = red
-		sibling.left.color = black
-		tree.rotateRight(sibling)
-	} else if node == node.parent.right &&
-		nodeColor(sibling) == black &&
-		nodeColor(sibling.right) == red &&
-		nodeColor(sibling.left) == black {
-		sibling.color = red
-		sibling.right.color = black
-		tree.rotateLeft(sibling)
-	}
-	tree.deleteCase6(node)
-}
-
-func (tree *RBTree) deleteCase6(node *redBlackNode) {
-	sibling := node.sibling()
-	sibling.color = nodeColor(node.parent)
-	node.parent.color = black
-	if node == node.parent.left && nodeColor(sibling.right) == red {
-		sibling.right.color = black
-		tree.rotateLeft(node.parent)
-	} else if nodeColor(sibling.left) == red {
-		sibling.left.color = black
-		tree.rotateRight(node.parent)
-	}
-}
-
 func (tree *RBTree) lookup(key interface{}) *redBlackNode {
 	node := tree.root
 	for node != nil {
@@ -298,93 +394,230 @@ func (tree *RBTree) lookup(key interface{}) *redBlackNode {
 	return nil
 }
 
-func (tree *RBTree) insertCase1(node *redBlackNode) {
-	if node.parent == nil {
-		node.color = black
-	} else {
-		tree.insertCase2(node)
+// the node with the largest key <= key, or nil
+func (tree *RBTree) floorNode(key interface{}) *redBlackNode {
+	var found *redBlackNode
+	for node := tree.root; node != nil; {
+		compare := tree.comparator(key, node.key)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			node = node.left
+		case compare > 0:
+			found, node = node, node.right
+		}
 	}
+	return found
 }
 
-func (tree *RBTree) insertCase2(node *redBlackNode) {
-	if nodeColor(node.parent) == black {
-		return
+// the node with the smallest key >= key, or nil
+func (tree *RBTree) ceilingNode(key interface{}) *redBlackNode {
+	var found *redBlackNode
+	for node := tree.root; node != nil; {
+		compare := tree.comparator(key, node.key)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			found, node = node, node.left
+		case compare > 0:
+			node = node.right
+		}
 	}
-	tree.insertCase3(node)
+	return found
 }
 
-func (tree *RBTree) insertCase3(node *redBlackNode) {
-	uncle := node.uncle()
-	if nodeColor(uncle) == red {
-		node.parent.color = black
-		uncle.color = black
-		node.grandparent().color = red
-		tree.insertCase1(node.grandparent())
-	} else {
-		tree.insertCase4(node)
+// the node with the largest key < key, or nil
+func (tree *RBTree) lowerNode(key interface{}) *redBlackNode {
+	var found *redBlackNode
+	for node := tree.root; node != nil; {
+		if tree.comparator(key, node.key) > 0 {
+			found, node = node, node.right
+		} else {
+			node = node.left
+		}
 	}
+	return found
 }
 
-func (tree *RBTree) insertCase4(node *redBlackNode) {
-	grandparent := node.grandparent()
-	if node == node.parent.right && node.parent == grandparent.left {
-		tree.rotateLeft(node.parent)
-		node = node.left
-	} else if node == node.parent.left && node.parent == grandparent.right {
-		tree.rotateRight(node.parent)
-		node = node.right
+// the node with the smallest key > key, or nil
+func (tree *RBTree) higherNode(key interface{}) *redBlackNode {
+	var found *redBlackNode
+	for node := tree.root; node != nil; {
+		if tree.comparator(key, node.key) < 0 {
+			found, node = node, node.left
+		} else {
+			node = node.right
+		}
 	}
-	tree.insertCase5(node)
+	return found
 }
 
-func (tree *RBTree) insertCase5(node *redBlackNode) {
-	node.parent.color = black
-	grandparent := node.grandparent()
-	grandparent.color = red
-	if node == node.parent.left && node.parent == grandparent.left {
-		tree.rotateRight(grandparent)
-	} else if node == node.parent.right && node.parent == grandparent.right {
-		tree.rotateLeft(grandparent)
+func nodeEntry(node *redBlackNode) (key interface{}, value interface{}, found bool) {
+	if node == nil {
+		return nil, nil, false
 	}
+	return node.key, node.value, true
 }
 
-func (tree *RBTree) rotateLeft(node *redBlackNode) {
-	right := node.right
-	tree.replaceNode(node, right)
-	node.right = right.left
-	if right.left != nil {
-		right.left.parent = node
+// returns the node *link points to, after replacing it with a copy if the tree does not
+// own it; the node holding link must be owned already
+func (tree *RBTree) own(link **redBlackNode) *redBlackNode {
+	node := *link
+	if node != nil && node.owner != tree.owner {
+		clone := *node
+		clone.owner = tree.owner
+		node = &clone
+		*link = node
 	}
-	right.left = node
-	node.parent = right
+	return node
 }
 
-func (tree *RBTree) rotateRight(node *redBlackNode) {
-	left := node.left
-	tree.replaceNode(node, left)
-	node.left = left.right
-	if left.right != nil {
-		left.right.parent = node
+// the link to path[i] from its parent path[i-1], or from the root
+func (tree *RBTree) link(path []*redBlackNode, i int) **redBlackNode {
+	if i == 0 {
+		return &tree.root
+	}
+	if parent := path[i-1]; parent.left == path[i] {
+		return &parent.left
+	} else {
+		return &parent.right
 	}
-	left.right = node
-	node.parent = left
 }
 
-func (tree *RBTree) replaceNode(old *redBlackNode, new *redBlackNode) {
-	if old.parent == nil {
-		tree.root = new
-	} else {
-		if old == old.parent.left {
-			old.parent.left = new
+// restores the colors after inserting the last node of path, all the nodes of path are owned
+func (tree *RBTree) insertFixup(path []*redBlackNode) {
+	for i := len(path) - 1; ; i -= 2 {
+		node := path[i]
+		if i == 0 {
+			node.color = black
+			return
+		}
+		parent := path[i-1]
+		if parent.color == black {
+			return
+		}
+		// a red parent is not the root
+		grandparent := path[i-2]
+		uncleLink := &grandparent.left
+		if parent == grandparent.left {
+			uncleLink = &grandparent.right
+		}
+		if nodeColor(*uncleLink) == red {
+			parent.color = black
+			tree.own(uncleLink).color = black
+			grandparent.color = red
+			continue
+		}
+
+		if parent == grandparent.left {
+			if node == parent.right {
+				tree.rotateLeft(parent, &grandparent.left)
+				parent = node
+			}
+			parent.color = black
+			grandparent.color = red
+			tree.rotateRight(grandparent, tree.link(path, i-2))
 		} else {
-			old.parent.right = new
+			if node == parent.left {
+				tree.rotateRight(parent, &grandparent.right)
+				parent = node
+			}
+			parent.color = black
+			grandparent.color = red
+			tree.rotateLeft(grandparent, tree.link(path, i-2))
 		}
+		return
 	}
-	if new != nil {
-		new.parent = old.parent
+}
+
+// restores the colors after a black node was removed below the last node of path,
+// *link being the subtree short of one black node; all the nodes of path are owned
+func (tree *RBTree) deleteFixup(path []*redBlackNode, link **redBlackNode) {
+	for len(path) > 0 {
+		parent := path[len(path)-1]
+		if link == &parent.left {
+			sibling := tree.own(&parent.right)
+			if sibling.color == red {
+				sibling.color = black
+				parent.color = red
+				tree.rotateLeft(parent, tree.link(path, len(path)-1))
+				path = append(path[:len(path)-1], sibling, parent)
+				sibling = tree.own(&parent.right)
+			}
+			if nodeColor(sibling.left) == black && nodeColor(sibling.right) == black {
+				sibling.color = red
+				if parent.color == red {
+					parent.color = black
+					return
+				}
+				link, path = tree.link(path, len(path)-1), path[:len(path)-1]
+				continue
+			}
+			if nodeColor(sibling.right) == black {
+				tree.own(&sibling.left).color = black
+				sibling.color = red
+				tree.rotateRight(sibling, &parent.right)
+				sibling = parent.right
+			}
+			sibling.color = parent.color
+			parent.color = black
+			tree.own(&sibling.right).color = black
+			tree.rotateLeft(parent, tree.link(path, len(path)-1))
+		} else {
+			sibling := tree.own(&parent.left)
+			if sibling.color == red {
+				sibling.color = black
+				parent.color = red
+				tree.rotateRight(parent, tree.link(path, len(path)-1))
+				path = append(path[:len(path)-1], sibling, parent)
+				sibling = tree.own(&parent.left)
+			}
+			if nodeColor(sibling.left) == black && nodeColor(sibling.right) == black {
+				sibling.color = red
+				if parent.color == red {
+					parent.color = black
+					return
+				}
+				link, path = tree.link(path, len(path)-1), path[:len(path)-1]
+				continue
+			}
+			if nodeColor(sibling.left) == black {
+				tree.own(&sibling.right).color = black
+				sibling.color = red
+				tree.rotateLeft(sibling, &parent.left)
+				sibling = parent.left
+			}
+			sibling.color = parent.color
+			parent.color = black
+			tree.own(&sibling.left).color = black
+			tree.rotateRight(parent, tree.link(path, len(path)-1))
+		}
+		return
 	}
 }
 
+// rotates the owned node at *link with its right child, which becomes owned
+func (tree *RBTree) rotateLeft(node *redBlackNode, link **redBlackNode) {
+	right := tree.own(&node.right)
+	node.right = right.left
+	right.left = node
+	*link = right
+	right.size = node.size
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
+}
+
+// rotates the owned node at *link with its left child, which becomes owned
+func (tree *RBTree) rotateRight(node *redBlackNode, link **redBlackNode) {
+	left := tree.own(&node.left)
+	node.left = left.right
+	left.right = node
+	*link = left
+	left.size = node.size
+	node.size = nodeSize(node.left) + nodeSize(node.right) + 1
+}
+
 func nodeColor(node *redBlackNode) color {
 	if node == nil {
 		return black
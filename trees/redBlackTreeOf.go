@@ -0,0 +1,528 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"iter"
+)
+
+type redBlackNodeOf[K any, V any] struct {
+	key    K
+	value  V
+	color  color
+	left   *redBlackNodeOf[K, V]
+	right  *redBlackNodeOf[K, V]
+	parent *redBlackNodeOf[K, V]
+}
+
+func (node *redBlackNodeOf[K, V]) maximumNode() *redBlackNodeOf[K, V] {
+	for node.right != nil {
+		node = node.right
+	}
+	return node
+}
+
+func (node *redBlackNodeOf[K, V]) grandparent() *redBlackNodeOf[K, V] {
+	if node != nil && node.parent != nil {
+		return node.parent.parent
+	}
+	return nil
+}
+
+func (node *redBlackNodeOf[K, V]) uncle() *redBlackNodeOf[K, V] {
+	if node == nil || node.parent == nil || node.parent.parent == nil {
+		return nil
+	}
+	return node.parent.sibling()
+}
+
+func (node *redBlackNodeOf[K, V]) sibling() *redBlackNodeOf[K, V] {
+	if node == nil || node.parent == nil {
+		return nil
+	}
+	if node == node.parent.left {
+		return node.parent.right
+	}
+	return node.parent.left
+}
+
+func (node *redBlackNodeOf[K, V]) minimumNode() *redBlackNodeOf[K, V] {
+	for node.left != nil {
+		node = node.left
+	}
+	return node
+}
+
+// next node in key order, or nil
+func (node *redBlackNodeOf[K, V]) successor() *redBlackNodeOf[K, V] {
+	if node.right != nil {
+		return node.right.minimumNode()
+	}
+	for node.parent != nil && node == node.parent.right {
+		node = node.parent
+	}
+	return node.parent
+}
+
+// previous node in key order, or nil
+func (node *redBlackNodeOf[K, V]) predecessor() *redBlackNodeOf[K, V] {
+	if node.left != nil {
+		return node.left.maximumNode()
+	}
+	for node.parent != nil && node == node.parent.left {
+		node = node.parent
+	}
+	return node.parent
+}
+
+func nodeColorOf[K any, V any](node *redBlackNodeOf[K, V]) color {
+	if node == nil {
+		return black
+	}
+	return node.color
+}
+
+// RBTreeOf is the type-parameterized version of RBTree
+type RBTreeOf[K any, V any] struct {
+	root       *redBlackNodeOf[K, V]
+	size       int
+	comparator container.Comparator[K]
+}
+
+func NewRBTreeOf[K any, V any](comparator container.Comparator[K]) *RBTreeOf[K, V] {
+	return &RBTreeOf[K, V]{
+		comparator: comparator,
+	}
+}
+
+// Inserts node into the tree, overwriting the value if the key is already present.
+func (tree *RBTreeOf[K, V]) Put(key K, value V) {
+	insertedNode := &redBlackNodeOf[K, V]{key: key, value: value, color: red}
+	if tree.root == nil {
+		tree.root = insertedNode
+	} else {
+		node := tree.root
+		loop := true
+		for loop {
+			compare := tree.comparator(key, node.key)
+			switch {
+			case compare == 0:
+				node.value = value
+				return
+			case compare < 0:
+				if node.left == nil {
+					node.left = insertedNode
+					loop = false
+				} else {
+					node = node.left
+				}
+			case compare > 0:
+				if node.right == nil {
+					node.right = insertedNode
+					loop = false
+				} else {
+					node = node.right
+				}
+			}
+		}
+		insertedNode.parent = node
+	}
+	tree.insertCase1(insertedNode)
+	tree.size += 1
+}
+
+// Searches the node in the tree by key and returns its value or zero value if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+func (tree *RBTreeOf[K, V]) Get(key K) (value V, found bool) {
+	node := tree.lookup(key)
+	if node != nil {
+		return node.value, true
+	}
+	return value, false
+}
+
+// Remove the node from the tree by key.
+func (tree *RBTreeOf[K, V]) Remove(key K) {
+	var child *redBlackNodeOf[K, V]
+	node := tree.lookup(key)
+	if node == nil {
+		return
+	}
+	if node.left != nil && node.right != nil {
+		pred := node.left.maximumNode()
+		node.key = pred.key
+		node.value = pred.value
+		node = pred
+	}
+	if node.right == nil {
+		child = node.left
+	} else {
+		child = node.right
+	}
+	if node.color == black {
+		node.color = nodeColorOf(child)
+		tree.deleteCase1(node)
+	}
+	tree.replaceNode(node, child)
+	if node.parent == nil && child != nil {
+		child.color = black
+	}
+	tree.size -= 1
+}
+
+// Returns true if the key is in the tree
+func (tree *RBTreeOf[K, V]) ContainsKey(key K) bool {
+	return tree.lookup(key) != nil
+}
+
+// Returns the smallest key and its value, third return parameter is false if the tree is empty.
+func (tree *RBTreeOf[K, V]) Min() (key K, value V, ok bool) {
+	if tree.root == nil {
+		return
+	}
+	node := tree.root.minimumNode()
+	return node.key, node.value, true
+}
+
+// Returns the largest key and its value, third return parameter is false if the tree is empty.
+func (tree *RBTreeOf[K, V]) Max() (key K, value V, ok bool) {
+	if tree.root == nil {
+		return
+	}
+	node := tree.root.maximumNode()
+	return node.key, node.value, true
+}
+
+// Returns true if tree does not contain any nodes
+func (tree *RBTreeOf[K, V]) Empty() bool {
+	return tree.Len() == 0
+}
+
+// Returns number of nodes in the tree.
+func (tree *RBTreeOf[K, V]) Len() int {
+	return tree.size
+}
+
+// Returns all keys in-order
+func (tree *RBTreeOf[K, V]) Keys() []K {
+	keys := make([]K, 0, tree.size)
+	tree.inOrder(func(node *redBlackNodeOf[K, V]) {
+		keys = append(keys, node.key)
+	})
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (tree *RBTreeOf[K, V]) Elements() []V {
+	values := make([]V, 0, tree.size)
+	tree.inOrder(func(node *redBlackNodeOf[K, V]) {
+		values = append(values, node.value)
+	})
+	return values
+}
+
+// Removes all nodes from the tree.
+func (tree *RBTreeOf[K, V]) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in key order.
+func (tree *RBTreeOf[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if tree.root == nil {
+			return
+		}
+		for node := tree.root.minimumNode(); node != nil; node = node.successor() {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of (key, value) pairs in reverse key order.
+func (tree *RBTreeOf[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if tree.root == nil {
+			return
+		}
+		for node := tree.root.maximumNode(); node != nil; node = node.predecessor() {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the keys in order.
+// It is not named Keys, which already returns a slice.
+func (tree *RBTreeOf[K, V]) KeySeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range tree.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a range-over-func sequence of the values in key order.
+func (tree *RBTreeOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range tree.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (tree *RBTreeOf[K, V]) String() string {
+	str := "RedBlackTree\n"
+	if !tree.Empty() {
+		outputOf(tree.root, "", true, &str)
+	}
+	return str
+}
+
+// visits all nodes in order
+func (tree *RBTreeOf[K, V]) inOrder(visit func(node *redBlackNodeOf[K, V])) {
+	stack := make([]*redBlackNodeOf[K, V], 0)
+	current := tree.root
+	for current != nil || len(stack) > 0 {
+		if current != nil {
+			stack = append(stack, current)
+			current = current.left
+			continue
+		}
+		current = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		visit(current)
+		current = current.right
+	}
+}
+
+func (tree *RBTreeOf[K, V]) lookup(key K) *redBlackNodeOf[K, V] {
+	node := tree.root
+	for node != nil {
+		compare := tree.comparator(key, node.key)
+		switch {
+		case compare == 0:
+			return node
+		case compare < 0:
+			node = node.left
+		case compare > 0:
+			node = node.right
+		}
+	}
+	return nil
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase1(node *redBlackNodeOf[K, V]) {
+	if node.parent == nil {
+		return
+	}
+	tree.deleteCase2(node)
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase2(node *redBlackNodeOf[K, V]) {
+	sibling := node.sibling()
+	if nodeColorOf(sibling) == red {
+		node.parent.color = red
+		sibling.color = black
+		if node == node.parent.left {
+			tree.rotateLeft(node.parent)
+		} else {
+			tree.rotateRight(node.parent)
+		}
+	}
+	tree.deleteCase3(node)
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase3(node *redBlackNodeOf[K, V]) {
+	sibling := node.sibling()
+	if nodeColorOf(node.parent) == black &&
+		nodeColorOf(sibling) == black &&
+		nodeColorOf(sibling.left) == black &&
+		nodeColorOf(sibling.right) == black {
+		sibling.color = red
+		tree.deleteCase1(node.parent)
+	} else {
+		tree.deleteCase4(node)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase4(node *redBlackNodeOf[K, V]) {
+	sibling := node.sibling()
+	if nodeColorOf(node.parent) == red &&
+		nodeColorOf(sibling) == black &&
+		nodeColorOf(sibling.left) == black &&
+		nodeColorOf(sibling.right) == black {
+		sibling.color = red
+		node.parent.color = black
+	} else {
+		tree.deleteCase5(node)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase5(node *redBlackNodeOf[K, V]) {
+	sibling := node.sibling()
+	if node == node.parent.left &&
+		nodeColorOf(sibling) == black &&
+		nodeColorOf(sibling.left) == red &&
+		nodeColorOf(sibling.right) == black {
+		sibling.color = red
+		sibling.left.color = black
+		tree.rotateRight(sibling)
+	} else if node == node.parent.right &&
+		nodeColorOf(sibling) == black &&
+		nodeColorOf(sibling.right) == red &&
+		nodeColorOf(sibling.left) == black {
+		sibling.color = red
+		sibling.right.color = black
+		tree.rotateLeft(sibling)
+	}
+	tree.deleteCase6(node)
+}
+
+func (tree *RBTreeOf[K, V]) deleteCase6(node *redBlackNodeOf[K, V]) {
+	sibling := node.sibling()
+	sibling.color = nodeColorOf(node.parent)
+	node.parent.color = black
+	if node == node.parent.left && nodeColorOf(sibling.right) == red {
+		sibling.right.color = black
+		tree.rotateLeft(node.parent)
+	} else if nodeColorOf(sibling.left) == red {
+		sibling.left.color = black
+		tree.rotateRight(node.parent)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) insertCase1(node *redBlackNodeOf[K, V]) {
+	if node.parent == nil {
+		node.color = black
+	} else {
+		tree.insertCase2(node)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) insertCase2(node *redBlackNodeOf[K, V]) {
+	if nodeColorOf(node.parent) == black {
+		return
+	}
+	tree.insertCase3(node)
+}
+
+func (tree *RBTreeOf[K, V]) insertCase3(node *redBlackNodeOf[K, V]) {
+	uncle := node.uncle()
+	if nodeColorOf(uncle) == red {
+		node.parent.color = black
+		uncle.color = black
+		node.grandparent().color = red
+		tree.insertCase1(node.grandparent())
+	} else {
+		tree.insertCase4(node)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) insertCase4(node *redBlackNodeOf[K, V]) {
+	grandparent := node.grandparent()
+	if node == node.parent.right && node.parent == grandparent.left {
+		tree.rotateLeft(node.parent)
+		node = node.left
+	} else if node == node.parent.left && node.parent == grandparent.right {
+		tree.rotateRight(node.parent)
+		node = node.right
+	}
+	tree.insertCase5(node)
+}
+
+func (tree *RBTreeOf[K, V]) insertCase5(node *redBlackNodeOf[K, V]) {
+	node.parent.color = black
+	grandparent := node.grandparent()
+	grandparent.color = red
+	if node == node.parent.left && node.parent == grandparent.left {
+		tree.rotateRight(grandparent)
+	} else if node == node.parent.right && node.parent == grandparent.right {
+		tree.rotateLeft(grandparent)
+	}
+}
+
+func (tree *RBTreeOf[K, V]) rotateLeft(node *redBlackNodeOf[K, V]) {
+	right := node.right
+	tree.replaceNode(node, right)
+	node.right = right.left
+	if right.left != nil {
+		right.left.parent = node
+	}
+	right.left = node
+	node.parent = right
+}
+
+func (tree *RBTreeOf[K, V]) rotateRight(node *redBlackNodeOf[K, V]) {
+	left := node.left
+	tree.replaceNode(node, left)
+	node.left = left.right
+	if left.right != nil {
+		left.right.parent = node
+	}
+	left.right = node
+	node.parent = left
+}
+
+func (tree *RBTreeOf[K, V]) replaceNode(old *redBlackNodeOf[K, V], new *redBlackNodeOf[K, V]) {
+	if old.parent == nil {
+		tree.root = new
+	} else {
+		if old == old.parent.left {
+			old.parent.left = new
+		} else {
+			old.parent.right = new
+		}
+	}
+	if new != nil {
+		new.parent = old.parent
+	}
+}
+
+func outputOf[K any, V any](node *redBlackNodeOf[K, V], prefix string, isTail bool, str *string) {
+	if node.right != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "│   "
+		} else {
+			newPrefix += "    "
+		}
+		outputOf(node.right, newPrefix, false, str)
+	}
+	*str += prefix
+	if isTail {
+		*str += "└── "
+	} else {
+		*str += "┌── "
+	}
+	*str += fmt.Sprintf("%v", node.key) + "\n"
+	if node.left != nil {
+		newPrefix := prefix
+		if isTail {
+			newPrefix += "    "
+		} else {
+			newPrefix += "│   "
+		}
+		outputOf(node.left, newPrefix, true, str)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestRedBlackTreeOf(t *testing.T) {
+
+	tree := NewRBTreeOf[int, string](container.OrderedCompareFunctionASC[int])
+
+	// insertions
+	tree.Put(5, "e")
+	tree.Put(6, "f")
+	tree.Put(7, "g")
+	tree.Put(3, "c")
+	tree.Put(4, "d")
+	tree.Put(1, "x")
+	tree.Put(2, "b")
+	tree.Put(1, "a") //overwrite
+
+	if actualValue := tree.Len(); actualValue != 7 {
+		t.Errorf("Got %v expected %v", actualValue, 7)
+	}
+
+	if actualValue, expactedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4 5 6 7]"; actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+	if actualValue, expactedValue := fmt.Sprint(tree.Elements()), "[a b c d e f g]"; actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+	if actualValue, found := tree.Get(4); actualValue != "d" || !found {
+		t.Errorf("Got %v expected %v", actualValue, "d")
+	}
+
+	if actualValue, found := tree.Get(8); actualValue != "" || found {
+		t.Errorf("Got %v expected %v", actualValue, "")
+	}
+
+	if key, value, ok := tree.Min(); key != 1 || value != "a" || !ok {
+		t.Errorf("Got %v expected %v", key, 1)
+	}
+
+	if key, value, ok := tree.Max(); key != 7 || value != "g" || !ok {
+		t.Errorf("Got %v expected %v", key, 7)
+	}
+
+	// removals
+	tree.Remove(5)
+	tree.Remove(6)
+	tree.Remove(7)
+	tree.Remove(8)
+	tree.Remove(5)
+
+	if actualValue, expactedValue := fmt.Sprint(tree.Keys()), "[1 2 3 4]"; actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+	if actualValue := tree.ContainsKey(5); actualValue != false {
+		t.Errorf("Got %v expected %v", actualValue, false)
+	}
+
+	tree.Clear()
+
+	if actualValue := tree.Empty(); actualValue != true {
+		t.Errorf("Got %v expected %v", actualValue, true)
+	}
+
+	// random insertions and removals against a reference map
+	rand.Seed(3)
+	reference := make(map[int]bool)
+	for i := 0; i < 10000; i++ {
+		r := int(rand.Int31n(500))
+		if rand.Intn(3) == 0 {
+			tree.Remove(r)
+			delete(reference, r)
+		} else {
+			tree.Put(r, "")
+			reference[r] = true
+		}
+	}
+
+	expected := make([]int, 0, len(reference))
+	for key := range reference {
+		expected = append(expected, key)
+	}
+	sort.Ints(expected)
+	if actualValue, expactedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(expected); actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+}
+
+func BenchmarkRedBlackTreeOf(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		tree := NewRBTreeOf[int, int](container.OrderedCompareFunctionASC[int])
+		for n := 0; n < 1000; n++ {
+			tree.Put(n, n)
+		}
+		for n := 0; n < 1000; n++ {
+			tree.Remove(n)
+		}
+	}
+}
+
+func TestRedBlackTreeOfSeq(t *testing.T) {
+
+	tree := NewRBTreeOf[int, string](container.OrderedCompareFunctionASC[int])
+	for i, value := range []string{"a", "b", "c", "d"} {
+		tree.Put(i, value)
+	}
+
+	if actualValue, expactedValue := fmt.Sprint(slices.Collect(tree.KeySeq())), "[0 1 2 3]"; actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+	if actualValue, expactedValue := fmt.Sprint(slices.Collect(tree.Values())), "[a b c d]"; actualValue != expactedValue {
+		t.Errorf("Got %v expected %v", actualValue, expactedValue)
+	}
+
+	expected := 3
+	for key, value := range tree.Backward() {
+		if key != expected || value != string(rune('a'+expected)) {
+			t.Errorf("Got %v expected %v", key, expected)
+		}
+		expected--
+	}
+
+}
@@ -16,6 +16,8 @@ package trees
 
 import (
     "fmt"
+    "math/rand"
+    "slices"
     "testing"
     "github.com/aiwuTech/container"
 )
@@ -159,4 +161,435 @@ func BenchmarkRedBlackTree(b *testing.B) {
             tree.Remove(n)
         }
     }
-}
\ No newline at end of file
+}
+func TestRedBlackTreeIterator(t *testing.T) {
+
+    tree := NewRBTree(container.IntCompareFunctionASC)
+
+    it := tree.ReverseIterator()
+    if it.Next() || it.Prev() {
+        t.Errorf("Got %v expected %v", true, false)
+    }
+
+    for i := 10; i > 0; i-- {
+        tree.Put(i, i*10)
+    }
+
+    it.Reset()
+    count := 0
+    for it.Next() {
+        count++
+        if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+            t.Errorf("Got %v expected %v", actualValue, expectedValue)
+        }
+        if actualValue, expectedValue := it.Value(), count*10; actualValue != expectedValue {
+            t.Errorf("Got %v expected %v", actualValue, expectedValue)
+        }
+    }
+    if count != 10 {
+        t.Errorf("Got %v expected %v", count, 10)
+    }
+
+    for it.Prev() {
+        if actualValue, expectedValue := it.Key(), count; actualValue != expectedValue {
+            t.Errorf("Got %v expected %v", actualValue, expectedValue)
+        }
+        count--
+    }
+    if count != 0 {
+        t.Errorf("Got %v expected %v", count, 0)
+    }
+
+    it.End()
+    if !it.Prev() || it.Key() != 10 {
+        t.Errorf("Got %v expected %v", it.Key(), 10)
+    }
+
+}
+
+func TestRedBlackTreeSeq(t *testing.T) {
+
+    tree := NewRBTree(container.IntCompareFunctionASC)
+    for i := 10; i > 0; i-- {
+        tree.Put(i, i*10)
+    }
+
+    count := 0
+    for key, value := range tree.All() {
+        count++
+        if key != count || value != count*10 {
+            t.Errorf("Got %v expected %v", key, count)
+        }
+    }
+    if count != 10 {
+        t.Errorf("Got %v expected %v", count, 10)
+    }
+
+    for key := range tree.Backward() {
+        if key != 10 {
+            t.Errorf("Got %v expected %v", key, 10)
+        }
+        break
+    }
+
+    if actualValue, expactedValue := fmt.Sprint(slices.Collect(tree.KeySeq())), fmt.Sprint(tree.Keys()); actualValue != expactedValue {
+        t.Errorf("Got %v expected %v", actualValue, expactedValue)
+    }
+
+    if actualValue, expactedValue := fmt.Sprint(slices.Collect(tree.Values())), fmt.Sprint(tree.Elements()); actualValue != expactedValue {
+        t.Errorf("Got %v expected %v", actualValue, expactedValue)
+    }
+
+}
+
+func TestRedBlackTreeNavigation(t *testing.T) {
+
+    tree := NewRBTree(container.IntCompareFunctionASC)
+
+    if _, _, found := tree.Min(); found {
+        t.Errorf("Got %v expected %v", found, false)
+    }
+    if _, _, found := tree.Floor(1); found {
+        t.Errorf("Got %v expected %v", found, false)
+    }
+
+    for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+        tree.Put(key, key*10)
+    }
+
+    // key, floor, ceiling, lower, higher; nil means not found
+    tests := [][]interface{}{
+        {5, nil, 10, nil, 10},
+        {10, 10, 10, nil, 20},
+        {25, 20, 30, 20, 30},
+        {50, 50, 50, 30, 70},
+        {60, 50, 70, 50, 70},
+        {90, 90, 90, 80, nil},
+        {95, 90, nil, 90, nil},
+    }
+
+    for _, test := range tests {
+        queries := []func(interface{}) (interface{}, interface{}, bool){tree.Floor, tree.Ceiling, tree.Lower, tree.Higher}
+        for i, query := range queries {
+            key, value, found := query(test[0])
+            if key != test[i+1] || found != (test[i+1] != nil) {
+                t.Errorf("Got %v expected %v", key, test[i+1])
+            }
+            if found && value != key.(int)*10 {
+                t.Errorf("Got %v expected %v", value, key.(int)*10)
+            }
+        }
+    }
+
+    if key, _, _ := tree.Min(); key != 10 {
+        t.Errorf("Got %v expected %v", key, 10)
+    }
+    if key, _, _ := tree.Max(); key != 90 {
+        t.Errorf("Got %v expected %v", key, 90)
+    }
+
+    // from, to, fromInclusive, toInclusive, expected keys
+    ranges := [][]interface{}{
+        {20, 70, true, true, "[20 30 50 70]"},
+        {20, 70, false, false, "[30 50]"},
+        {25, 75, true, false, "[30 50 70]"},
+        {nil, 30, true, true, "[10 20 30]"},
+        {70, nil, false, true, "[80 90]"},
+        {60, 40, true, true, "[]"},
+    }
+    for _, test := range ranges {
+        keys := []interface{}{}
+        for key, value := range tree.Range(test[0], test[1], test[2].(bool), test[3].(bool)) {
+            if value != key.(int)*10 {
+                t.Errorf("Got %v expected %v", value, key.(int)*10)
+            }
+            keys = append(keys, key)
+        }
+        if actualValue := fmt.Sprint(keys); actualValue != test[4] {
+            t.Errorf("Got %v expected %v", actualValue, test[4])
+        }
+    }
+
+    if key, value, found := tree.PollMin(); key != 10 || value != 100 || !found {
+        t.Errorf("Got %v expected %v", key, 10)
+    }
+    if key, value, found := tree.PollMax(); key != 90 || value != 900 || !found {
+        t.Errorf("Got %v expected %v", key, 90)
+    }
+    if actualValue, expactedValue := fmt.Sprint(tree.Keys()), "[20 30 50 70 80]"; actualValue != expactedValue {
+        t.Errorf("Got %v expected %v", actualValue, expactedValue)
+    }
+
+    for !tree.Empty() {
+        tree.PollMin()
+    }
+    if _, _, found := tree.PollMax(); found {
+        t.Errorf("Got %v expected %v", found, false)
+    }
+
+}
+
+func TestRedBlackTreeRank(t *testing.T) {
+
+    tree := NewRBTree(container.IntCompareFunctionASC)
+    for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+        tree.Put(key, key*10)
+    }
+
+    // key, rank
+    tests := [][]int{{5, 0}, {10, 0}, {20, 1}, {25, 2}, {50, 3}, {90, 6}, {95, 7}}
+    for _, test := range tests {
+        if actualValue := tree.Rank(test[0]); actualValue != test[1] {
+            t.Errorf("Got %v expected %v", actualValue, test[1])
+        }
+    }
+
+    for i, expectedKey := range []int{10, 20, 30, 50, 70, 80, 90} {
+        key, value, found := tree.Select(i)
+        if key != expectedKey || value != expectedKey*10 || !found {
+            t.Errorf("Got %v expected %v", key, expectedKey)
+        }
+    }
+    if _, _, found := tree.Select(7); found {
+        t.Errorf("Got %v expected %v", found, false)
+    }
+    if _, _, found := tree.Select(-1); found {
+        t.Errorf("Got %v expected %v", found, false)
+    }
+
+    if actualValue := tree.CountRange(20, 80); actualValue != 4 {
+        t.Errorf("Got %v expected %v", actualValue, 4)
+    }
+    if actualValue := tree.CountRange(80, 20); actualValue != 0 {
+        t.Errorf("Got %v expected %v", actualValue, 0)
+    }
+
+    // sizes must survive the rotations of random insertions and removals
+    random := rand.New(rand.NewSource(1))
+    reference := map[int]bool{}
+    for i := 0; i < 2000; i++ {
+        key := random.Intn(300)
+        if random.Intn(3) == 0 {
+            tree.Remove(key)
+            delete(reference, key)
+        } else {
+            tree.Put(key, key*10)
+            reference[key] = true
+        }
+        checkRedBlackNodeSize(t, tree.root)
+    }
+    for i, key := range tree.Keys() {
+        if actualValue := tree.Rank(key); actualValue != i {
+            t.Errorf("Got %v expected %v", actualValue, i)
+        }
+        if actualValue, _, _ := tree.Select(i); actualValue != key {
+            t.Errorf("Got %v expected %v", actualValue, key)
+        }
+    }
+    if tree.Len() != len(reference) || nodeSize(tree.root) != len(reference) {
+        t.Errorf("Got %v expected %v", nodeSize(tree.root), len(reference))
+    }
+
+}
+
+func checkRedBlackNodeSize(t *testing.T, node *redBlackNode) int {
+    if node == nil {
+        return 0
+    }
+    size := checkRedBlackNodeSize(t, node.left) + checkRedBlackNodeSize(t, node.right) + 1
+    if node.size != size {
+        t.Fatalf("Got %v expected %v", node.size, size)
+    }
+    return size
+}
+
+func BenchmarkRedBlackTreeRank(b *testing.B) {
+    tree := NewRBTree(container.IntCompareFunctionASC)
+    for n := 0; n < 1000; n++ {
+        tree.Put(n, n)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        for n := 0; n < 1000; n++ {
+            tree.Select(tree.Rank(n))
+        }
+    }
+}
+
+func TestRedBlackTreeSnapshot(t *testing.T) {
+    tree := NewRBTree(container.IntCompareFunctionASC)
+    reference := map[int]int{}
+    for n := 0; n < 500; n++ {
+        tree.Put(n, n)
+        reference[n] = n
+    }
+
+    random := rand.New(rand.NewSource(3))
+    var snapshots []*RBTree
+    var references []map[int]int
+    for round := 0; round < 20; round++ {
+        snapshots = append(snapshots, tree.Snapshot())
+        frozen := make(map[int]int, len(reference))
+        for k, v := range reference {
+            frozen[k] = v
+        }
+        references = append(references, frozen)
+
+        for n := 0; n < 200; n++ {
+            key := random.Intn(1000)
+            if random.Intn(2) == 0 {
+                tree.Put(key, round)
+                reference[key] = round
+            } else {
+                tree.Remove(key)
+                delete(reference, key)
+            }
+        }
+        // the snapshots are writable as well, without affecting the tree
+        snapshot := snapshots[len(snapshots)-1]
+        if round%2 == 1 {
+            snapshot.Put(-1, -1)
+            snapshot.Remove(-1)
+        }
+    }
+
+    snapshots = append(snapshots, tree)
+    references = append(references, reference)
+    for i, snapshot := range snapshots {
+        checkRedBlackTree(t, snapshot)
+        if snapshot.Len() != len(references[i]) {
+            t.Errorf("Got %v expected %v", snapshot.Len(), len(references[i]))
+        }
+        previous := -1
+        for key, value := range snapshot.All() {
+            if key.(int) <= previous || references[i][key.(int)] != value {
+                t.Errorf("Got %v expected %v", value, references[i][key.(int)])
+            }
+            previous = key.(int)
+        }
+    }
+}
+
+// checks the colors and sizes of the tree, returns its black height
+func checkRedBlackTree(t *testing.T, tree *RBTree) int {
+    checkRedBlackNodeSize(t, tree.root)
+    if nodeColor(tree.root) != black {
+        t.Fatalf("Got %v expected %v", nodeColor(tree.root), black)
+    }
+    return checkRedBlackNodeColor(t, tree.root)
+}
+
+func checkRedBlackNodeColor(t *testing.T, node *redBlackNode) int {
+    if node == nil {
+        return 1
+    }
+    if node.color == red && (nodeColor(node.left) == red || nodeColor(node.right) == red) {
+        t.Fatalf("Got red child of red node %v", node.key)
+    }
+    height := checkRedBlackNodeColor(t, node.left)
+    if rightHeight := checkRedBlackNodeColor(t, node.right); rightHeight != height {
+        t.Fatalf("Got %v expected %v", rightHeight, height)
+    }
+    if node.color == black {
+        height++
+    }
+    return height
+}
+
+func BenchmarkRedBlackTreeSnapshot(b *testing.B) {
+    tree := NewRBTree(container.IntCompareFunctionASC)
+    for n := 0; n < 1000; n++ {
+        tree.Put(n, n)
+    }
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        tree.Snapshot()
+        for n := 0; n < 1000; n++ {
+            tree.Put(n, i)
+        }
+    }
+}
+
+// the loop bodies put and remove odd keys, every even key must still be seen once and in order
+func TestRedBlackTreeWriteDuringRange(t *testing.T) {
+    walks := []struct {
+        name    string
+        reverse bool
+        walk    func(tree *RBTree, body func()) []int
+    }{
+        {"All", false, func(tree *RBTree, body func()) (keys []int) {
+            for key := range tree.All() {
+                keys = append(keys, key.(int))
+                body()
+            }
+            return
+        }},
+        {"Range", false, func(tree *RBTree, body func()) (keys []int) {
+            for key := range tree.Range(nil, nil, true, true) {
+                keys = append(keys, key.(int))
+                body()
+            }
+            return
+        }},
+        {"Iterator", false, func(tree *RBTree, body func()) (keys []int) {
+            for it := tree.Iterator(); it.Next(); {
+                keys = append(keys, it.Key().(int))
+                body()
+            }
+            return
+        }},
+        {"Backward", true, func(tree *RBTree, body func()) (keys []int) {
+            for key := range tree.Backward() {
+                keys = append(keys, key.(int))
+                body()
+            }
+            return
+        }},
+        {"ReverseIterator", true, func(tree *RBTree, body func()) (keys []int) {
+            it := tree.ReverseIterator()
+            for it.End(); it.Prev(); {
+                keys = append(keys, it.Key().(int))
+                body()
+            }
+            return
+        }},
+    }
+    for _, walk := range walks {
+        for seed := int64(0); seed < 20; seed++ {
+            tree := NewRBTree(container.IntCompareFunctionASC)
+            for n := 0; n < 200; n++ {
+                tree.Put(n, n)
+            }
+            random := rand.New(rand.NewSource(seed))
+            keys := walk.walk(tree, func() {
+                for i := 0; i < 3; i++ {
+                    key := 2*random.Intn(100) + 1
+                    switch random.Intn(5) {
+                    case 0:
+                        tree.Snapshot()
+                    case 1, 2:
+                        tree.Put(key, key)
+                    default:
+                        tree.Remove(key)
+                    }
+                }
+            })
+
+            var evens []int
+            for i, key := range keys {
+                if i > 0 && (keys[i-1] < key) == walk.reverse {
+                    t.Errorf("%v seed %v: Got %v after %v", walk.name, seed, key, keys[i-1])
+                }
+                if key%2 == 0 {
+                    evens = append(evens, key)
+                }
+            }
+            if walk.reverse {
+                slices.Reverse(evens)
+            }
+            if len(evens) != 100 || evens[0] != 0 || evens[99] != 198 {
+                t.Errorf("%v seed %v: Got %v even keys expected %v", walk.name, seed, len(evens), 100)
+            }
+        }
+    }
+}
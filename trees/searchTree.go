@@ -0,0 +1,207 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+)
+
+// searchNode is a node of a binary search tree, *avlNode or *treapNode, which only links
+// down to its children. What keeps the tree balanced is left to each kind of node.
+type searchNode[N comparable] interface {
+	comparable
+	entry() (key interface{}, value interface{})
+	children() (left N, right N)
+}
+
+// searchTree holds what AVLTree and Treap share: the lookups and the in-order walk, which only
+// go down from the root, whatever the updates do to keep the tree balanced.
+type searchTree[N searchNode[N]] struct {
+	root       N
+	size       int
+	comparator container.CompareFunction
+}
+
+// Searches the node in the tree by key and returns its value or nil if key is not found in tree.
+// Second return parameter is true if key was found, otherwise false.
+func (tree *searchTree[N]) Get(key interface{}) (value interface{}, found bool) {
+	var none N
+	for node := tree.root; node != none; {
+		nodeKey, nodeValue := node.entry()
+		left, right := node.children()
+		compare := tree.comparator(key, nodeKey)
+		switch {
+		case compare == 0:
+			return nodeValue, true
+		case compare < 0:
+			node = left
+		case compare > 0:
+			node = right
+		}
+	}
+	return nil, false
+}
+
+// Returns all keys in-order
+func (tree *searchTree[N]) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.size)
+	for it := tree.Iterator(); it.Next(); {
+		keys = append(keys, it.Key())
+	}
+	return keys
+}
+
+// Returns all values in-order based on the key.
+func (tree *searchTree[N]) Elements() []interface{} {
+	values := make([]interface{}, 0, tree.size)
+	for it := tree.Iterator(); it.Next(); {
+		values = append(values, it.Value())
+	}
+	return values
+}
+
+func (tree *searchTree[N]) Min() (key interface{}, value interface{}, found bool) {
+	var none N
+	if tree.root == none {
+		return nil, nil, false
+	}
+	node := tree.root
+	for left, _ := node.children(); left != none; left, _ = node.children() {
+		node = left
+	}
+	key, value = node.entry()
+	return key, value, true
+}
+
+func (tree *searchTree[N]) Max() (key interface{}, value interface{}, found bool) {
+	var none N
+	if tree.root == none {
+		return nil, nil, false
+	}
+	node := tree.root
+	for _, right := node.children(); right != none; _, right = node.children() {
+		node = right
+	}
+	key, value = node.entry()
+	return key, value, true
+}
+
+func (tree *searchTree[N]) Floor(key interface{}) (floorKey interface{}, value interface{}, found bool) {
+	var none N
+	for node := tree.root; node != none; {
+		nodeKey, nodeValue := node.entry()
+		left, right := node.children()
+		compare := tree.comparator(key, nodeKey)
+		switch {
+		case compare == 0:
+			return nodeKey, nodeValue, true
+		case compare < 0:
+			node = left
+		case compare > 0:
+			floorKey, value, found = nodeKey, nodeValue, true
+			node = right
+		}
+	}
+	return floorKey, value, found
+}
+
+func (tree *searchTree[N]) Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool) {
+	var none N
+	for node := tree.root; node != none; {
+		nodeKey, nodeValue := node.entry()
+		left, right := node.children()
+		compare := tree.comparator(key, nodeKey)
+		switch {
+		case compare == 0:
+			return nodeKey, nodeValue, true
+		case compare < 0:
+			ceilingKey, value, found = nodeKey, nodeValue, true
+			node = left
+		case compare > 0:
+			node = right
+		}
+	}
+	return ceilingKey, value, found
+}
+
+// Returns true if tree does not contain any nodes
+func (tree *searchTree[N]) Empty() bool {
+	return tree.size == 0
+}
+
+// Returns number of nodes in the tree.
+func (tree *searchTree[N]) Len() int {
+	return tree.size
+}
+
+// Removes all nodes from the tree.
+func (tree *searchTree[N]) Clear() {
+	var none N
+	tree.root = none
+	tree.size = 0
+}
+
+// check if the values are in the tree
+func (tree *searchTree[N]) Contains(values ...interface{}) bool {
+	return treeContains(tree, values)
+}
+
+// Returns a stateful iterator over the tree in key order, positioned before the smallest key.
+func (tree *searchTree[N]) Iterator() container.Iterator {
+	return &searchTreeIterator[N]{tree: tree}
+}
+
+// searchTreeIterator walks the tree in order, keeping the path of nodes still to visit on a stack.
+type searchTreeIterator[N searchNode[N]] struct {
+	tree    *searchTree[N]
+	stack   []N
+	node    N
+	started bool
+}
+
+func (it *searchTreeIterator[N]) Next() bool {
+	var next, none N
+	if !it.started {
+		it.started = true
+		next = it.tree.root
+	} else if it.node != none {
+		_, next = it.node.children()
+	} else {
+		return false
+	}
+	for ; next != none; next, _ = next.children() {
+		it.stack = append(it.stack, next)
+	}
+	it.node = none
+	if len(it.stack) > 0 {
+		it.node = it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+	}
+	return it.node != none
+}
+
+func (it *searchTreeIterator[N]) Value() interface{} {
+	_, value := it.node.entry()
+	return value
+}
+
+func (it *searchTreeIterator[N]) Key() interface{} {
+	key, _ := it.node.entry()
+	return key
+}
+
+func (it *searchTreeIterator[N]) Reset() {
+	var none N
+	it.stack, it.node, it.started = it.stack[:0], none, false
+}
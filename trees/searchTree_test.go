@@ -0,0 +1,137 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+	"testing"
+)
+
+var searchTrees = []struct {
+	name    string
+	newTree func() SortedMapInterface
+	// checks the shape each tree keeps to stay balanced, returns the size
+	check     func(t *testing.T, tree SortedMapInterface) int
+	maxHeight int // with 1023 ascending keys, which would make a list of an unbalanced tree
+}{
+	{"AVLTree", func() SortedMapInterface { return NewAVLTree(container.IntCompareFunctionASC) },
+		func(t *testing.T, tree SortedMapInterface) int { return checkAVLNode(t, tree.(*AVLTree).root) }, 10},
+	{"Treap", func() SortedMapInterface { return NewTreap(container.IntCompareFunctionASC) },
+		func(t *testing.T, tree SortedMapInterface) int { return checkTreapNode(t, tree.(*Treap).root) }, 40},
+}
+
+func TestSearchTrees(t *testing.T) {
+	for _, searchTree := range searchTrees {
+		t.Run(searchTree.name, func(t *testing.T) {
+			tree := searchTree.newTree()
+			for i := 0; i < 1023; i++ {
+				tree.Put(i, i)
+			}
+			if actualValue := searchTreeHeight(tree); actualValue > searchTree.maxHeight {
+				t.Errorf("Got %v expected %v", actualValue, searchTree.maxHeight)
+			}
+			if size := searchTree.check(t, tree); size != tree.Len() {
+				t.Errorf("Got %v expected %v", size, tree.Len())
+			}
+
+			random := rand.New(rand.NewSource(1))
+			for i := 0; i < 5000; i++ {
+				if key := random.Intn(2000); random.Intn(2) == 0 {
+					tree.Remove(key)
+				} else {
+					tree.Put(key, key)
+				}
+			}
+			if size := searchTree.check(t, tree); size != tree.Len() {
+				t.Errorf("Got %v expected %v", size, tree.Len())
+			}
+
+			it := tree.Iterator()
+			for i := 0; i < 2; i++ {
+				count := 0
+				for it.Next() {
+					count++
+				}
+				if count != tree.Len() {
+					t.Errorf("Got %v expected %v", count, tree.Len())
+				}
+				it.Reset()
+			}
+		})
+	}
+}
+
+func searchTreeHeight(tree SortedMapInterface) int {
+	switch tree := tree.(type) {
+	case *AVLTree:
+		return searchNodeHeight(tree.root)
+	case *Treap:
+		return searchNodeHeight(tree.root)
+	}
+	return 0
+}
+
+func searchNodeHeight[N searchNode[N]](node N) int {
+	var none N
+	if node == none {
+		return 0
+	}
+	left, right := node.children()
+	return max(searchNodeHeight(left), searchNodeHeight(right)) + 1
+}
+
+// checks the heights and the balance of the subtree, returns its size
+func checkAVLNode(t *testing.T, node *avlNode) int {
+	if node == nil {
+		return 0
+	}
+	size := checkAVLNode(t, node.left) + checkAVLNode(t, node.right) + 1
+	if balance := avlHeight(node.left) - avlHeight(node.right); balance > 1 || balance < -1 {
+		t.Fatalf("Got balance %v at %v", balance, node.key)
+	}
+	if expectedHeight := max(avlHeight(node.left), avlHeight(node.right)) + 1; node.height != expectedHeight {
+		t.Fatalf("Got %v expected %v", node.height, expectedHeight)
+	}
+	return size
+}
+
+// checks the priorities of the subtree, returns its size
+func checkTreapNode(t *testing.T, node *treapNode) int {
+	if node == nil {
+		return 0
+	}
+	for _, child := range []*treapNode{node.left, node.right} {
+		if child != nil && child.priority > node.priority {
+			t.Fatalf("Got child priority %v above %v at %v", child.priority, node.priority, node.key)
+		}
+	}
+	return checkTreapNode(t, node.left) + checkTreapNode(t, node.right) + 1
+}
+
+func BenchmarkSearchTrees(b *testing.B) {
+	for _, searchTree := range searchTrees {
+		b.Run(searchTree.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree := searchTree.newTree()
+				for n := 0; n < 1000; n++ {
+					tree.Put(n, n)
+				}
+				for n := 0; n < 1000; n++ {
+					tree.Remove(n)
+				}
+			}
+		})
+	}
+}
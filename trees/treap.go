@@ -0,0 +1,124 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees
+
+import (
+	"github.com/aiwuTech/container"
+	"math/rand"
+)
+
+type treapNode struct {
+	key      interface{}
+	value    interface{}
+	priority uint64 // a node's priority is above its children's
+	left     *treapNode
+	right    *treapNode
+}
+
+func (node *treapNode) entry() (interface{}, interface{}) {
+	return node.key, node.value
+}
+
+func (node *treapNode) children() (*treapNode, *treapNode) {
+	return node.left, node.right
+}
+
+// Treap is a search tree arranged as a heap of random priorities, which keeps it balanced
+// with high probability. Its updates rotate less than RBTree's and AVLTree's.
+type Treap struct {
+	searchTree[*treapNode]
+}
+
+func NewTreap(comparator container.CompareFunction) *Treap {
+	return &Treap{searchTree[*treapNode]{comparator: comparator}}
+}
+
+// Inserts node into the tree.
+// Key should adhere to the comparator's type assertion, otherwise method panics.
+func (tree *Treap) Put(key interface{}, value interface{}) {
+	tree.root = tree.put(tree.root, key, value)
+}
+
+// Remove the node from the tree by key.
+func (tree *Treap) Remove(key interface{}) {
+	tree.root = tree.remove(tree.root, key)
+}
+
+func (tree *Treap) String() string {
+	return treeString("Treap", tree)
+}
+
+func (tree *Treap) put(node *treapNode, key interface{}, value interface{}) *treapNode {
+	if node == nil {
+		tree.size++
+		return &treapNode{key: key, value: value, priority: rand.Uint64()}
+	}
+	compare := tree.comparator(key, node.key)
+	switch {
+	case compare == 0:
+		node.value = value
+	case compare < 0:
+		node.left = tree.put(node.left, key, value)
+		if node.left.priority > node.priority {
+			node = treapRotateRight(node)
+		}
+	case compare > 0:
+		node.right = tree.put(node.right, key, value)
+		if node.right.priority > node.priority {
+			node = treapRotateLeft(node)
+		}
+	}
+	return node
+}
+
+func (tree *Treap) remove(node *treapNode, key interface{}) *treapNode {
+	if node == nil {
+		return nil
+	}
+	compare := tree.comparator(key, node.key)
+	switch {
+	case compare < 0:
+		node.left = tree.remove(node.left, key)
+	case compare > 0:
+		node.right = tree.remove(node.right, key)
+	case node.left == nil:
+		tree.size--
+		return node.right
+	case node.right == nil:
+		tree.size--
+		return node.left
+	// rotates the node down below the child of higher priority until it has a single child
+	case node.left.priority > node.right.priority:
+		node = treapRotateRight(node)
+		node.right = tree.remove(node.right, key)
+	default:
+		node = treapRotateLeft(node)
+		node.left = tree.remove(node.left, key)
+	}
+	return node
+}
+
+func treapRotateLeft(node *treapNode) *treapNode {
+	right := node.right
+	node.right = right.left
+	right.left = node
+	return right
+}
+
+func treapRotateRight(node *treapNode) *treapNode {
+	left := node.left
+	node.left = left.right
+	left.right = node
+	return left
+}
@@ -13,8 +13,66 @@
 // under the License.
 package trees
 
-import "github.com/aiwuTech/container"
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"strings"
+)
 
 type TreeInterface interface {
 	container.ContainerInterface
 }
+
+// SortedMapInterface is implemented by the balanced search trees, which keep their keys
+// in the comparator's order. Like RBTree, their Contains and Elements work on the values.
+type SortedMapInterface interface {
+	// Inserts the key, or replaces the value of an existing key.
+	Put(key interface{}, value interface{})
+	// Returns the value of key, second return parameter is false if key is not found.
+	Get(key interface{}) (value interface{}, found bool)
+	Remove(key interface{})
+	// Returns all keys in-order.
+	Keys() []interface{}
+	// Returns the smallest key and its value, third return parameter is false if the tree is empty.
+	Min() (key interface{}, value interface{}, found bool)
+	// Returns the largest key and its value, third return parameter is false if the tree is empty.
+	Max() (key interface{}, value interface{}, found bool)
+	// Returns the largest key <= the given key and its value, third return parameter is false if there is none.
+	Floor(key interface{}) (floorKey interface{}, value interface{}, found bool)
+	// Returns the smallest key >= the given key and its value, third return parameter is false if there is none.
+	Ceiling(key interface{}) (ceilingKey interface{}, value interface{}, found bool)
+	TreeInterface
+}
+
+var (
+	_ SortedMapInterface = &RBTree{}
+	_ SortedMapInterface = &AVLTree{}
+	_ SortedMapInterface = &Treap{}
+	_ SortedMapInterface = &BTree{}
+	_ SortedMapInterface = &BPlusTree{}
+)
+
+// check if the values are in the tree by walking it
+func treeContains(tree interface{ Iterator() container.Iterator }, values []interface{}) bool {
+	for _, value := range values {
+		found := false
+		for it := tree.Iterator(); it.Next() && !found; {
+			found = it.Value() == value
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func treeString(name string, tree SortedMapInterface) string {
+	str := name + "{ "
+	values := []string{}
+	for it := tree.Iterator(); it.Next(); {
+		values = append(values, fmt.Sprintf("%v:%v", it.Key(), it.Value()))
+	}
+	str += strings.Join(values, ", ")
+	str += " }"
+	return str
+}
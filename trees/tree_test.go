@@ -0,0 +1,260 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package trees_test
+
+import (
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/skiplists"
+	"github.com/aiwuTech/container/trees"
+	"iter"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+var sortedMaps = []struct {
+	name    string
+	newTree func() trees.SortedMapInterface
+}{
+	{"RBTree", func() trees.SortedMapInterface { return trees.NewRBTree(container.IntCompareFunctionASC) }},
+	{"AVLTree", func() trees.SortedMapInterface { return trees.NewAVLTree(container.IntCompareFunctionASC) }},
+	{"Treap", func() trees.SortedMapInterface { return trees.NewTreap(container.IntCompareFunctionASC) }},
+	{"BTree", func() trees.SortedMapInterface { return trees.NewBTree(2, container.IntCompareFunctionASC) }},
+	{"BTree32", func() trees.SortedMapInterface { return trees.NewBTree(32, container.IntCompareFunctionASC) }},
+	{"BPlusTree", func() trees.SortedMapInterface { return trees.NewBPlusTree(2, container.IntCompareFunctionASC) }},
+	{"BPlusTree32", func() trees.SortedMapInterface { return trees.NewBPlusTree(32, container.IntCompareFunctionASC) }},
+	{"SkipList", func() trees.SortedMapInterface { return skiplists.NewSkipList(container.IntCompareFunctionASC) }},
+}
+
+func TestSortedMaps(t *testing.T) {
+	for _, sortedMap := range sortedMaps {
+		t.Run(sortedMap.name, func(t *testing.T) {
+			testSortedMap(t, sortedMap.newTree())
+		})
+	}
+}
+
+func testSortedMap(t *testing.T, tree trees.SortedMapInterface) {
+	if _, _, found := tree.Min(); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	if _, _, found := tree.Floor(1); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+
+	for _, key := range []int{50, 20, 80, 10, 30, 70, 90} {
+		tree.Put(key, fmt.Sprint(key))
+	}
+	tree.Put(10, "ten")
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys(), tree.Elements()), "[10 20 30 50 70 80 90] [ten 20 30 50 70 80 90]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if !tree.Contains("ten", "90") || tree.Contains("10") || tree.Len() != 7 {
+		t.Errorf("Contains error, got %v", tree)
+	}
+
+	if key, value, found := tree.Floor(60); key != 50 || value != "50" || !found {
+		t.Errorf("Got %v expected %v", key, 50)
+	}
+	if key, _, _ := tree.Ceiling(60); key != 70 {
+		t.Errorf("Got %v expected %v", key, 70)
+	}
+	if _, _, found := tree.Ceiling(95); found {
+		t.Errorf("Got %v expected %v", found, false)
+	}
+	if key, _, _ := tree.Min(); key != 10 {
+		t.Errorf("Got %v expected %v", key, 10)
+	}
+	if key, _, _ := tree.Max(); key != 90 {
+		t.Errorf("Got %v expected %v", key, 90)
+	}
+
+	tree.Remove(50)
+	tree.Remove(50)
+	if value, found := tree.Get(50); value != nil || found || tree.Len() != 6 {
+		t.Errorf("Got %v expected %v", value, nil)
+	}
+
+	// random insertions and removals against a reference map
+	tree.Clear()
+	random := rand.New(rand.NewSource(1))
+	reference := map[int]int{}
+	for i := 0; i < 5000; i++ {
+		key := random.Intn(500)
+		if random.Intn(3) == 0 {
+			tree.Remove(key)
+			delete(reference, key)
+		} else {
+			tree.Put(key, i)
+			reference[key] = i
+		}
+	}
+	keys := []int{}
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for key, expectedValue := range reference {
+		if actualValue, _ := tree.Get(key); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+}
+
+type rangeMap interface {
+	trees.SortedMapInterface
+	Range(from, to interface{}, fromInclusive, toInclusive bool) iter.Seq2[interface{}, interface{}]
+}
+
+// the ranges and the navigation of the sorted maps against a sorted slice of their keys
+func TestSortedMapsRange(t *testing.T) {
+	for _, sortedMap := range sortedMaps {
+		t.Run(sortedMap.name, func(t *testing.T) {
+			tree := sortedMap.newTree()
+			keys := []int{}
+			for i := 0; i < 100; i += 2 {
+				tree.Put(i, i)
+				// removals leave stale separators in a B+ tree
+				if i >= 10 && i < 30 && i%4 == 2 {
+					tree.Remove(i)
+				} else {
+					keys = append(keys, i)
+				}
+			}
+
+			for key := -1; key <= 100; key++ {
+				expectedFloor, expectedCeiling := interface{}(nil), interface{}(nil)
+				for _, k := range keys {
+					if k <= key {
+						expectedFloor = k
+					}
+					if k >= key && expectedCeiling == nil {
+						expectedCeiling = k
+					}
+				}
+				if actualValue, _, _ := tree.Floor(key); actualValue != expectedFloor {
+					t.Errorf("Floor(%v): got %v expected %v", key, actualValue, expectedFloor)
+				}
+				if actualValue, _, _ := tree.Ceiling(key); actualValue != expectedCeiling {
+					t.Errorf("Ceiling(%v): got %v expected %v", key, actualValue, expectedCeiling)
+				}
+			}
+
+			ranged, ok := tree.(rangeMap)
+			if !ok {
+				return
+			}
+			bounds := []interface{}{nil, -1, 0, 1, 10, 11, 14, 50, 98, 99, 200}
+			for _, from := range bounds {
+				for _, to := range bounds {
+					for _, inclusive := range [][2]bool{{true, true}, {true, false}, {false, true}, {false, false}} {
+						actualKeys, expectedKeys := []interface{}{}, []interface{}{}
+						for key := range ranged.Range(from, to, inclusive[0], inclusive[1]) {
+							actualKeys = append(actualKeys, key)
+						}
+						for _, key := range keys {
+							if inRange(key, from, to, inclusive) {
+								expectedKeys = append(expectedKeys, key)
+							}
+						}
+						if actualValue, expectedValue := fmt.Sprint(actualKeys), fmt.Sprint(expectedKeys); actualValue != expectedValue {
+							t.Errorf("Range(%v, %v, %v): got %v expected %v", from, to, inclusive, actualValue, expectedValue)
+						}
+					}
+				}
+			}
+
+			count := 0
+			for range ranged.Range(nil, nil, true, true) {
+				if count++; count == 3 {
+					break
+				}
+			}
+			if count != 3 {
+				t.Errorf("Got %v expected %v", count, 3)
+			}
+		})
+	}
+}
+
+// whether key is between the bounds, a nil bound means unbounded
+func inRange(key int, from, to interface{}, inclusive [2]bool) bool {
+	if from != nil && (key < from.(int) || key == from.(int) && !inclusive[0]) {
+		return false
+	}
+	return to == nil || key < to.(int) || key == to.(int) && inclusive[1]
+}
+
+func BenchmarkSortedMapsPut(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(1000)
+	for _, sortedMap := range sortedMaps {
+		b.Run(sortedMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				tree := sortedMap.newTree()
+				for _, key := range keys {
+					tree.Put(key, key)
+				}
+				for _, key := range keys {
+					tree.Remove(key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortedMapsGet(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(1000)
+	for _, sortedMap := range sortedMaps {
+		tree := sortedMap.newTree()
+		for _, key := range keys {
+			tree.Put(key, key)
+		}
+		b.Run(sortedMap.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, key := range keys {
+					tree.Get(key)
+				}
+			}
+		})
+	}
+}
+
+// reports the heap kept alive per key once the keys are in the tree
+func BenchmarkSortedMapsMemory(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(100000)
+	for _, sortedMap := range sortedMaps {
+		b.Run(sortedMap.name, func(b *testing.B) {
+			var before, after runtime.MemStats
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				tree := sortedMap.newTree()
+				for _, key := range keys {
+					tree.Put(key, nil)
+				}
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc))/float64(len(keys)), "bytes/key")
+				runtime.KeepAlive(tree)
+			}
+		})
+	}
+}
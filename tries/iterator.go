@@ -0,0 +1,102 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package tries
+
+import (
+	"strings"
+)
+
+type radixTreeFrame struct {
+	node *radixNode
+	key  string
+}
+
+// radixTreeIterator walks the subtree of the keys starting with prefix depth first,
+// a node before its children and the children in order, which is the key order.
+type radixTreeIterator struct {
+	tree    *RadixTree
+	prefix  string
+	stack   []radixTreeFrame
+	current radixTreeFrame
+	mods    int // of the tree when the stack was built
+	started bool
+}
+
+func (it *radixTreeIterator) Next() bool {
+	switch {
+	case !it.started:
+		it.started, it.mods = true, it.tree.mods
+		if node, key := it.tree.subtree(it.prefix); node != nil {
+			it.stack = append(it.stack, radixTreeFrame{node, key})
+		}
+	case it.mods != it.tree.mods && it.current.node != nil:
+		it.mods = it.tree.mods
+		it.seek(it.current.key)
+	}
+	for len(it.stack) > 0 {
+		frame := it.stack[len(it.stack)-1]
+		it.stack = it.stack[:len(it.stack)-1]
+		if !strings.HasPrefix(frame.key, it.prefix) {
+			// seek only pushes keys after one with the prefix, this and the rest are past them all
+			it.stack = it.stack[:0]
+			break
+		}
+		for i := len(frame.node.children) - 1; i >= 0; i-- {
+			child := frame.node.children[i]
+			it.stack = append(it.stack, radixTreeFrame{child, frame.key + child.prefix})
+		}
+		if frame.node.hasValue {
+			it.current = frame
+			return true
+		}
+	}
+	it.current = radixTreeFrame{}
+	return false
+}
+
+// rebuilds the stack so that the walk goes on with the keys after key, once the tree was
+// written: the nodes on the stack may have been split or merged, and their keys with them
+func (it *radixTreeIterator) seek(key string) {
+	it.stack = it.stack[:0]
+	node, nodeKey := it.tree.root, ""
+	for node != nil {
+		rest := key[len(nodeKey):]
+		var next *radixNode
+		// the larger children first, the stack pops the smallest key first
+		for i := len(node.children) - 1; i >= 0; i-- {
+			switch child := node.children[i]; {
+			case strings.HasPrefix(rest, child.prefix):
+				next = child
+			case child.prefix > rest:
+				it.stack = append(it.stack, radixTreeFrame{child, nodeKey + child.prefix})
+			}
+		}
+		if next != nil {
+			nodeKey += next.prefix
+		}
+		node = next
+	}
+}
+
+func (it *radixTreeIterator) Value() interface{} {
+	return it.current.node.value
+}
+
+func (it *radixTreeIterator) Key() interface{} {
+	return it.current.key
+}
+
+func (it *radixTreeIterator) Reset() {
+	it.stack, it.current, it.started = it.stack[:0], radixTreeFrame{}, false
+}
@@ -0,0 +1,388 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+// Package tries provides maps keyed by strings which also answer prefix queries.
+package tries
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aiwuTech/container"
+	"github.com/aiwuTech/container/maps"
+	"iter"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// a node is reached from its parent through the edge labelled prefix, its key is the
+// concatenation of the labels from the root; only the nodes with hasValue hold a key
+type radixNode struct {
+	prefix   string
+	value    interface{}
+	hasValue bool
+	children []*radixNode // sorted by the first byte of their prefix
+}
+
+// index of the child whose prefix starts with b, found tells if there is one
+func (node *radixNode) child(b byte) (index int, found bool) {
+	index = sort.Search(len(node.children), func(i int) bool {
+		return node.children[i].prefix[0] >= b
+	})
+	return index, index < len(node.children) && node.children[index].prefix[0] == b
+}
+
+// merges the only child into a node without value
+func (node *radixNode) mergeChild() {
+	child := node.children[0]
+	node.prefix += child.prefix
+	node.value, node.hasValue = child.value, child.hasValue
+	node.children = child.children
+}
+
+// RadixTree is a map from strings to elements on a compressed trie: the chains of nodes
+// with a single child are merged into one edge. Get, Put and Remove are O(len(key)),
+// whatever the number of keys, and the keys sharing a prefix are found together.
+// Iteration is in byte-wise lexicographic order of the keys.
+type RadixTree struct {
+	root     *radixNode
+	size     int
+	elemType reflect.Type
+	lock     *sync.Mutex
+	mods     int // counts the writes, which may split, merge or drop the nodes an iterator holds
+}
+
+var _ maps.MapInterface = &RadixTree{}
+
+var stringType = reflect.TypeOf("")
+
+func NewRadixTree(elemType reflect.Type) *RadixTree {
+	return &RadixTree{
+		root:     &radixNode{},
+		elemType: elemType,
+		lock:     &sync.Mutex{},
+	}
+}
+
+func (tree *RadixTree) isAcceptableElem(elem interface{}) bool {
+	return elem != nil && reflect.TypeOf(elem) == tree.elemType
+}
+
+// Returns the element of key, or nil. Key must be a string.
+func (tree *RadixTree) Get(key interface{}) interface{} {
+	k, ok := key.(string)
+	if !ok {
+		return nil
+	}
+
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	if node := tree.find(k); node != nil && node.hasValue {
+		return node.value
+	}
+	return nil
+}
+
+// Puts the element at key and returns the element it replaces, or nil.
+// Second return parameter is false if the key is not a string or elem is not of the element type.
+func (tree *RadixTree) Put(key interface{}, elem interface{}) (interface{}, bool) {
+	k, ok := key.(string)
+	if !ok || !tree.isAcceptableElem(elem) {
+		return nil, false
+	}
+
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	tree.mods++
+	node := tree.root
+	for {
+		if k == "" {
+			oldElem := node.value
+			if !node.hasValue {
+				tree.size++
+			}
+			node.value, node.hasValue = elem, true
+			return oldElem, true
+		}
+
+		index, found := node.child(k[0])
+		if !found {
+			node.children = insertAt(node.children, index, &radixNode{prefix: k, value: elem, hasValue: true})
+			tree.size++
+			return nil, true
+		}
+		child := node.children[index]
+		common := commonPrefixLen(child.prefix, k)
+		if common < len(child.prefix) {
+			// the key leaves the edge halfway, split it there
+			middle := &radixNode{prefix: child.prefix[:common], children: []*radixNode{child}}
+			child.prefix = child.prefix[common:]
+			node.children[index] = middle
+			child = middle
+		}
+		node, k = child, k[common:]
+	}
+}
+
+// Removes key and returns its element, or nil.
+func (tree *RadixTree) Remove(key interface{}) interface{} {
+	k, ok := key.(string)
+	if !ok {
+		return nil
+	}
+
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	var parent *radixNode
+	node := tree.root
+	for k != "" {
+		index, found := node.child(k[0])
+		if !found || !strings.HasPrefix(k, node.children[index].prefix) {
+			return nil
+		}
+		parent, node, k = node, node.children[index], k[len(node.children[index].prefix):]
+	}
+	if !node.hasValue {
+		return nil
+	}
+
+	oldElem := node.value
+	node.value, node.hasValue = nil, false
+	tree.size--
+	tree.mods++
+
+	// keep the tree compressed, the root is never merged
+	switch {
+	case parent == nil:
+	case len(node.children) == 0:
+		index, _ := parent.child(node.prefix[0])
+		parent.children = removeAt(parent.children, index)
+		if parent != tree.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case len(node.children) == 1:
+		node.mergeChild()
+	}
+	return oldElem
+}
+
+// Returns the longest key which is a prefix of s, and its element.
+// Third return parameter is false if no key is a prefix of s.
+func (tree *RadixTree) LongestPrefixMatch(s string) (key string, elem interface{}, ok bool) {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	node, consumed := tree.root, 0
+	for {
+		if node.hasValue {
+			key, elem, ok = s[:consumed], node.value, true
+		}
+		if consumed == len(s) {
+			return
+		}
+		index, found := node.child(s[consumed])
+		if !found || !strings.HasPrefix(s[consumed:], node.children[index].prefix) {
+			return
+		}
+		node = node.children[index]
+		consumed += len(node.prefix)
+	}
+}
+
+// Calls fn on the keys starting with prefix and their elements, in key order, until fn returns false.
+// Like the iterator the lock is not held during the calls, so fn may use the tree.
+func (tree *RadixTree) WalkPrefix(prefix string, fn func(key string, elem interface{}) bool) {
+	for key, elem := range container.Seq2(func() container.Iterator { return tree.PrefixIterator(prefix) }) {
+		if !fn(key.(string), elem) {
+			return
+		}
+	}
+}
+
+// Returns a stateful iterator over the keys starting with prefix, in key order.
+func (tree *RadixTree) PrefixIterator(prefix string) container.Iterator {
+	return container.SyncIterator(&radixTreeIterator{tree: tree, prefix: prefix}, tree.lock)
+}
+
+// Removes all keys.
+func (tree *RadixTree) Clear() {
+	tree.lock.Lock()
+	tree.root = &radixNode{}
+	tree.size = 0
+	tree.mods++
+	tree.lock.Unlock()
+}
+
+func (tree *RadixTree) Len() int {
+	tree.lock.Lock()
+	length := tree.size
+	tree.lock.Unlock()
+	return length
+}
+
+func (tree *RadixTree) Empty() bool {
+	return tree.Len() == 0
+}
+
+// whether all the keys are in the tree, O(len(key)) for each key
+func (tree *RadixTree) Contains(keys ...interface{}) bool {
+	tree.lock.Lock()
+	defer tree.lock.Unlock()
+	for _, key := range keys {
+		k, ok := key.(string)
+		if !ok {
+			return false
+		}
+		if node := tree.find(k); node == nil || !node.hasValue {
+			return false
+		}
+	}
+	return true
+}
+
+// keys in order
+func (tree *RadixTree) Keys() []interface{} {
+	keys := make([]interface{}, 0, tree.Len())
+	for key := range tree.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// elements in the order of their keys
+func (tree *RadixTree) Elements() []interface{} {
+	elems := make([]interface{}, 0, tree.Len())
+	for _, elem := range tree.All() {
+		elems = append(elems, elem)
+	}
+	return elems
+}
+
+func (tree *RadixTree) ToMap() map[interface{}]interface{} {
+	replica := make(map[interface{}]interface{}, tree.Len())
+	for key, elem := range tree.All() {
+		replica[key] = elem
+	}
+	return replica
+}
+
+// always string
+func (tree *RadixTree) KeyType() reflect.Type {
+	return stringType
+}
+
+func (tree *RadixTree) ElemType() reflect.Type {
+	return tree.elemType
+}
+
+// Returns a stateful iterator over the tree in key order, positioned before the first key.
+func (tree *RadixTree) Iterator() container.Iterator {
+	return tree.PrefixIterator("")
+}
+
+// Returns a range-over-func sequence of (key, element) pairs in key order.
+// The loop body may put and remove keys, the walk goes on with the key after the current one.
+func (tree *RadixTree) All() iter.Seq2[interface{}, interface{}] {
+	return container.Seq2(tree.Iterator)
+}
+
+// Returns a range-over-func sequence of the keys in order.
+func (tree *RadixTree) KeySeq() iter.Seq[interface{}] {
+	return container.KeySeq(tree.Iterator)
+}
+
+// Returns a range-over-func sequence of the elements in the order of their keys.
+func (tree *RadixTree) Values() iter.Seq[interface{}] {
+	return container.ValueSeq(tree.Iterator)
+}
+
+func (tree *RadixTree) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("RadixTree<string,")
+	buf.WriteString(tree.ElemType().Kind().String())
+	buf.WriteString(">{")
+	first := true
+	for key, elem := range tree.All() {
+		if first {
+			first = false
+		} else {
+			buf.WriteString(" ")
+		}
+
+		buf.WriteString(fmt.Sprintf("%v", key))
+		buf.WriteString(":")
+		buf.WriteString(fmt.Sprintf("%+v", elem))
+	}
+	buf.WriteString("}")
+
+	return buf.String()
+}
+
+// the node whose key is exactly key, or nil
+func (tree *RadixTree) find(key string) *radixNode {
+	node := tree.root
+	for key != "" {
+		index, found := node.child(key[0])
+		if !found || !strings.HasPrefix(key, node.children[index].prefix) {
+			return nil
+		}
+		node, key = node.children[index], key[len(node.children[index].prefix):]
+	}
+	return node
+}
+
+// the highest node whose keys all start with prefix, and its key; nil if there is none
+func (tree *RadixTree) subtree(prefix string) (*radixNode, string) {
+	node, key := tree.root, ""
+	for len(key) < len(prefix) {
+		rest := prefix[len(key):]
+		index, found := node.child(rest[0])
+		if !found {
+			return nil, ""
+		}
+		// the edge may go past the prefix, but must not leave it
+		child := node.children[index]
+		if common := commonPrefixLen(child.prefix, rest); common < len(rest) && common < len(child.prefix) {
+			return nil, ""
+		}
+		node, key = child, key+child.prefix
+	}
+	return node, key
+}
+
+func commonPrefixLen(a, b string) int {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return n
+}
+
+// inserts value at index, shifting the following ones
+func insertAt[T any](slice []T, index int, value T) []T {
+	var zero T
+	slice = append(slice, zero)
+	copy(slice[index+1:], slice[index:])
+	slice[index] = value
+	return slice
+}
+
+// removes the value at index, shifting the following ones
+func removeAt[T any](slice []T, index int) []T {
+	copy(slice[index:], slice[index+1:])
+	var zero T
+	slice[len(slice)-1] = zero
+	return slice[:len(slice)-1]
+}
@@ -0,0 +1,229 @@
+// Copyright 2015 mint.zhao.chiu@gmail.com
+//
+// Licensed under the Apache License, Version 2.0 (the "License"): you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+// License for the specific language governing permissions and limitations
+// under the License.
+package tries
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRadixTree(t *testing.T) {
+
+	tree := NewRadixTree(reflect.TypeOf(0))
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "r", ""} {
+		if _, ok := tree.Put(key, i); !ok {
+			t.Errorf("Got %v expected %v", ok, true)
+		}
+	}
+	if _, ok := tree.Put(1, 1); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if _, ok := tree.Put("x", "x"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+	if oldElem, _ := tree.Put("rubens", 10); oldElem != 3 {
+		t.Errorf("Got %v expected %v", oldElem, 3)
+	}
+
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), "[ r romane romanus romulus rubens ruber rubicon rubicundus]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if actualValue, expectedValue := tree.Len(), 9; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if elem := tree.Get("rubens"); elem != 10 {
+		t.Errorf("Got %v expected %v", elem, 10)
+	}
+	if elem := tree.Get("rom"); elem != nil {
+		t.Errorf("Got %v expected %v", elem, nil)
+	}
+	if !tree.Contains("r", "ruber") || tree.Contains("rub") || tree.Contains(1) {
+		t.Errorf("Contains error, got %v", tree)
+	}
+
+	keys := []string{}
+	tree.WalkPrefix("rub", func(key string, elem interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), "[rubens ruber rubicon rubicundus]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	keys = keys[:0]
+	tree.WalkPrefix("rubic", func(key string, elem interface{}) bool {
+		keys = append(keys, key)
+		return false
+	})
+	if actualValue, expectedValue := fmt.Sprint(keys), "[rubicon]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	tree.WalkPrefix("rubx", func(key string, elem interface{}) bool {
+		t.Errorf("Got %v expected no key", key)
+		return true
+	})
+
+	for s, expectedKey := range map[string]string{"romanesque": "romane", "rom": "r", "x": "", "rubicundus": "rubicundus"} {
+		if key, _, ok := tree.LongestPrefixMatch(s); key != expectedKey || !ok {
+			t.Errorf("LongestPrefixMatch(%v): got %v expected %v", s, key, expectedKey)
+		}
+	}
+
+	if oldElem := tree.Remove("ruber"); oldElem != 4 {
+		t.Errorf("Got %v expected %v", oldElem, 4)
+	}
+	if oldElem := tree.Remove("rub"); oldElem != nil {
+		t.Errorf("Got %v expected %v", oldElem, nil)
+	}
+	tree.Remove("")
+	if actualValue, expectedValue := tree.String(), "RadixTree<string,int>{r:7 romane:0 romanus:1 romulus:2 rubens:10 rubicon:5 rubicundus:6}"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	if _, _, ok := tree.LongestPrefixMatch("x"); ok {
+		t.Errorf("Got %v expected %v", ok, false)
+	}
+
+	tree.Clear()
+	if !tree.Empty() || len(tree.Keys()) != 0 {
+		t.Errorf("Got %v expected %v", tree, "empty tree")
+	}
+
+}
+
+func TestRadixTreeWriteDuringRange(t *testing.T) {
+
+	// removing "ab" merges "a" with its child "c" in place
+	tree := NewRadixTree(reflect.TypeOf(0))
+	for i, key := range []string{"0", "ab", "ac"} {
+		tree.Put(key, i)
+	}
+	var keys, elems []interface{}
+	for key, elem := range tree.All() {
+		keys, elems = append(keys, key), append(elems, elem)
+		if key == "0" {
+			tree.Remove("ab")
+		}
+	}
+	if actualValue, expectedValue := fmt.Sprint(keys, elems), "[0 ac] [0 2]"; actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+
+	// the loop body puts and removes the binary strings of odd numbers, splitting and merging
+	// the nodes of the even ones, which must all be seen once and in order
+	for _, prefix := range []string{"", "1", "10", "110"} {
+		tree := NewRadixTree(reflect.TypeOf(0))
+		expectedValue := []string{}
+		for n := 0; n < 200; n++ {
+			key := fmt.Sprintf("%b", n)
+			tree.Put(key, n)
+			if n%2 == 0 && strings.HasPrefix(key, prefix) {
+				expectedValue = append(expectedValue, key)
+			}
+		}
+		slices.Sort(expectedValue)
+
+		random := rand.New(rand.NewSource(1))
+		evens := []string{}
+		tree.WalkPrefix(prefix, func(key string, elem interface{}) bool {
+			if key[len(key)-1] == '0' {
+				evens = append(evens, key)
+			}
+			if !strings.HasPrefix(key, prefix) || elem != tree.Get(key) {
+				t.Errorf("Got %v expected %v", key, "a key starting with "+prefix)
+			}
+			for i := 0; i < 3; i++ {
+				n := 2*random.Intn(100) + 1
+				if random.Intn(2) == 0 {
+					tree.Put(fmt.Sprintf("%b", n), n)
+				} else {
+					tree.Remove(fmt.Sprintf("%b", n))
+				}
+			}
+			return true
+		})
+		if actualValue := fmt.Sprint(evens); actualValue != fmt.Sprint(expectedValue) {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+
+}
+
+func TestRadixTreeRandom(t *testing.T) {
+
+	tree := NewRadixTree(reflect.TypeOf(0))
+	reference := map[string]int{}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		// short keys on a small alphabet share many prefixes
+		key := make([]byte, random.Intn(6))
+		for j := range key {
+			key[j] = "abc"[random.Intn(3)]
+		}
+		if random.Intn(3) == 0 {
+			tree.Remove(string(key))
+			delete(reference, string(key))
+		} else {
+			tree.Put(string(key), i)
+			reference[string(key)] = i
+		}
+	}
+
+	keys := []string{}
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if actualValue, expectedValue := fmt.Sprint(tree.Keys()), fmt.Sprint(keys); actualValue != expectedValue {
+		t.Errorf("Got %v expected %v", actualValue, expectedValue)
+	}
+	for key, expectedValue := range reference {
+		if actualValue := tree.Get(key); actualValue != expectedValue {
+			t.Errorf("Got %v expected %v", actualValue, expectedValue)
+		}
+	}
+	checkRadixNode(t, tree.root, true)
+
+}
+
+// checks that the tree is compressed and the children sorted
+func checkRadixNode(t *testing.T, node *radixNode, root bool) {
+	if !root && (node.prefix == "" || !node.hasValue && len(node.children) < 2) {
+		t.Fatalf("Got uncompressed node %q with %v children", node.prefix, len(node.children))
+	}
+	for i, child := range node.children {
+		if i > 0 && child.prefix[0] <= node.children[i-1].prefix[0] {
+			t.Fatalf("Got child %q after %q", child.prefix, node.children[i-1].prefix)
+		}
+		checkRadixNode(t, child, false)
+	}
+}
+
+func BenchmarkRadixTree(b *testing.B) {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("/api/v1/users/%d/posts", i)
+	}
+	for i := 0; i < b.N; i++ {
+		tree := NewRadixTree(reflect.TypeOf(0))
+		for n, key := range keys {
+			tree.Put(key, n)
+		}
+		for _, key := range keys {
+			tree.Get(key)
+		}
+	}
+}